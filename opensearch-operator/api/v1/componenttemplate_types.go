@@ -0,0 +1,58 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// OpenSearchComponentTemplateSpec defines the desired state of OpenSearchComponentTemplate
+type OpenSearchComponentTemplateSpec struct {
+	// The cluster the template is applied to, must be in the same namespace
+	OpensearchRef corev1.LocalObjectReference `json:"opensearchCluster"`
+	// Name of the template in opensearch, defaults to the name of the resource
+	Name     string             `json:"name,omitempty"`
+	Version  *int64             `json:"version,omitempty"`
+	Template OpensearchTemplate `json:"template"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:resource:shortName=oscomponenttemplate
+//+kubebuilder:printcolumn:name="Cluster",type="string",JSONPath=".spec.opensearchCluster.name"
+//+kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase"
+// OpenSearchComponentTemplate is the Schema for the opensearchcomponenttemplates API
+type OpenSearchComponentTemplate struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   OpenSearchComponentTemplateSpec `json:"spec,omitempty"`
+	Status OpenSearchTemplateStatus        `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+// OpenSearchComponentTemplateList contains a list of OpenSearchComponentTemplate
+type OpenSearchComponentTemplateList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []OpenSearchComponentTemplate `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&OpenSearchComponentTemplate{}, &OpenSearchComponentTemplateList{})
+}
@@ -0,0 +1,91 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const (
+	TemplatePhasePending = "PENDING"
+	TemplatePhaseApplied = "APPLIED"
+	TemplatePhaseError   = "ERROR"
+)
+
+// OpensearchTemplate contains the settings, mappings and aliases applied to matching indices
+type OpensearchTemplate struct {
+	// Index settings, e.g. number_of_shards
+	//+kubebuilder:pruning:PreserveUnknownFields
+	Settings *runtime.RawExtension `json:"settings,omitempty"`
+	// Index mappings
+	//+kubebuilder:pruning:PreserveUnknownFields
+	Mappings *runtime.RawExtension `json:"mappings,omitempty"`
+	// Aliases added to the indices, keyed by alias name
+	//+kubebuilder:pruning:PreserveUnknownFields
+	Aliases *runtime.RawExtension `json:"aliases,omitempty"`
+}
+
+// OpenSearchIndexTemplateSpec defines the desired state of OpenSearchIndexTemplate
+type OpenSearchIndexTemplateSpec struct {
+	// The cluster the template is applied to, must be in the same namespace
+	OpensearchRef corev1.LocalObjectReference `json:"opensearchCluster"`
+	// Name of the template in opensearch, defaults to the name of the resource
+	Name string `json:"name,omitempty"`
+	//+kubebuilder:validation:MinItems=1
+	IndexPatterns []string `json:"indexPatterns"`
+	// Names of component templates the template is composed of, in order
+	ComposedOf []string           `json:"composedOf,omitempty"`
+	Priority   *int64             `json:"priority,omitempty"`
+	Version    *int64             `json:"version,omitempty"`
+	Template   OpensearchTemplate `json:"template,omitempty"`
+}
+
+// OpenSearchTemplateStatus defines the observed state of index and component templates
+type OpenSearchTemplateStatus struct {
+	Phase  string `json:"phase,omitempty"`
+	Reason string `json:"reason,omitempty"`
+	// Name of the template in opensearch that is managed by this resource
+	ExistingName string `json:"existingName,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:resource:shortName=osindextemplate
+//+kubebuilder:printcolumn:name="Cluster",type="string",JSONPath=".spec.opensearchCluster.name"
+//+kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase"
+// OpenSearchIndexTemplate is the Schema for the opensearchindextemplates API
+type OpenSearchIndexTemplate struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   OpenSearchIndexTemplateSpec `json:"spec,omitempty"`
+	Status OpenSearchTemplateStatus    `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+// OpenSearchIndexTemplateList contains a list of OpenSearchIndexTemplate
+type OpenSearchIndexTemplateList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []OpenSearchIndexTemplate `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&OpenSearchIndexTemplate{}, &OpenSearchIndexTemplateList{})
+}
@@ -0,0 +1,110 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const (
+	ISMPolicyPhasePending = "PENDING"
+	ISMPolicyPhaseApplied = "APPLIED"
+	ISMPolicyPhaseError   = "ERROR"
+)
+
+// ISMState is a state of an ISM policy with the actions executed when entering it
+type ISMState struct {
+	Name string `json:"name"`
+	// Actions executed in order, e.g. {"rollover": {"min_size": "50gb"}}
+	Actions []apiextensionsv1.JSON `json:"actions,omitempty"`
+	// Transitions to other states, evaluated in order
+	Transitions []ISMTransition `json:"transitions,omitempty"`
+}
+
+type ISMTransition struct {
+	StateName string `json:"stateName"`
+	// Conditions of the transition, e.g. {"min_index_age": "30d"}. Without conditions the transition happens immediately.
+	//+kubebuilder:pruning:PreserveUnknownFields
+	Conditions *runtime.RawExtension `json:"conditions,omitempty"`
+}
+
+// ISMTemplate automatically applies the policy to newly created indices
+type ISMTemplate struct {
+	//+kubebuilder:validation:MinItems=1
+	IndexPatterns []string `json:"indexPatterns"`
+	Priority      int64    `json:"priority,omitempty"`
+}
+
+// OpenSearchISMPolicySpec defines the desired state of OpenSearchISMPolicy
+type OpenSearchISMPolicySpec struct {
+	// The cluster the policy is applied to, must be in the same namespace
+	OpensearchRef corev1.LocalObjectReference `json:"opensearchCluster"`
+	// ID of the policy in opensearch, defaults to the name of the resource
+	PolicyID     string `json:"policyId,omitempty"`
+	Description  string `json:"description,omitempty"`
+	DefaultState string `json:"defaultState"`
+	//+kubebuilder:validation:MinItems=1
+	States      []ISMState    `json:"states"`
+	ISMTemplate []ISMTemplate `json:"ismTemplate,omitempty"`
+	//+kubebuilder:pruning:PreserveUnknownFields
+	ErrorNotification *runtime.RawExtension `json:"errorNotification,omitempty"`
+	// Patterns of existing indices the policy is attached to. Indices already managed by another policy are not changed.
+	ApplyToIndices []string `json:"applyToIndices,omitempty"`
+}
+
+// OpenSearchISMPolicyStatus defines the observed state of OpenSearchISMPolicy
+type OpenSearchISMPolicyStatus struct {
+	Phase  string `json:"phase,omitempty"`
+	Reason string `json:"reason,omitempty"`
+	// ID of the policy in opensearch that is managed by this resource
+	PolicyID string `json:"policyId,omitempty"`
+	// Sequence number and primary term of the policy document after it was last applied.
+	// A difference to the values in opensearch means the policy was changed outside of the operator.
+	SeqNo       int64 `json:"seqNo,omitempty"`
+	PrimaryTerm int64 `json:"primaryTerm,omitempty"`
+	// The generation of the resource that was last applied
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:resource:shortName=osismpolicy
+//+kubebuilder:printcolumn:name="Cluster",type="string",JSONPath=".spec.opensearchCluster.name"
+//+kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase"
+// OpenSearchISMPolicy is the Schema for the opensearchismpolicies API
+type OpenSearchISMPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   OpenSearchISMPolicySpec   `json:"spec,omitempty"`
+	Status OpenSearchISMPolicyStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+// OpenSearchISMPolicyList contains a list of OpenSearchISMPolicy
+type OpenSearchISMPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []OpenSearchISMPolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&OpenSearchISMPolicy{}, &OpenSearchISMPolicyList{})
+}
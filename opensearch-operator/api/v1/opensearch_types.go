@@ -24,6 +24,10 @@ import (
 const (
 	PhasePending = "PENDING"
 	PhaseRunning = "RUNNING"
+
+	RestoreStateRestoring = "Restoring"
+	RestoreStateFinished  = "Finished"
+	RestoreStateFailed    = "Failed"
 )
 
 // EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
@@ -46,18 +50,56 @@ type GeneralConfig struct {
 	DrainDataNodes bool `json:"drainDataNodes,omitempty"`
 }
 
+// NodeRole is a role of the nodes of a node pool. The cluster manager role is called master before OpenSearch 2.0, the
+// operator uses the name supported by the version of the cluster.
+// +kubebuilder:validation:Enum=cluster_manager;master;data;ingest;ml;remote_cluster_client;search
+type NodeRole string
+
 type NodePool struct {
 	Component        string                      `json:"component"`
 	Replicas         int32                       `json:"replicas"`
 	DiskSize         string                      `json:"diskSize,omitempty"`
 	Resources        corev1.ResourceRequirements `json:"resources,omitempty"`
 	Jvm              string                      `json:"jvm,omitempty"`
-	Roles            []string                    `json:"roles"`
+	// Roles of the nodes of the node pool
+	Roles            []NodeRole                  `json:"roles"`
 	Tolerations      []corev1.Toleration         `json:"tolerations,omitempty"`
 	NodeSelector     map[string]string           `json:"nodeSelector,omitempty"`
 	Affinity         *corev1.Affinity            `json:"affinity,omitempty"`
 	Persistence      *PersistenceConfig          `json:"persistence,omitempty"`
 	AdditionalConfig map[string]string           `json:"additionalConfig,omitempty"`
+	// Custom attributes of the nodes, e.g. temp: hot is set as node.attr.temp: hot and can be used for shard allocation filtering
+	Attributes map[string]string `json:"attributes,omitempty"`
+	// Autoscaling policy for the node pool, only used if confMgmt.autoScaler is enabled.
+	// The autoscaler changes replicas of the node pool in the spec.
+	AutoScaling *AutoScalingPolicy `json:"autoScaling,omitempty"`
+}
+
+// AutoScalingPolicy adjusts the replicas of a data node pool based on the resource usage of its nodes
+type AutoScalingPolicy struct {
+	//+kubebuilder:validation:Minimum=1
+	MinReplicas int32 `json:"minReplicas"`
+	//+kubebuilder:validation:Minimum=1
+	MaxReplicas int32 `json:"maxReplicas"`
+	// Add a node if the average usage of any resource is above its threshold
+	ScaleUp AutoScalingThresholds `json:"scaleUp,omitempty"`
+	// Remove a node if the average usage of all resources is below their thresholds
+	ScaleDown AutoScalingThresholds `json:"scaleDown,omitempty"`
+	// Minimum time between two scaling operations of the node pool, defaults to 10m
+	Cooldown *metav1.Duration `json:"cooldown,omitempty"`
+}
+
+// AutoScalingThresholds are usage limits in percent, unset thresholds are ignored
+type AutoScalingThresholds struct {
+	//+kubebuilder:validation:Minimum=0
+	//+kubebuilder:validation:Maximum=100
+	DiskPercent *int32 `json:"diskPercent,omitempty"`
+	//+kubebuilder:validation:Minimum=0
+	//+kubebuilder:validation:Maximum=100
+	CpuPercent *int32 `json:"cpuPercent,omitempty"`
+	//+kubebuilder:validation:Minimum=0
+	//+kubebuilder:validation:Maximum=100
+	JvmHeapPercent *int32 `json:"jvmHeapPercent,omitempty"`
 }
 
 // PersistencConfig defines options for data persistence
@@ -82,6 +124,19 @@ type ConfMgmt struct {
 	Monitoring  bool `json:"monitoring,omitempty"`
 	VerUpdate   bool `json:"VerUpdate,omitempty"`
 	SmartScaler bool `json:"smartScaler,omitempty"`
+	// Options for the metrics exporter, only used if monitoring is enabled
+	MonitoringConfig *MonitoringConfig `json:"monitoringConfig,omitempty"`
+}
+
+// MonitoringConfig defines the prometheus exporter sidecar and the ServiceMonitor created for it
+type MonitoringConfig struct {
+	// Image of the exporter sidecar, defaults to the prometheus community elasticsearch exporter
+	ExporterImage string                      `json:"exporterImage,omitempty"`
+	Resources     corev1.ResourceRequirements `json:"resources,omitempty"`
+	// Interval in which the exporter is scraped, e.g. 30s
+	ScrapeInterval string `json:"scrapeInterval,omitempty"`
+	// Additional labels for the ServiceMonitor, e.g. to match the serviceMonitorSelector of the prometheus instance
+	ServiceMonitorLabels map[string]string `json:"serviceMonitorLabels,omitempty"`
 }
 
 type DashboardsConfig struct {
@@ -115,6 +170,8 @@ type Security struct {
 type TlsConfig struct {
 	Transport *TlsConfigTransport `json:"transport,omitempty"`
 	Http      *TlsConfigHttp      `json:"http,omitempty"`
+	// Changing this value starts a rotation of the CA generated by the operator, e.g. set it to the current date
+	RotateCa string `json:"rotateCa,omitempty"`
 }
 
 type TlsConfigTransport struct {
@@ -140,6 +197,48 @@ type TlsCertificateConfig struct {
 	Secret corev1.LocalObjectReference `json:"secret,omitempty"`
 	// Optional, secret that contains the ca certificate as ca.crt. If this and generate=true is set the existing CA cert from that secret is used to generate the node certs. In this case must contain ca.crt and ca.key fields
 	CaSecret corev1.LocalObjectReference `json:"caSecret,omitempty"`
+	// Validity of generated certificates, defaults to one year
+	Duration *metav1.Duration `json:"duration,omitempty"`
+	// Generated certificates are re-issued when they expire within this time, defaults to 30 days
+	RenewBefore *metav1.Duration `json:"renewBefore,omitempty"`
+	// Optional, issue the certificates with cert-manager instead of generating them. Takes precedence over generate
+	CertManager *CertManagerConfig `json:"certManager,omitempty"`
+	// Private key of generated certificates, defaults to RSA with 4096 bits
+	PrivateKey *TlsPrivateKeyConfig `json:"privateKey,omitempty"`
+	// Additional subject fields of generated certificates
+	Subject *TlsSubjectConfig `json:"subject,omitempty"`
+	// Additional IP addresses to add as SANs to generated certificates
+	IPAddresses []string `json:"ipAddresses,omitempty"`
+}
+
+// Configure the private key of generated certificates
+type TlsPrivateKeyConfig struct {
+	// +kubebuilder:validation:Enum=RSA;ECDSA
+	Algorithm string `json:"algorithm,omitempty"`
+	// Size of the key in bits for RSA (at least 2048, defaults to 4096) or the curve size for ECDSA (256 or 384, defaults to 256)
+	Size int `json:"size,omitempty"`
+}
+
+// Subject fields of generated certificates, the common name and organizational unit are set by the operator
+type TlsSubjectConfig struct {
+	Organizations []string `json:"organizations,omitempty"`
+	Countries     []string `json:"countries,omitempty"`
+	Localities    []string `json:"localities,omitempty"`
+}
+
+// Configure certificates issued by cert-manager
+type CertManagerConfig struct {
+	// Issuer or ClusterIssuer that signs the certificates
+	IssuerRef CertManagerIssuerRef `json:"issuerRef"`
+}
+
+// Reference to a cert-manager issuer
+type CertManagerIssuerRef struct {
+	Name string `json:"name"`
+	// Kind of the issuer, Issuer or ClusterIssuer. Defaults to Issuer
+	Kind string `json:"kind,omitempty"`
+	// API group of the issuer, defaults to cert-manager.io
+	Group string `json:"group,omitempty"`
 }
 
 // Reference to a secret
@@ -157,6 +256,40 @@ type SecurityConfig struct {
 	AdminCredentialsSecret corev1.LocalObjectReference `json:"adminCredentialsSecret,omitempty"`
 }
 
+// ClusterReference references an OpenSearchCluster, possibly in another namespace
+type ClusterReference struct {
+	Name string `json:"name"`
+	// Defaults to the namespace of the referencing resource
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// RemoteCluster is added to the remote clusters of the cluster, either cluster or seeds must be set
+type RemoteCluster struct {
+	// Alias of the remote cluster used in searches, e.g. <alias>:logs-*
+	Alias string `json:"alias"`
+	// A cluster managed by the operator, both clusters are configured to trust each other
+	Cluster *ClusterReference `json:"cluster,omitempty"`
+	// Transport addresses of a cluster not managed by the operator, e.g. opensearch.example.com:9300
+	Seeds []string `json:"seeds,omitempty"`
+}
+
+// RestoreConfig defines a snapshot that is restored into the cluster once it is initialized
+type RestoreConfig struct {
+	Repository SnapshotRepository `json:"repository"`
+	// Name of the snapshot to restore, changing it triggers a new restore
+	Snapshot string `json:"snapshot"`
+	// Indices to restore, defaults to all indices except the security index
+	Indices []string `json:"indices,omitempty"`
+	// Restore the cluster state from the snapshot
+	IncludeGlobalState bool `json:"includeGlobalState,omitempty"`
+	// Regular expression used to rename restored indices, e.g. (.+)
+	RenamePattern string `json:"renamePattern,omitempty"`
+	// Replacement for renamed indices, e.g. restored-$1
+	RenameReplacement string `json:"renameReplacement,omitempty"`
+	// A restore that did not finish within the timeout is marked as failed, defaults to 24h
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+}
+
 type ImageSpec struct {
 	Image            *string                       `json:"image,omitempty"`
 	ImagePullPolicy  *corev1.PullPolicy            `json:"imagePullPolicy,omitempty"`
@@ -172,16 +305,133 @@ type ClusterSpec struct {
 	Dashboards DashboardsConfig `json:"dashboards,omitempty"`
 	Security   *Security        `json:"security,omitempty"`
 	NodePools  []NodePool       `json:"nodePools"`
+	Restore    *RestoreConfig   `json:"restore,omitempty"`
+	// Remote clusters that can be queried using cross-cluster search
+	RemoteClusters []RemoteCluster `json:"remoteClusters,omitempty"`
+	// Dynamic persistent cluster settings, e.g. indices.recovery.max_bytes_per_sec: 100mb. Removed settings are reset to their defaults.
+	ClusterSettings map[string]string `json:"clusterSettings,omitempty"`
+	// Spread the nodes and the copies of every shard across zones
+	ZoneAwareness *ZoneAwareness `json:"zoneAwareness,omitempty"`
+}
+
+// ZoneAwareness sets the zone of the Kubernetes node as node.attr.zone and allocates the copies of a shard in different zones
+type ZoneAwareness struct {
+	// Label of the Kubernetes nodes with their zone, defaults to topology.kubernetes.io/zone
+	TopologyKey string `json:"topologyKey,omitempty"`
+	// If set, replicas of the zones that are not available stay unassigned instead of being allocated in the remaining zones
+	ForcedZones []string `json:"forcedZones,omitempty"`
+	// Maximum difference of the number of nodes of a node pool between two zones, defaults to 1
+	//+kubebuilder:validation:Minimum=1
+	MaxSkew int32 `json:"maxSkew,omitempty"`
+	// DoNotSchedule or ScheduleAnyway, defaults to DoNotSchedule
+	WhenUnsatisfiable corev1.UnsatisfiableConstraintAction `json:"whenUnsatisfiable,omitempty"`
 }
 
 // ClusterStatus defines the observed state of Es
 type ClusterStatus struct {
 	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
 	// Important: Run "make" to regenerate code after modifying this file
-	Phase            string            `json:"phase,omitempty"`
-	ComponentsStatus []ComponentStatus `json:"componentsStatus"`
-	Version          string            `json:"version,omitempty"`
-	Initialized      bool              `json:"initialized,omitempty"`
+	Phase            string             `json:"phase,omitempty"`
+	ComponentsStatus []ComponentStatus  `json:"componentsStatus"`
+	Version          string             `json:"version,omitempty"`
+	Initialized      bool               `json:"initialized,omitempty"`
+	Restore          *RestoreStatus     `json:"restore,omitempty"`
+	AutoScaler       []AutoScalerStatus `json:"autoScaler,omitempty"`
+	// Expiry of the certificates generated by the operator
+	Certificates []CertificateStatus `json:"certificates,omitempty"`
+	CaRotation   *CaRotationStatus   `json:"caRotation,omitempty"`
+	// Remote clusters configured by the operator
+	RemoteClusters []RemoteClusterStatus `json:"remoteClusters,omitempty"`
+	ClusterSettings *ClusterSettingsStatus `json:"clusterSettings,omitempty"`
+	// Node pools whose statefulset was replaced to change their storage
+	StorageMigrations []StorageMigrationStatus `json:"storageMigrations,omitempty"`
+}
+
+// StorageMigrationStatus tracks the statefulset of a node pool that was replaced to change its storage class or access modes
+type StorageMigrationStatus struct {
+	Component string `json:"component"`
+	// The statefulset with the current storage of the node pool
+	StatefulSet string `json:"statefulSet"`
+	// The statefulset with the previous storage, its nodes are drained and it is removed once the new statefulset is
+	// ready. Empty once the migration is done.
+	Source string `json:"source,omitempty"`
+}
+
+// ClusterSettingsStatus tracks the persistent cluster settings managed by the operator
+type ClusterSettingsStatus struct {
+	// Settings applied by the operator with their values
+	Applied map[string]string `json:"applied,omitempty"`
+	// Settings that were changed outside of the operator when drift was last detected, they were re-applied
+	Drift         []ClusterSettingDrift `json:"drift,omitempty"`
+	LastDriftTime *metav1.Time          `json:"lastDriftTime,omitempty"`
+	// Error of the last attempt to apply the settings
+	Reason string `json:"reason,omitempty"`
+}
+
+type ClusterSettingDrift struct {
+	Name     string `json:"name"`
+	Expected string `json:"expected"`
+	// Value found in the cluster, empty if the setting was reset
+	Actual string `json:"actual,omitempty"`
+}
+
+// RemoteClusterStatus is the connection state of a remote cluster
+type RemoteClusterStatus struct {
+	Alias     string   `json:"alias"`
+	Seeds     []string `json:"seeds,omitempty"`
+	Connected bool     `json:"connected,omitempty"`
+}
+
+// CertificateStatus tracks the expiry and renewal of a generated certificate
+type CertificateStatus struct {
+	// Name of the certificate, e.g. transport, http or admin
+	Name   string `json:"name"`
+	Secret string `json:"secret"`
+	// Expiry of the certificate, for per node certificates the earliest expiry of all nodes
+	NotAfter        *metav1.Time `json:"notAfter,omitempty"`
+	LastRenewalTime *metav1.Time `json:"lastRenewalTime,omitempty"`
+}
+
+const (
+	// Nodes are restarted to trust both the old and the new CA
+	CaRotationPhaseTrusting = "Trusting"
+	// Certificates are re-issued from the new CA and nodes restarted to use them
+	CaRotationPhaseReissuing = "Reissuing"
+	// The old CA is removed from the trust bundle and nodes restarted a last time
+	CaRotationPhaseFinalizing = "Finalizing"
+	CaRotationPhaseCompleted  = "Completed"
+)
+
+// CaRotationStatus tracks the rotation of the CA generated by the operator
+type CaRotationStatus struct {
+	Phase string `json:"phase,omitempty"`
+	// Value of rotateCa the last rotation was started for
+	Token string `json:"token,omitempty"`
+	// Nodes started before this time have not yet been restarted for the current phase
+	PhaseStartTime *metav1.Time `json:"phaseStartTime,omitempty"`
+}
+
+// AutoScalerStatus records the last scaling operation of the autoscaler for a node pool
+type AutoScalerStatus struct {
+	Component       string       `json:"component"`
+	LastScaleTime   *metav1.Time `json:"lastScaleTime,omitempty"`
+	LastScaleReason string       `json:"lastScaleReason,omitempty"`
+}
+
+// RestoreStatus tracks the restore of a snapshot into the cluster
+type RestoreStatus struct {
+	Snapshot  string               `json:"snapshot"`
+	State     string               `json:"state"`
+	Reason    string               `json:"reason,omitempty"`
+	StartTime *metav1.Time         `json:"startTime,omitempty"`
+	Indices   []IndexRestoreStatus `json:"indices,omitempty"`
+}
+
+// IndexRestoreStatus is the recovery state of a single index being restored
+type IndexRestoreStatus struct {
+	Index   string `json:"index"`
+	Stage   string `json:"stage"`
+	Percent string `json:"percent,omitempty"`
 }
 
 //+kubebuilder:object:root=true
@@ -0,0 +1,115 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	ReplicationPhasePending = "PENDING"
+	ReplicationPhaseRunning = "RUNNING"
+	ReplicationPhaseError   = "ERROR"
+)
+
+// ReplicationRule is an auto-follow rule that replicates all leader indices matching the pattern
+type ReplicationRule struct {
+	// Name of the rule in the follower cluster, must be unique in the follower cluster
+	Name string `json:"name"`
+	// Leader indices matching the pattern are replicated into follower indices with the same name, e.g. logs-*
+	Pattern string `json:"pattern"`
+}
+
+// ReplicationRoles are the security roles used to replicate the indices
+type ReplicationRoles struct {
+	LeaderClusterRole   string `json:"leaderClusterRole"`
+	FollowerClusterRole string `json:"followerClusterRole"`
+}
+
+// OpenSearchReplicationSpec defines the desired state of OpenSearchReplication
+type OpenSearchReplicationSpec struct {
+	// The follower cluster the indices are replicated to, must be in the same namespace
+	OpensearchRef corev1.LocalObjectReference `json:"opensearchCluster"`
+	// The leader cluster the indices are replicated from
+	LeaderCluster ClusterReference `json:"leaderCluster"`
+	// Alias of the leader cluster in the remote cluster settings of the follower cluster, defaults to the name of the leader cluster
+	ConnectionAlias string `json:"connectionAlias,omitempty"`
+	// Removing a rule stops the replication of the indices replicated by it, the follower indices become regular indices
+	Rules []ReplicationRule `json:"rules,omitempty"`
+	// Roles used for the replication, default to all_access in both clusters
+	UseRoles *ReplicationRoles `json:"useRoles,omitempty"`
+}
+
+// ReplicationRuleStatus contains the statistics of an auto-follow rule
+type ReplicationRuleStatus struct {
+	Name    string `json:"name"`
+	Pattern string `json:"pattern"`
+	// Number of indices the replication was started for
+	ReplicatedIndices int64 `json:"replicatedIndices,omitempty"`
+	// Leader indices the replication could not be started for
+	FailedIndices []string `json:"failedIndices,omitempty"`
+}
+
+// ReplicationIndexStatus contains the replication status of a follower index
+type ReplicationIndexStatus struct {
+	Index       string `json:"index"`
+	LeaderIndex string `json:"leaderIndex,omitempty"`
+	// One of BOOTSTRAPPING, SYNCING, PAUSED or REPLICATION NOT IN PROGRESS
+	Status string `json:"status"`
+	Reason string `json:"reason,omitempty"`
+}
+
+// OpenSearchReplicationStatus defines the observed state of OpenSearchReplication
+type OpenSearchReplicationStatus struct {
+	Phase  string `json:"phase,omitempty"`
+	Reason string `json:"reason,omitempty"`
+	// Alias of the leader cluster that is configured in the follower cluster
+	ConnectionAlias string `json:"connectionAlias,omitempty"`
+	// If the follower cluster is connected to the leader cluster
+	Connected bool                     `json:"connected,omitempty"`
+	Rules     []ReplicationRuleStatus  `json:"rules,omitempty"`
+	Indices   []ReplicationIndexStatus `json:"indices,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:resource:shortName=osreplication
+//+kubebuilder:printcolumn:name="Follower",type="string",JSONPath=".spec.opensearchCluster.name"
+//+kubebuilder:printcolumn:name="Leader",type="string",JSONPath=".spec.leaderCluster.name"
+//+kubebuilder:printcolumn:name="Connected",type="boolean",JSONPath=".status.connected"
+//+kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase"
+// OpenSearchReplication is the Schema for the opensearchreplications API
+type OpenSearchReplication struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   OpenSearchReplicationSpec   `json:"spec,omitempty"`
+	Status OpenSearchReplicationStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+// OpenSearchReplicationList contains a list of OpenSearchReplication
+type OpenSearchReplicationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []OpenSearchReplication `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&OpenSearchReplication{}, &OpenSearchReplicationList{})
+}
@@ -0,0 +1,163 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	SecurityPhasePending = "PENDING"
+	SecurityPhaseApplied = "APPLIED"
+	SecurityPhaseError   = "ERROR"
+)
+
+// OpenSearchSecurityStatus defines the observed state of users, roles and role mappings
+type OpenSearchSecurityStatus struct {
+	Phase  string `json:"phase,omitempty"`
+	Reason string `json:"reason,omitempty"`
+	// Name of the resource in the security plugin that is managed by this resource
+	ExistingName string `json:"existingName,omitempty"`
+	// Checksum of the password that was last applied, only used by users
+	PasswordChecksum string `json:"passwordChecksum,omitempty"`
+}
+
+// OpenSearchUserSpec defines the desired state of OpenSearchUser
+type OpenSearchUserSpec struct {
+	// The cluster the user is created in, must be in the same namespace
+	OpensearchRef corev1.LocalObjectReference `json:"opensearchCluster"`
+	// Name of the user, defaults to the name of the resource
+	Name string `json:"name,omitempty"`
+	// Secret key containing the password of the user
+	PasswordFrom            corev1.SecretKeySelector `json:"passwordFrom"`
+	BackendRoles            []string                 `json:"backendRoles,omitempty"`
+	OpendistroSecurityRoles []string                 `json:"opendistroSecurityRoles,omitempty"`
+	Attributes              map[string]string        `json:"attributes,omitempty"`
+	Description             string                   `json:"description,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:resource:shortName=osuser
+//+kubebuilder:printcolumn:name="Cluster",type="string",JSONPath=".spec.opensearchCluster.name"
+//+kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase"
+// OpenSearchUser is the Schema for the opensearchusers API
+type OpenSearchUser struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   OpenSearchUserSpec       `json:"spec,omitempty"`
+	Status OpenSearchSecurityStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+// OpenSearchUserList contains a list of OpenSearchUser
+type OpenSearchUserList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []OpenSearchUser `json:"items"`
+}
+
+type IndexPermissions struct {
+	IndexPatterns  []string `json:"indexPatterns,omitempty"`
+	AllowedActions []string `json:"allowedActions,omitempty"`
+	// Document level security query
+	Dls string `json:"dls,omitempty"`
+	// Field level security, prefix fields with ~ to exclude them
+	Fls          []string `json:"fls,omitempty"`
+	MaskedFields []string `json:"maskedFields,omitempty"`
+}
+
+type TenantPermissions struct {
+	TenantPatterns []string `json:"tenantPatterns,omitempty"`
+	AllowedActions []string `json:"allowedActions,omitempty"`
+}
+
+// OpenSearchRoleSpec defines the desired state of OpenSearchRole
+type OpenSearchRoleSpec struct {
+	// The cluster the role is created in, must be in the same namespace
+	OpensearchRef corev1.LocalObjectReference `json:"opensearchCluster"`
+	// Name of the role, defaults to the name of the resource
+	Name               string              `json:"name,omitempty"`
+	ClusterPermissions []string            `json:"clusterPermissions,omitempty"`
+	IndexPermissions   []IndexPermissions  `json:"indexPermissions,omitempty"`
+	TenantPermissions  []TenantPermissions `json:"tenantPermissions,omitempty"`
+	Description        string              `json:"description,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:resource:shortName=osrole
+//+kubebuilder:printcolumn:name="Cluster",type="string",JSONPath=".spec.opensearchCluster.name"
+//+kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase"
+// OpenSearchRole is the Schema for the opensearchroles API
+type OpenSearchRole struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   OpenSearchRoleSpec       `json:"spec,omitempty"`
+	Status OpenSearchSecurityStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+// OpenSearchRoleList contains a list of OpenSearchRole
+type OpenSearchRoleList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []OpenSearchRole `json:"items"`
+}
+
+// OpenSearchRoleMappingSpec defines the desired state of OpenSearchRoleMapping
+type OpenSearchRoleMappingSpec struct {
+	// The cluster the role mapping is created in, must be in the same namespace
+	OpensearchRef corev1.LocalObjectReference `json:"opensearchCluster"`
+	// Name of the role that is mapped, defaults to the name of the resource
+	Role         string   `json:"role,omitempty"`
+	Users        []string `json:"users,omitempty"`
+	BackendRoles []string `json:"backendRoles,omitempty"`
+	Hosts        []string `json:"hosts,omitempty"`
+	Description  string   `json:"description,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:resource:shortName=osrolemapping
+//+kubebuilder:printcolumn:name="Cluster",type="string",JSONPath=".spec.opensearchCluster.name"
+//+kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase"
+// OpenSearchRoleMapping is the Schema for the opensearchrolemappings API
+type OpenSearchRoleMapping struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   OpenSearchRoleMappingSpec `json:"spec,omitempty"`
+	Status OpenSearchSecurityStatus  `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+// OpenSearchRoleMappingList contains a list of OpenSearchRoleMapping
+type OpenSearchRoleMappingList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []OpenSearchRoleMapping `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&OpenSearchUser{}, &OpenSearchUserList{})
+	SchemeBuilder.Register(&OpenSearchRole{}, &OpenSearchRoleList{})
+	SchemeBuilder.Register(&OpenSearchRoleMapping{}, &OpenSearchRoleMappingList{})
+}
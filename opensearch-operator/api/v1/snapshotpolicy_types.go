@@ -0,0 +1,105 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	SnapshotPolicyPhasePending = "PENDING"
+	SnapshotPolicyPhaseRunning = "RUNNING"
+	SnapshotPolicyPhaseError   = "ERROR"
+)
+
+// SnapshotRepository describes a snapshot repository that should be registered in the cluster
+type SnapshotRepository struct {
+	// Name of the repository inside opensearch
+	Name string `json:"name"`
+	// Type of the repository, fs requires path.repo to be configured on all nodes, s3 requires the repository-s3 plugin
+	//+kubebuilder:validation:Enum=fs;s3
+	Type string `json:"type"`
+	// Repository settings, e.g. location for fs or bucket, endpoint and path_style_access for S3 compatible stores like MinIO
+	Settings map[string]string `json:"settings,omitempty"`
+}
+
+// SnapshotRetention controls which snapshots taken by the policy are deleted again
+type SnapshotRetention struct {
+	// Maximum number of snapshots to keep
+	MaxCount *int32 `json:"maxCount,omitempty"`
+	// Maximum age of snapshots to keep, e.g. 168h
+	MaxAge *metav1.Duration `json:"maxAge,omitempty"`
+}
+
+// OpenSearchSnapshotPolicySpec defines the desired state of OpenSearchSnapshotPolicy
+type OpenSearchSnapshotPolicySpec struct {
+	// The cluster the snapshots are taken from, must be in the same namespace
+	OpensearchRef corev1.LocalObjectReference `json:"opensearchCluster"`
+	Repository    SnapshotRepository          `json:"repository"`
+	// Cron expression (standard 5 field format) when snapshots are taken
+	Schedule string `json:"schedule"`
+	// Prefix for the names of the snapshots, defaults to the name of the policy
+	SnapshotPrefix string `json:"snapshotPrefix,omitempty"`
+	// Indices to include in the snapshots, defaults to all indices
+	Indices []string `json:"indices,omitempty"`
+	// Include the cluster state in the snapshots
+	IncludeGlobalState bool              `json:"includeGlobalState,omitempty"`
+	Retention          SnapshotRetention `json:"retention,omitempty"`
+	// Suspend taking new snapshots, retention is still applied
+	Suspend bool `json:"suspend,omitempty"`
+}
+
+// OpenSearchSnapshotPolicyStatus defines the observed state of OpenSearchSnapshotPolicy
+type OpenSearchSnapshotPolicyStatus struct {
+	Phase                string       `json:"phase,omitempty"`
+	Reason               string       `json:"reason,omitempty"`
+	RepositoryRegistered bool         `json:"repositoryRegistered,omitempty"`
+	LastSnapshotName     string       `json:"lastSnapshotName,omitempty"`
+	LastSnapshotState    string       `json:"lastSnapshotState,omitempty"`
+	LastSnapshotTime     *metav1.Time `json:"lastSnapshotTime,omitempty"`
+	NextSnapshotTime     *metav1.Time `json:"nextSnapshotTime,omitempty"`
+	// Number of snapshots of this policy currently stored in the repository
+	Snapshots int32 `json:"snapshots,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:resource:shortName=ossnapshotpolicy
+//+kubebuilder:printcolumn:name="Cluster",type="string",JSONPath=".spec.opensearchCluster.name"
+//+kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase"
+//+kubebuilder:printcolumn:name="Last snapshot",type="string",JSONPath=".status.lastSnapshotName"
+// OpenSearchSnapshotPolicy is the Schema for the opensearchsnapshotpolicies API
+type OpenSearchSnapshotPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   OpenSearchSnapshotPolicySpec   `json:"spec,omitempty"`
+	Status OpenSearchSnapshotPolicyStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+// OpenSearchSnapshotPolicyList contains a list of OpenSearchSnapshotPolicy
+type OpenSearchSnapshotPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []OpenSearchSnapshotPolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&OpenSearchSnapshotPolicy{}, &OpenSearchSnapshotPolicyList{})
+}
@@ -23,14 +23,221 @@ package v1
 
 import (
 	corev1 "k8s.io/api/core/v1"
-	runtime "k8s.io/apimachinery/pkg/runtime"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoScalerStatus) DeepCopyInto(out *AutoScalerStatus) {
+	*out = *in
+	if in.LastScaleTime != nil {
+		in, out := &in.LastScaleTime, &out.LastScaleTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoScalerStatus.
+func (in *AutoScalerStatus) DeepCopy() *AutoScalerStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoScalerStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoScalingPolicy) DeepCopyInto(out *AutoScalingPolicy) {
+	*out = *in
+	in.ScaleUp.DeepCopyInto(&out.ScaleUp)
+	in.ScaleDown.DeepCopyInto(&out.ScaleDown)
+	if in.Cooldown != nil {
+		in, out := &in.Cooldown, &out.Cooldown
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoScalingPolicy.
+func (in *AutoScalingPolicy) DeepCopy() *AutoScalingPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoScalingPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoScalingThresholds) DeepCopyInto(out *AutoScalingThresholds) {
+	*out = *in
+	if in.DiskPercent != nil {
+		in, out := &in.DiskPercent, &out.DiskPercent
+		*out = new(int32)
+		**out = **in
+	}
+	if in.CpuPercent != nil {
+		in, out := &in.CpuPercent, &out.CpuPercent
+		*out = new(int32)
+		**out = **in
+	}
+	if in.JvmHeapPercent != nil {
+		in, out := &in.JvmHeapPercent, &out.JvmHeapPercent
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoScalingThresholds.
+func (in *AutoScalingThresholds) DeepCopy() *AutoScalingThresholds {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoScalingThresholds)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CaRotationStatus) DeepCopyInto(out *CaRotationStatus) {
+	*out = *in
+	if in.PhaseStartTime != nil {
+		in, out := &in.PhaseStartTime, &out.PhaseStartTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CaRotationStatus.
+func (in *CaRotationStatus) DeepCopy() *CaRotationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CaRotationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertManagerConfig) DeepCopyInto(out *CertManagerConfig) {
+	*out = *in
+	out.IssuerRef = in.IssuerRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertManagerConfig.
+func (in *CertManagerConfig) DeepCopy() *CertManagerConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(CertManagerConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertManagerIssuerRef) DeepCopyInto(out *CertManagerIssuerRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertManagerIssuerRef.
+func (in *CertManagerIssuerRef) DeepCopy() *CertManagerIssuerRef {
+	if in == nil {
+		return nil
+	}
+	out := new(CertManagerIssuerRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertificateStatus) DeepCopyInto(out *CertificateStatus) {
+	*out = *in
+	if in.NotAfter != nil {
+		in, out := &in.NotAfter, &out.NotAfter
+		*out = (*in).DeepCopy()
+	}
+	if in.LastRenewalTime != nil {
+		in, out := &in.LastRenewalTime, &out.LastRenewalTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateStatus.
+func (in *CertificateStatus) DeepCopy() *CertificateStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CertificateStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterReference) DeepCopyInto(out *ClusterReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterReference.
+func (in *ClusterReference) DeepCopy() *ClusterReference {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterSettingDrift) DeepCopyInto(out *ClusterSettingDrift) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterSettingDrift.
+func (in *ClusterSettingDrift) DeepCopy() *ClusterSettingDrift {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterSettingDrift)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterSettingsStatus) DeepCopyInto(out *ClusterSettingsStatus) {
+	*out = *in
+	if in.Applied != nil {
+		in, out := &in.Applied, &out.Applied
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Drift != nil {
+		in, out := &in.Drift, &out.Drift
+		*out = make([]ClusterSettingDrift, len(*in))
+		copy(*out, *in)
+	}
+	if in.LastDriftTime != nil {
+		in, out := &in.LastDriftTime, &out.LastDriftTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterSettingsStatus.
+func (in *ClusterSettingsStatus) DeepCopy() *ClusterSettingsStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterSettingsStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterSpec) DeepCopyInto(out *ClusterSpec) {
 	*out = *in
 	in.General.DeepCopyInto(&out.General)
-	out.ConfMgmt = in.ConfMgmt
+	in.ConfMgmt.DeepCopyInto(&out.ConfMgmt)
 	in.Dashboards.DeepCopyInto(&out.Dashboards)
 	if in.Security != nil {
 		in, out := &in.Security, &out.Security
@@ -44,6 +251,30 @@ func (in *ClusterSpec) DeepCopyInto(out *ClusterSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Restore != nil {
+		in, out := &in.Restore, &out.Restore
+		*out = new(RestoreConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RemoteClusters != nil {
+		in, out := &in.RemoteClusters, &out.RemoteClusters
+		*out = make([]RemoteCluster, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ClusterSettings != nil {
+		in, out := &in.ClusterSettings, &out.ClusterSettings
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ZoneAwareness != nil {
+		in, out := &in.ZoneAwareness, &out.ZoneAwareness
+		*out = new(ZoneAwareness)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterSpec.
@@ -64,6 +295,47 @@ func (in *ClusterStatus) DeepCopyInto(out *ClusterStatus) {
 		*out = make([]ComponentStatus, len(*in))
 		copy(*out, *in)
 	}
+	if in.Restore != nil {
+		in, out := &in.Restore, &out.Restore
+		*out = new(RestoreStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AutoScaler != nil {
+		in, out := &in.AutoScaler, &out.AutoScaler
+		*out = make([]AutoScalerStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Certificates != nil {
+		in, out := &in.Certificates, &out.Certificates
+		*out = make([]CertificateStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.CaRotation != nil {
+		in, out := &in.CaRotation, &out.CaRotation
+		*out = new(CaRotationStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RemoteClusters != nil {
+		in, out := &in.RemoteClusters, &out.RemoteClusters
+		*out = make([]RemoteClusterStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ClusterSettings != nil {
+		in, out := &in.ClusterSettings, &out.ClusterSettings
+		*out = new(ClusterSettingsStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.StorageMigrations != nil {
+		in, out := &in.StorageMigrations, &out.StorageMigrations
+		*out = make([]StorageMigrationStatus, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterStatus.
@@ -94,6 +366,11 @@ func (in *ComponentStatus) DeepCopy() *ComponentStatus {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ConfMgmt) DeepCopyInto(out *ConfMgmt) {
 	*out = *in
+	if in.MonitoringConfig != nil {
+		in, out := &in.MonitoringConfig, &out.MonitoringConfig
+		*out = new(MonitoringConfig)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfMgmt.
@@ -113,7 +390,7 @@ func (in *DashboardsConfig) DeepCopyInto(out *DashboardsConfig) {
 	if in.Tls != nil {
 		in, out := &in.Tls, &out.Tls
 		*out = new(DashboardsTlsConfig)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.AdditionalConfig != nil {
 		in, out := &in.AdditionalConfig, &out.AdditionalConfig
@@ -138,7 +415,7 @@ func (in *DashboardsConfig) DeepCopy() *DashboardsConfig {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DashboardsTlsConfig) DeepCopyInto(out *DashboardsTlsConfig) {
 	*out = *in
-	out.CertificateConfig = in.CertificateConfig
+	in.CertificateConfig.DeepCopyInto(&out.CertificateConfig)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DashboardsTlsConfig.
@@ -178,214 +455,1374 @@ func (in *GeneralConfig) DeepCopy() *GeneralConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(GeneralConfig)
+	out := new(GeneralConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ISMState) DeepCopyInto(out *ISMState) {
+	*out = *in
+	if in.Actions != nil {
+		in, out := &in.Actions, &out.Actions
+		*out = make([]apiextensionsv1.JSON, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Transitions != nil {
+		in, out := &in.Transitions, &out.Transitions
+		*out = make([]ISMTransition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ISMState.
+func (in *ISMState) DeepCopy() *ISMState {
+	if in == nil {
+		return nil
+	}
+	out := new(ISMState)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ISMTemplate) DeepCopyInto(out *ISMTemplate) {
+	*out = *in
+	if in.IndexPatterns != nil {
+		in, out := &in.IndexPatterns, &out.IndexPatterns
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ISMTemplate.
+func (in *ISMTemplate) DeepCopy() *ISMTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(ISMTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ISMTransition) DeepCopyInto(out *ISMTransition) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = new(runtime.RawExtension)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ISMTransition.
+func (in *ISMTransition) DeepCopy() *ISMTransition {
+	if in == nil {
+		return nil
+	}
+	out := new(ISMTransition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageSpec) DeepCopyInto(out *ImageSpec) {
+	*out = *in
+	if in.Image != nil {
+		in, out := &in.Image, &out.Image
+		*out = new(string)
+		**out = **in
+	}
+	if in.ImagePullPolicy != nil {
+		in, out := &in.ImagePullPolicy, &out.ImagePullPolicy
+		*out = new(corev1.PullPolicy)
+		**out = **in
+	}
+	if in.ImagePullSecrets != nil {
+		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
+		*out = make([]corev1.LocalObjectReference, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageSpec.
+func (in *ImageSpec) DeepCopy() *ImageSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IndexPermissions) DeepCopyInto(out *IndexPermissions) {
+	*out = *in
+	if in.IndexPatterns != nil {
+		in, out := &in.IndexPatterns, &out.IndexPatterns
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllowedActions != nil {
+		in, out := &in.AllowedActions, &out.AllowedActions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Fls != nil {
+		in, out := &in.Fls, &out.Fls
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MaskedFields != nil {
+		in, out := &in.MaskedFields, &out.MaskedFields
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IndexPermissions.
+func (in *IndexPermissions) DeepCopy() *IndexPermissions {
+	if in == nil {
+		return nil
+	}
+	out := new(IndexPermissions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IndexRestoreStatus) DeepCopyInto(out *IndexRestoreStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IndexRestoreStatus.
+func (in *IndexRestoreStatus) DeepCopy() *IndexRestoreStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(IndexRestoreStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MonitoringConfig) DeepCopyInto(out *MonitoringConfig) {
+	*out = *in
+	in.Resources.DeepCopyInto(&out.Resources)
+	if in.ServiceMonitorLabels != nil {
+		in, out := &in.ServiceMonitorLabels, &out.ServiceMonitorLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MonitoringConfig.
+func (in *MonitoringConfig) DeepCopy() *MonitoringConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(MonitoringConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodePool) DeepCopyInto(out *NodePool) {
+	*out = *in
+	in.Resources.DeepCopyInto(&out.Resources)
+	if in.Roles != nil {
+		in, out := &in.Roles, &out.Roles
+		*out = make([]NodeRole, len(*in))
+		copy(*out, *in)
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]corev1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Affinity != nil {
+		in, out := &in.Affinity, &out.Affinity
+		*out = new(corev1.Affinity)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Persistence != nil {
+		in, out := &in.Persistence, &out.Persistence
+		*out = new(PersistenceConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AdditionalConfig != nil {
+		in, out := &in.AdditionalConfig, &out.AdditionalConfig
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Attributes != nil {
+		in, out := &in.Attributes, &out.Attributes
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.AutoScaling != nil {
+		in, out := &in.AutoScaling, &out.AutoScaling
+		*out = new(AutoScalingPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodePool.
+func (in *NodePool) DeepCopy() *NodePool {
+	if in == nil {
+		return nil
+	}
+	out := new(NodePool)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenSearchCluster) DeepCopyInto(out *OpenSearchCluster) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenSearchCluster.
+func (in *OpenSearchCluster) DeepCopy() *OpenSearchCluster {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenSearchCluster)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *OpenSearchCluster) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenSearchClusterList) DeepCopyInto(out *OpenSearchClusterList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]OpenSearchCluster, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenSearchClusterList.
+func (in *OpenSearchClusterList) DeepCopy() *OpenSearchClusterList {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenSearchClusterList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *OpenSearchClusterList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenSearchComponentTemplate) DeepCopyInto(out *OpenSearchComponentTemplate) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	out.Status = in.Status
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenSearchComponentTemplate.
+func (in *OpenSearchComponentTemplate) DeepCopy() *OpenSearchComponentTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenSearchComponentTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *OpenSearchComponentTemplate) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenSearchComponentTemplateList) DeepCopyInto(out *OpenSearchComponentTemplateList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]OpenSearchComponentTemplate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenSearchComponentTemplateList.
+func (in *OpenSearchComponentTemplateList) DeepCopy() *OpenSearchComponentTemplateList {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenSearchComponentTemplateList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *OpenSearchComponentTemplateList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenSearchComponentTemplateSpec) DeepCopyInto(out *OpenSearchComponentTemplateSpec) {
+	*out = *in
+	out.OpensearchRef = in.OpensearchRef
+	if in.Version != nil {
+		in, out := &in.Version, &out.Version
+		*out = new(int64)
+		**out = **in
+	}
+	in.Template.DeepCopyInto(&out.Template)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenSearchComponentTemplateSpec.
+func (in *OpenSearchComponentTemplateSpec) DeepCopy() *OpenSearchComponentTemplateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenSearchComponentTemplateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenSearchISMPolicy) DeepCopyInto(out *OpenSearchISMPolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	out.Status = in.Status
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenSearchISMPolicy.
+func (in *OpenSearchISMPolicy) DeepCopy() *OpenSearchISMPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenSearchISMPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *OpenSearchISMPolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenSearchISMPolicyList) DeepCopyInto(out *OpenSearchISMPolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]OpenSearchISMPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenSearchISMPolicyList.
+func (in *OpenSearchISMPolicyList) DeepCopy() *OpenSearchISMPolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenSearchISMPolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *OpenSearchISMPolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenSearchISMPolicySpec) DeepCopyInto(out *OpenSearchISMPolicySpec) {
+	*out = *in
+	out.OpensearchRef = in.OpensearchRef
+	if in.States != nil {
+		in, out := &in.States, &out.States
+		*out = make([]ISMState, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ISMTemplate != nil {
+		in, out := &in.ISMTemplate, &out.ISMTemplate
+		*out = make([]ISMTemplate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ErrorNotification != nil {
+		in, out := &in.ErrorNotification, &out.ErrorNotification
+		*out = new(runtime.RawExtension)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ApplyToIndices != nil {
+		in, out := &in.ApplyToIndices, &out.ApplyToIndices
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenSearchISMPolicySpec.
+func (in *OpenSearchISMPolicySpec) DeepCopy() *OpenSearchISMPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenSearchISMPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenSearchISMPolicyStatus) DeepCopyInto(out *OpenSearchISMPolicyStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenSearchISMPolicyStatus.
+func (in *OpenSearchISMPolicyStatus) DeepCopy() *OpenSearchISMPolicyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenSearchISMPolicyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenSearchIndexTemplate) DeepCopyInto(out *OpenSearchIndexTemplate) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	out.Status = in.Status
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenSearchIndexTemplate.
+func (in *OpenSearchIndexTemplate) DeepCopy() *OpenSearchIndexTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenSearchIndexTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *OpenSearchIndexTemplate) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenSearchIndexTemplateList) DeepCopyInto(out *OpenSearchIndexTemplateList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]OpenSearchIndexTemplate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenSearchIndexTemplateList.
+func (in *OpenSearchIndexTemplateList) DeepCopy() *OpenSearchIndexTemplateList {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenSearchIndexTemplateList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *OpenSearchIndexTemplateList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenSearchIndexTemplateSpec) DeepCopyInto(out *OpenSearchIndexTemplateSpec) {
+	*out = *in
+	out.OpensearchRef = in.OpensearchRef
+	if in.IndexPatterns != nil {
+		in, out := &in.IndexPatterns, &out.IndexPatterns
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ComposedOf != nil {
+		in, out := &in.ComposedOf, &out.ComposedOf
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Priority != nil {
+		in, out := &in.Priority, &out.Priority
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Version != nil {
+		in, out := &in.Version, &out.Version
+		*out = new(int64)
+		**out = **in
+	}
+	in.Template.DeepCopyInto(&out.Template)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenSearchIndexTemplateSpec.
+func (in *OpenSearchIndexTemplateSpec) DeepCopy() *OpenSearchIndexTemplateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenSearchIndexTemplateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenSearchReplication) DeepCopyInto(out *OpenSearchReplication) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenSearchReplication.
+func (in *OpenSearchReplication) DeepCopy() *OpenSearchReplication {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenSearchReplication)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *OpenSearchReplication) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenSearchReplicationList) DeepCopyInto(out *OpenSearchReplicationList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]OpenSearchReplication, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenSearchReplicationList.
+func (in *OpenSearchReplicationList) DeepCopy() *OpenSearchReplicationList {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenSearchReplicationList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *OpenSearchReplicationList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenSearchReplicationSpec) DeepCopyInto(out *OpenSearchReplicationSpec) {
+	*out = *in
+	out.OpensearchRef = in.OpensearchRef
+	out.LeaderCluster = in.LeaderCluster
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]ReplicationRule, len(*in))
+		copy(*out, *in)
+	}
+	if in.UseRoles != nil {
+		in, out := &in.UseRoles, &out.UseRoles
+		*out = new(ReplicationRoles)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenSearchReplicationSpec.
+func (in *OpenSearchReplicationSpec) DeepCopy() *OpenSearchReplicationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenSearchReplicationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenSearchReplicationStatus) DeepCopyInto(out *OpenSearchReplicationStatus) {
+	*out = *in
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]ReplicationRuleStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Indices != nil {
+		in, out := &in.Indices, &out.Indices
+		*out = make([]ReplicationIndexStatus, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenSearchReplicationStatus.
+func (in *OpenSearchReplicationStatus) DeepCopy() *OpenSearchReplicationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenSearchReplicationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenSearchRole) DeepCopyInto(out *OpenSearchRole) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	out.Status = in.Status
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenSearchRole.
+func (in *OpenSearchRole) DeepCopy() *OpenSearchRole {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenSearchRole)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *OpenSearchRole) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenSearchRoleList) DeepCopyInto(out *OpenSearchRoleList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]OpenSearchRole, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenSearchRoleList.
+func (in *OpenSearchRoleList) DeepCopy() *OpenSearchRoleList {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenSearchRoleList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *OpenSearchRoleList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenSearchRoleMapping) DeepCopyInto(out *OpenSearchRoleMapping) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	out.Status = in.Status
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenSearchRoleMapping.
+func (in *OpenSearchRoleMapping) DeepCopy() *OpenSearchRoleMapping {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenSearchRoleMapping)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *OpenSearchRoleMapping) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenSearchRoleMappingList) DeepCopyInto(out *OpenSearchRoleMappingList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]OpenSearchRoleMapping, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenSearchRoleMappingList.
+func (in *OpenSearchRoleMappingList) DeepCopy() *OpenSearchRoleMappingList {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenSearchRoleMappingList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *OpenSearchRoleMappingList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenSearchRoleMappingSpec) DeepCopyInto(out *OpenSearchRoleMappingSpec) {
+	*out = *in
+	out.OpensearchRef = in.OpensearchRef
+	if in.Users != nil {
+		in, out := &in.Users, &out.Users
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.BackendRoles != nil {
+		in, out := &in.BackendRoles, &out.BackendRoles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Hosts != nil {
+		in, out := &in.Hosts, &out.Hosts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenSearchRoleMappingSpec.
+func (in *OpenSearchRoleMappingSpec) DeepCopy() *OpenSearchRoleMappingSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenSearchRoleMappingSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenSearchRoleSpec) DeepCopyInto(out *OpenSearchRoleSpec) {
+	*out = *in
+	out.OpensearchRef = in.OpensearchRef
+	if in.ClusterPermissions != nil {
+		in, out := &in.ClusterPermissions, &out.ClusterPermissions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.IndexPermissions != nil {
+		in, out := &in.IndexPermissions, &out.IndexPermissions
+		*out = make([]IndexPermissions, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.TenantPermissions != nil {
+		in, out := &in.TenantPermissions, &out.TenantPermissions
+		*out = make([]TenantPermissions, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenSearchRoleSpec.
+func (in *OpenSearchRoleSpec) DeepCopy() *OpenSearchRoleSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenSearchRoleSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenSearchSecurityStatus) DeepCopyInto(out *OpenSearchSecurityStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenSearchSecurityStatus.
+func (in *OpenSearchSecurityStatus) DeepCopy() *OpenSearchSecurityStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenSearchSecurityStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenSearchSnapshotPolicy) DeepCopyInto(out *OpenSearchSnapshotPolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenSearchSnapshotPolicy.
+func (in *OpenSearchSnapshotPolicy) DeepCopy() *OpenSearchSnapshotPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenSearchSnapshotPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *OpenSearchSnapshotPolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenSearchSnapshotPolicyList) DeepCopyInto(out *OpenSearchSnapshotPolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]OpenSearchSnapshotPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenSearchSnapshotPolicyList.
+func (in *OpenSearchSnapshotPolicyList) DeepCopy() *OpenSearchSnapshotPolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenSearchSnapshotPolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *OpenSearchSnapshotPolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenSearchSnapshotPolicySpec) DeepCopyInto(out *OpenSearchSnapshotPolicySpec) {
+	*out = *in
+	out.OpensearchRef = in.OpensearchRef
+	in.Repository.DeepCopyInto(&out.Repository)
+	if in.Indices != nil {
+		in, out := &in.Indices, &out.Indices
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.Retention.DeepCopyInto(&out.Retention)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenSearchSnapshotPolicySpec.
+func (in *OpenSearchSnapshotPolicySpec) DeepCopy() *OpenSearchSnapshotPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenSearchSnapshotPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenSearchSnapshotPolicyStatus) DeepCopyInto(out *OpenSearchSnapshotPolicyStatus) {
+	*out = *in
+	if in.LastSnapshotTime != nil {
+		in, out := &in.LastSnapshotTime, &out.LastSnapshotTime
+		*out = (*in).DeepCopy()
+	}
+	if in.NextSnapshotTime != nil {
+		in, out := &in.NextSnapshotTime, &out.NextSnapshotTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenSearchSnapshotPolicyStatus.
+func (in *OpenSearchSnapshotPolicyStatus) DeepCopy() *OpenSearchSnapshotPolicyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenSearchSnapshotPolicyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenSearchTemplateStatus) DeepCopyInto(out *OpenSearchTemplateStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenSearchTemplateStatus.
+func (in *OpenSearchTemplateStatus) DeepCopy() *OpenSearchTemplateStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenSearchTemplateStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenSearchUser) DeepCopyInto(out *OpenSearchUser) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	out.Status = in.Status
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenSearchUser.
+func (in *OpenSearchUser) DeepCopy() *OpenSearchUser {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenSearchUser)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *OpenSearchUser) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenSearchUserList) DeepCopyInto(out *OpenSearchUserList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]OpenSearchUser, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenSearchUserList.
+func (in *OpenSearchUserList) DeepCopy() *OpenSearchUserList {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenSearchUserList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *OpenSearchUserList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenSearchUserSpec) DeepCopyInto(out *OpenSearchUserSpec) {
+	*out = *in
+	out.OpensearchRef = in.OpensearchRef
+	in.PasswordFrom.DeepCopyInto(&out.PasswordFrom)
+	if in.BackendRoles != nil {
+		in, out := &in.BackendRoles, &out.BackendRoles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.OpendistroSecurityRoles != nil {
+		in, out := &in.OpendistroSecurityRoles, &out.OpendistroSecurityRoles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Attributes != nil {
+		in, out := &in.Attributes, &out.Attributes
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenSearchUserSpec.
+func (in *OpenSearchUserSpec) DeepCopy() *OpenSearchUserSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenSearchUserSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpensearchTemplate) DeepCopyInto(out *OpensearchTemplate) {
+	*out = *in
+	if in.Settings != nil {
+		in, out := &in.Settings, &out.Settings
+		*out = new(runtime.RawExtension)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Mappings != nil {
+		in, out := &in.Mappings, &out.Mappings
+		*out = new(runtime.RawExtension)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Aliases != nil {
+		in, out := &in.Aliases, &out.Aliases
+		*out = new(runtime.RawExtension)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpensearchTemplate.
+func (in *OpensearchTemplate) DeepCopy() *OpensearchTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(OpensearchTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PVCSource) DeepCopyInto(out *PVCSource) {
+	*out = *in
+	if in.AccessModes != nil {
+		in, out := &in.AccessModes, &out.AccessModes
+		*out = make([]corev1.PersistentVolumeAccessMode, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PVCSource.
+func (in *PVCSource) DeepCopy() *PVCSource {
+	if in == nil {
+		return nil
+	}
+	out := new(PVCSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PersistenceConfig) DeepCopyInto(out *PersistenceConfig) {
+	*out = *in
+	in.PersistenceSource.DeepCopyInto(&out.PersistenceSource)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PersistenceConfig.
+func (in *PersistenceConfig) DeepCopy() *PersistenceConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(PersistenceConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PersistenceSource) DeepCopyInto(out *PersistenceSource) {
+	*out = *in
+	if in.PVC != nil {
+		in, out := &in.PVC, &out.PVC
+		*out = new(PVCSource)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.EmptyDir != nil {
+		in, out := &in.EmptyDir, &out.EmptyDir
+		*out = new(corev1.EmptyDirVolumeSource)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HostPath != nil {
+		in, out := &in.HostPath, &out.HostPath
+		*out = new(corev1.HostPathVolumeSource)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PersistenceSource.
+func (in *PersistenceSource) DeepCopy() *PersistenceSource {
+	if in == nil {
+		return nil
+	}
+	out := new(PersistenceSource)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ImageSpec) DeepCopyInto(out *ImageSpec) {
+func (in *RemoteCluster) DeepCopyInto(out *RemoteCluster) {
 	*out = *in
-	if in.Image != nil {
-		in, out := &in.Image, &out.Image
-		*out = new(string)
-		**out = **in
-	}
-	if in.ImagePullPolicy != nil {
-		in, out := &in.ImagePullPolicy, &out.ImagePullPolicy
-		*out = new(corev1.PullPolicy)
+	if in.Cluster != nil {
+		in, out := &in.Cluster, &out.Cluster
+		*out = new(ClusterReference)
 		**out = **in
 	}
-	if in.ImagePullSecrets != nil {
-		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
-		*out = make([]corev1.LocalObjectReference, len(*in))
+	if in.Seeds != nil {
+		in, out := &in.Seeds, &out.Seeds
+		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageSpec.
-func (in *ImageSpec) DeepCopy() *ImageSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteCluster.
+func (in *RemoteCluster) DeepCopy() *RemoteCluster {
 	if in == nil {
 		return nil
 	}
-	out := new(ImageSpec)
+	out := new(RemoteCluster)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NodePool) DeepCopyInto(out *NodePool) {
+func (in *RemoteClusterStatus) DeepCopyInto(out *RemoteClusterStatus) {
 	*out = *in
-	in.Resources.DeepCopyInto(&out.Resources)
-	if in.Roles != nil {
-		in, out := &in.Roles, &out.Roles
+	if in.Seeds != nil {
+		in, out := &in.Seeds, &out.Seeds
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
-	if in.Tolerations != nil {
-		in, out := &in.Tolerations, &out.Tolerations
-		*out = make([]corev1.Toleration, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.NodeSelector != nil {
-		in, out := &in.NodeSelector, &out.NodeSelector
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
-	if in.Affinity != nil {
-		in, out := &in.Affinity, &out.Affinity
-		*out = new(corev1.Affinity)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.Persistence != nil {
-		in, out := &in.Persistence, &out.Persistence
-		*out = new(PersistenceConfig)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.AdditionalConfig != nil {
-		in, out := &in.AdditionalConfig, &out.AdditionalConfig
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodePool.
-func (in *NodePool) DeepCopy() *NodePool {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteClusterStatus.
+func (in *RemoteClusterStatus) DeepCopy() *RemoteClusterStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(NodePool)
+	out := new(RemoteClusterStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OpenSearchCluster) DeepCopyInto(out *OpenSearchCluster) {
+func (in *ReplicationIndexStatus) DeepCopyInto(out *ReplicationIndexStatus) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenSearchCluster.
-func (in *OpenSearchCluster) DeepCopy() *OpenSearchCluster {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplicationIndexStatus.
+func (in *ReplicationIndexStatus) DeepCopy() *ReplicationIndexStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(OpenSearchCluster)
+	out := new(ReplicationIndexStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *OpenSearchCluster) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OpenSearchClusterList) DeepCopyInto(out *OpenSearchClusterList) {
+func (in *ReplicationRoles) DeepCopyInto(out *ReplicationRoles) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]OpenSearchCluster, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenSearchClusterList.
-func (in *OpenSearchClusterList) DeepCopy() *OpenSearchClusterList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplicationRoles.
+func (in *ReplicationRoles) DeepCopy() *ReplicationRoles {
 	if in == nil {
 		return nil
 	}
-	out := new(OpenSearchClusterList)
+	out := new(ReplicationRoles)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *OpenSearchClusterList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReplicationRule) DeepCopyInto(out *ReplicationRule) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplicationRule.
+func (in *ReplicationRule) DeepCopy() *ReplicationRule {
+	if in == nil {
+		return nil
 	}
-	return nil
+	out := new(ReplicationRule)
+	in.DeepCopyInto(out)
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PVCSource) DeepCopyInto(out *PVCSource) {
+func (in *ReplicationRuleStatus) DeepCopyInto(out *ReplicationRuleStatus) {
 	*out = *in
-	if in.AccessModes != nil {
-		in, out := &in.AccessModes, &out.AccessModes
-		*out = make([]corev1.PersistentVolumeAccessMode, len(*in))
+	if in.FailedIndices != nil {
+		in, out := &in.FailedIndices, &out.FailedIndices
+		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PVCSource.
-func (in *PVCSource) DeepCopy() *PVCSource {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplicationRuleStatus.
+func (in *ReplicationRuleStatus) DeepCopy() *ReplicationRuleStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(PVCSource)
+	out := new(ReplicationRuleStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PersistenceConfig) DeepCopyInto(out *PersistenceConfig) {
+func (in *RestoreConfig) DeepCopyInto(out *RestoreConfig) {
 	*out = *in
-	in.PersistenceSource.DeepCopyInto(&out.PersistenceSource)
+	in.Repository.DeepCopyInto(&out.Repository)
+	if in.Indices != nil {
+		in, out := &in.Indices, &out.Indices
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PersistenceConfig.
-func (in *PersistenceConfig) DeepCopy() *PersistenceConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestoreConfig.
+func (in *RestoreConfig) DeepCopy() *RestoreConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(PersistenceConfig)
+	out := new(RestoreConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PersistenceSource) DeepCopyInto(out *PersistenceSource) {
+func (in *RestoreStatus) DeepCopyInto(out *RestoreStatus) {
 	*out = *in
-	if in.PVC != nil {
-		in, out := &in.PVC, &out.PVC
-		*out = new(PVCSource)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.EmptyDir != nil {
-		in, out := &in.EmptyDir, &out.EmptyDir
-		*out = new(corev1.EmptyDirVolumeSource)
-		(*in).DeepCopyInto(*out)
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
 	}
-	if in.HostPath != nil {
-		in, out := &in.HostPath, &out.HostPath
-		*out = new(corev1.HostPathVolumeSource)
-		(*in).DeepCopyInto(*out)
+	if in.Indices != nil {
+		in, out := &in.Indices, &out.Indices
+		*out = make([]IndexRestoreStatus, len(*in))
+		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PersistenceSource.
-func (in *PersistenceSource) DeepCopy() *PersistenceSource {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestoreStatus.
+func (in *RestoreStatus) DeepCopy() *RestoreStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(PersistenceSource)
+	out := new(RestoreStatus)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -433,11 +1870,128 @@ func (in *SecurityConfig) DeepCopy() *SecurityConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SnapshotRepository) DeepCopyInto(out *SnapshotRepository) {
+	*out = *in
+	if in.Settings != nil {
+		in, out := &in.Settings, &out.Settings
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SnapshotRepository.
+func (in *SnapshotRepository) DeepCopy() *SnapshotRepository {
+	if in == nil {
+		return nil
+	}
+	out := new(SnapshotRepository)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SnapshotRetention) DeepCopyInto(out *SnapshotRetention) {
+	*out = *in
+	if in.MaxCount != nil {
+		in, out := &in.MaxCount, &out.MaxCount
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxAge != nil {
+		in, out := &in.MaxAge, &out.MaxAge
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SnapshotRetention.
+func (in *SnapshotRetention) DeepCopy() *SnapshotRetention {
+	if in == nil {
+		return nil
+	}
+	out := new(SnapshotRetention)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StorageMigrationStatus) DeepCopyInto(out *StorageMigrationStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StorageMigrationStatus.
+func (in *StorageMigrationStatus) DeepCopy() *StorageMigrationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(StorageMigrationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TenantPermissions) DeepCopyInto(out *TenantPermissions) {
+	*out = *in
+	if in.TenantPatterns != nil {
+		in, out := &in.TenantPatterns, &out.TenantPatterns
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllowedActions != nil {
+		in, out := &in.AllowedActions, &out.AllowedActions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantPermissions.
+func (in *TenantPermissions) DeepCopy() *TenantPermissions {
+	if in == nil {
+		return nil
+	}
+	out := new(TenantPermissions)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TlsCertificateConfig) DeepCopyInto(out *TlsCertificateConfig) {
 	*out = *in
 	out.Secret = in.Secret
 	out.CaSecret = in.CaSecret
+	if in.Duration != nil {
+		in, out := &in.Duration, &out.Duration
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.RenewBefore != nil {
+		in, out := &in.RenewBefore, &out.RenewBefore
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.CertManager != nil {
+		in, out := &in.CertManager, &out.CertManager
+		*out = new(CertManagerConfig)
+		**out = **in
+	}
+	if in.PrivateKey != nil {
+		in, out := &in.PrivateKey, &out.PrivateKey
+		*out = new(TlsPrivateKeyConfig)
+		**out = **in
+	}
+	if in.Subject != nil {
+		in, out := &in.Subject, &out.Subject
+		*out = new(TlsSubjectConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.IPAddresses != nil {
+		in, out := &in.IPAddresses, &out.IPAddresses
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TlsCertificateConfig.
@@ -461,7 +2015,7 @@ func (in *TlsConfig) DeepCopyInto(out *TlsConfig) {
 	if in.Http != nil {
 		in, out := &in.Http, &out.Http
 		*out = new(TlsConfigHttp)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 }
 
@@ -478,7 +2032,7 @@ func (in *TlsConfig) DeepCopy() *TlsConfig {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TlsConfigHttp) DeepCopyInto(out *TlsConfigHttp) {
 	*out = *in
-	out.CertificateConfig = in.CertificateConfig
+	in.CertificateConfig.DeepCopyInto(&out.CertificateConfig)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TlsConfigHttp.
@@ -494,7 +2048,7 @@ func (in *TlsConfigHttp) DeepCopy() *TlsConfigHttp {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TlsConfigTransport) DeepCopyInto(out *TlsConfigTransport) {
 	*out = *in
-	out.CertificateConfig = in.CertificateConfig
+	in.CertificateConfig.DeepCopyInto(&out.CertificateConfig)
 	if in.NodesDn != nil {
 		in, out := &in.NodesDn, &out.NodesDn
 		*out = make([]string, len(*in))
@@ -517,6 +2071,21 @@ func (in *TlsConfigTransport) DeepCopy() *TlsConfigTransport {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TlsPrivateKeyConfig) DeepCopyInto(out *TlsPrivateKeyConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TlsPrivateKeyConfig.
+func (in *TlsPrivateKeyConfig) DeepCopy() *TlsPrivateKeyConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(TlsPrivateKeyConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TlsSecret) DeepCopyInto(out *TlsSecret) {
 	*out = *in
@@ -536,3 +2105,53 @@ func (in *TlsSecret) DeepCopy() *TlsSecret {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TlsSubjectConfig) DeepCopyInto(out *TlsSubjectConfig) {
+	*out = *in
+	if in.Organizations != nil {
+		in, out := &in.Organizations, &out.Organizations
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Countries != nil {
+		in, out := &in.Countries, &out.Countries
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Localities != nil {
+		in, out := &in.Localities, &out.Localities
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TlsSubjectConfig.
+func (in *TlsSubjectConfig) DeepCopy() *TlsSubjectConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(TlsSubjectConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ZoneAwareness) DeepCopyInto(out *ZoneAwareness) {
+	*out = *in
+	if in.ForcedZones != nil {
+		in, out := &in.ForcedZones, &out.ForcedZones
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ZoneAwareness.
+func (in *ZoneAwareness) DeepCopy() *ZoneAwareness {
+	if in == nil {
+		return nil
+	}
+	out := new(ZoneAwareness)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -83,18 +83,25 @@ var _ = Describe("Cluster Reconciler", func() {
 					defer GinkgoRecover()
 					defer wg.Done()
 					sts := &appsv1.StatefulSet{}
-					Eventually(func() []corev1.EnvVar {
+					Eventually(func() []corev1.VolumeMount {
 						if err := k8sClient.Get(context.Background(), types.NamespacedName{
 							Namespace: OpensearchCluster.Namespace,
 							Name:      clusterName + "-" + nodePool.Component,
 						}, sts); err != nil {
-							return []corev1.EnvVar{}
+							return []corev1.VolumeMount{}
 						}
-						return sts.Spec.Template.Spec.Containers[0].Env
-					}, timeout, interval).Should(ContainElement(corev1.EnvVar{
-						Name:  "foo",
-						Value: "bar",
+						return sts.Spec.Template.Spec.Containers[0].VolumeMounts
+					}, timeout, interval).Should(ContainElement(corev1.VolumeMount{
+						Name:      "config",
+						MountPath: "/usr/share/opensearch/config/opensearch.yml",
+						SubPath:   "opensearch-" + nodePool.Component + ".yml",
 					}))
+					configMap := corev1.ConfigMap{}
+					Expect(k8sClient.Get(context.Background(), types.NamespacedName{
+						Namespace: OpensearchCluster.Namespace,
+						Name:      clusterName + "-config",
+					}, &configMap)).To(Succeed())
+					Expect(configMap.Data["opensearch-"+nodePool.Component+".yml"]).To(ContainSubstring("foo: bar\n"))
 					Expect(sts.Spec.Template.Spec.Containers[0].Resources.Limits.Cpu().String()).To(Equal("500m"))
 					Expect(sts.Spec.Template.Spec.Containers[0].Resources.Limits.Memory().String()).To(Equal("2Gi"))
 				}(nodePool)
@@ -103,17 +110,15 @@ var _ = Describe("Cluster Reconciler", func() {
 		})
 
 		It("should set nodepool specific config", func() {
-			sts := &appsv1.StatefulSet{}
+			configMap := corev1.ConfigMap{}
 			Eventually(func() error {
 				return k8sClient.Get(context.Background(), types.NamespacedName{
-					Name:      fmt.Sprintf("%s-client", OpensearchCluster.Name),
+					Name:      fmt.Sprintf("%s-config", OpensearchCluster.Name),
 					Namespace: OpensearchCluster.Namespace,
-				}, sts)
+				}, &configMap)
 			}, timeout, interval).Should(Succeed())
-			Expect(sts.Spec.Template.Spec.Containers[0].Env).To(ContainElement(corev1.EnvVar{
-				Name:  "baz",
-				Value: "bat",
-			}))
+			Expect(configMap.Data["opensearch-client.yml"]).To(ContainSubstring("baz: bat\n"))
+			Expect(configMap.Data["opensearch-nodes.yml"]).ToNot(ContainSubstring("baz"))
 		})
 
 		It("should create a bootstrap pod", func() {
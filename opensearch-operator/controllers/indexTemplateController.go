@@ -0,0 +1,103 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/retry"
+	opsterv1 "opensearch.opster.io/api/v1"
+	"opensearch.opster.io/pkg/helpers"
+	"opensearch.opster.io/pkg/reconcilers"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// OpenSearchIndexTemplateReconciler reconciles a OpenSearchIndexTemplate object
+type OpenSearchIndexTemplateReconciler struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+	Instance *opsterv1.OpenSearchIndexTemplate
+	logr.Logger
+}
+
+//+kubebuilder:rbac:groups=opensearch.opster.io,resources=opensearchindextemplates,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=opensearch.opster.io,resources=opensearchindextemplates/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=opensearch.opster.io,resources=opensearchindextemplates/finalizers,verbs=update
+
+// Reconcile applies the index template to the referenced cluster and re-applies it if it was changed
+// in the cluster. The index template is removed from the cluster when the resource is deleted.
+func (r *OpenSearchIndexTemplateReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	r.Logger = log.FromContext(ctx).WithValues("indextemplate", req.NamespacedName)
+	r.Logger.Info("Reconciling OpenSearchIndexTemplate")
+	myFinalizerName := "Opster"
+
+	r.Instance = &opsterv1.OpenSearchIndexTemplate{}
+	err := r.Get(ctx, req.NamespacedName, r.Instance)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	indexTemplate := reconcilers.NewIndexTemplateReconciler(r.Client, ctx, r.Recorder, r.Instance)
+	if !r.Instance.ObjectMeta.DeletionTimestamp.IsZero() {
+		if !helpers.ContainsString(r.Instance.GetFinalizers(), myFinalizerName) {
+			return ctrl.Result{}, nil
+		}
+		if err := indexTemplate.Delete(); err != nil {
+			return ctrl.Result{}, err
+		}
+		err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+			if err := r.Get(ctx, req.NamespacedName, r.Instance); err != nil {
+				return err
+			}
+			controllerutil.RemoveFinalizer(r.Instance, myFinalizerName)
+			return r.Update(ctx, r.Instance)
+		})
+		return ctrl.Result{}, err
+	}
+
+	if !helpers.ContainsString(r.Instance.GetFinalizers(), myFinalizerName) {
+		err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+			if err := r.Get(ctx, req.NamespacedName, r.Instance); err != nil {
+				return err
+			}
+			controllerutil.AddFinalizer(r.Instance, myFinalizerName)
+			return r.Update(ctx, r.Instance)
+		})
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+	return indexTemplate.Reconcile()
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *OpenSearchIndexTemplateReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&opsterv1.OpenSearchIndexTemplate{}).
+		Complete(r)
+}
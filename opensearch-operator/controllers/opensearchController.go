@@ -26,6 +26,7 @@ import (
 	"k8s.io/client-go/util/retry"
 	"opensearch.opster.io/pkg/builders"
 	"opensearch.opster.io/pkg/helpers"
+	"opensearch.opster.io/pkg/metrics"
 	"opensearch.opster.io/pkg/reconcilers"
 
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
@@ -33,6 +34,7 @@ import (
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -59,9 +61,15 @@ type OpenSearchClusterReconciler struct {
 //+kubebuilder:rbac:groups=core,resources=services,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=core,resources=secrets,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=core,resources=persistentvolumeclaims,verbs=get;list;watch;update;patch;delete
+//+kubebuilder:rbac:groups=storage.k8s.io,resources=storageclasses,verbs=get;list;watch
+//+kubebuilder:rbac:groups=core,resources=nodes,verbs=get;list;watch
 //+kubebuilder:rbac:groups=core,resources=namespaces,verbs=get;list;create;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=core,resources=events,verbs=create;update;patch
+//+kubebuilder:rbac:groups=policy,resources=poddisruptionbudgets,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=monitoring.coreos.com,resources=servicemonitors,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=cert-manager.io,resources=certificates,verbs=get;list;watch;create;update;patch;delete
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -155,6 +163,7 @@ func (r *OpenSearchClusterReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		Owns(&corev1.Service{}).
 		Owns(&appsv1.Deployment{}).
 		Owns(&appsv1.StatefulSet{}).
+		Owns(&policyv1.PodDisruptionBudget{}).
 		Complete(r)
 }
 
@@ -167,6 +176,7 @@ func (r *OpenSearchClusterReconciler) deleteExternalResources(ctx context.Contex
 	tls := reconcilers.NewTLSReconciler(
 		r.Client,
 		ctx,
+		r.Recorder,
 		&reconcilerContext,
 		r.Instance,
 	)
@@ -198,6 +208,13 @@ func (r *OpenSearchClusterReconciler) deleteExternalResources(ctx context.Contex
 		&reconcilerContext,
 		r.Instance,
 	)
+	monitoring := reconcilers.NewMonitoringReconciler(
+		r.Client,
+		ctx,
+		r.Recorder,
+		&reconcilerContext,
+		r.Instance,
+	)
 
 	componentReconcilers := []reconcilers.ComponentReconciler{
 		tls.DeleteResources,
@@ -205,6 +222,7 @@ func (r *OpenSearchClusterReconciler) deleteExternalResources(ctx context.Contex
 		config.DeleteResources,
 		cluster.DeleteResources,
 		dashboards.DeleteResources,
+		monitoring.DeleteResources,
 	}
 	for _, rec := range componentReconcilers {
 		result, err := rec()
@@ -212,6 +230,7 @@ func (r *OpenSearchClusterReconciler) deleteExternalResources(ctx context.Contex
 			return result, err
 		}
 	}
+	reconcilers.RemoveOsClient(r.Instance)
 	r.Logger.Info("Finished deleting resources")
 	return ctrl.Result{}, nil
 }
@@ -259,6 +278,7 @@ func (r *OpenSearchClusterReconciler) reconcilePhaseRunning(ctx context.Context)
 	tls := reconcilers.NewTLSReconciler(
 		r.Client,
 		ctx,
+		r.Recorder,
 		&reconcilerContext,
 		r.Instance,
 	)
@@ -283,6 +303,13 @@ func (r *OpenSearchClusterReconciler) reconcilePhaseRunning(ctx context.Context)
 		&reconcilerContext,
 		r.Instance,
 	)
+	autoscaler := reconcilers.NewAutoScalerReconciler(
+		r.Client,
+		ctx,
+		r.Recorder,
+		&reconcilerContext,
+		r.Instance,
+	)
 	scaler := reconcilers.NewScalerReconciler(
 		r.Client,
 		ctx,
@@ -311,16 +338,49 @@ func (r *OpenSearchClusterReconciler) reconcilePhaseRunning(ctx context.Context)
 		&reconcilerContext,
 		r.Instance,
 	)
+	restore := reconcilers.NewRestoreReconciler(
+		r.Client,
+		ctx,
+		r.Recorder,
+		&reconcilerContext,
+		r.Instance,
+	)
+	clusterSettings := reconcilers.NewClusterSettingsReconciler(
+		r.Client,
+		ctx,
+		r.Recorder,
+		&reconcilerContext,
+		r.Instance,
+	)
+	remoteClusters := reconcilers.NewRemoteClusterReconciler(
+		r.Client,
+		ctx,
+		r.Recorder,
+		&reconcilerContext,
+		r.Instance,
+	)
+	monitoring := reconcilers.NewMonitoringReconciler(
+		r.Client,
+		ctx,
+		r.Recorder,
+		&reconcilerContext,
+		r.Instance,
+	)
 
 	componentReconcilers := []reconcilers.ComponentReconciler{
-		tls.Reconcile,
-		securityconfig.Reconcile,
-		config.Reconcile,
-		cluster.Reconcile,
-		scaler.Reconcile,
-		dashboards.Reconcile,
-		upgrade.Reconcile,
-		restart.Reconcile,
+		metrics.InstrumentReconciler(r.Instance, "monitoring", monitoring.Reconcile),
+		metrics.InstrumentReconciler(r.Instance, "tls", tls.Reconcile),
+		metrics.InstrumentReconciler(r.Instance, "securityconfig", securityconfig.Reconcile),
+		metrics.InstrumentReconciler(r.Instance, "config", config.Reconcile),
+		metrics.InstrumentReconciler(r.Instance, "cluster", cluster.Reconcile),
+		metrics.InstrumentReconciler(r.Instance, "autoscaler", autoscaler.Reconcile),
+		metrics.InstrumentReconciler(r.Instance, "scaler", scaler.Reconcile),
+		metrics.InstrumentReconciler(r.Instance, "dashboards", dashboards.Reconcile),
+		metrics.InstrumentReconciler(r.Instance, "upgrade", upgrade.Reconcile),
+		metrics.InstrumentReconciler(r.Instance, "restart", restart.Reconcile),
+		metrics.InstrumentReconciler(r.Instance, "restore", restore.Reconcile),
+		metrics.InstrumentReconciler(r.Instance, "clustersettings", clusterSettings.Reconcile),
+		metrics.InstrumentReconciler(r.Instance, "remoteclusters", remoteClusters.Reconcile),
 	}
 	for _, rec := range componentReconcilers {
 		result, err := rec()
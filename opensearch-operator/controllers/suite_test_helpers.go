@@ -120,7 +120,7 @@ func ComposeOpensearchCrd(clusterName string, namespace string) opsterv1.OpenSea
 						v1.ResourceCPU:    resource.MustParse("500m"),
 						v1.ResourceMemory: resource.MustParse("2Gi"),
 					}},
-				Roles: []string{
+				Roles: []opsterv1.NodeRole{
 					"master",
 					"data",
 				}}, {
@@ -132,7 +132,7 @@ func ComposeOpensearchCrd(clusterName string, namespace string) opsterv1.OpenSea
 						v1.ResourceCPU:    resource.MustParse("500m"),
 						v1.ResourceMemory: resource.MustParse("2Gi"),
 					}},
-				Roles: []string{
+				Roles: []opsterv1.NodeRole{
 					"data",
 				}}, {
 				Component: "client",
@@ -143,7 +143,7 @@ func ComposeOpensearchCrd(clusterName string, namespace string) opsterv1.OpenSea
 						v1.ResourceCPU:    resource.MustParse("500m"),
 						v1.ResourceMemory: resource.MustParse("2Gi"),
 					}},
-				Roles: []string{
+				Roles: []opsterv1.NodeRole{
 					"data",
 					"ingest",
 				},
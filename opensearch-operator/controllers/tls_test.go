@@ -52,7 +52,7 @@ var _ = Describe("TLS Reconciler", func() {
 					{
 						Component: "masters",
 						Replicas:  3,
-						Roles:     []string{"master", "data"},
+						Roles:     []opsterv1.NodeRole{"master", "data"},
 					},
 				},
 			}}
@@ -21,6 +21,7 @@ import (
 	"os"
 
 	"opensearch.opster.io/controllers"
+	"opensearch.opster.io/pkg/reconcilers"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
@@ -53,11 +54,15 @@ func main() {
 	var metricsAddr string
 	var enableLeaderElection bool
 	var probeAddr string
+	var connectionMode string
 	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8080", "The address the metric endpoint binds to.")
 	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
 	flag.BoolVar(&enableLeaderElection, "leader-elect", false,
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
+	flag.StringVar(&connectionMode, "connection-mode", reconcilers.ConnectionModeService,
+		"How the operator connects to the opensearch clusters. "+
+			"Use service when running inside the kubernetes cluster or port-forward when running the operator locally.")
 	opts := zap.Options{
 		Development: true,
 	}
@@ -79,6 +84,13 @@ func main() {
 		os.Exit(1)
 	}
 
+	connectionStrategy, err := reconcilers.NewConnectionStrategy(connectionMode, mgr.GetConfig())
+	if err != nil {
+		setupLog.Error(err, "unable to set up connection strategy")
+		os.Exit(1)
+	}
+	reconcilers.SetConnectionStrategy(connectionStrategy)
+
 	if err = (&controllers.OpenSearchClusterReconciler{
 		Client:   mgr.GetClient(),
 		Scheme:   mgr.GetScheme(),
@@ -88,6 +100,78 @@ func main() {
 		os.Exit(1)
 	}
 
+	if err = (&controllers.OpenSearchSnapshotPolicyReconciler{
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorderFor("snapshotpolicy-controller"),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "OpenSearchSnapshotPolicy")
+		os.Exit(1)
+	}
+
+	if err = (&controllers.OpenSearchIndexTemplateReconciler{
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorderFor("indextemplate-controller"),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "OpenSearchIndexTemplate")
+		os.Exit(1)
+	}
+
+	if err = (&controllers.OpenSearchComponentTemplateReconciler{
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorderFor("componenttemplate-controller"),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "OpenSearchComponentTemplate")
+		os.Exit(1)
+	}
+
+	if err = (&controllers.OpenSearchISMPolicyReconciler{
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorderFor("ismpolicy-controller"),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "OpenSearchISMPolicy")
+		os.Exit(1)
+	}
+
+	if err = (&controllers.OpenSearchUserReconciler{
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorderFor("user-controller"),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "OpenSearchUser")
+		os.Exit(1)
+	}
+
+	if err = (&controllers.OpenSearchRoleReconciler{
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorderFor("role-controller"),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "OpenSearchRole")
+		os.Exit(1)
+	}
+
+	if err = (&controllers.OpenSearchRoleMappingReconciler{
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorderFor("rolemapping-controller"),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "OpenSearchRoleMapping")
+		os.Exit(1)
+	}
+
+	if err = (&controllers.OpenSearchReplicationReconciler{
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorderFor("replication-controller"),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "OpenSearchReplication")
+		os.Exit(1)
+	}
+
 	//+kubebuilder:scaffold:builder
 
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
@@ -0,0 +1,33 @@
+package requests
+
+type ISMPolicy struct {
+	Policy ISMPolicyBody `json:"policy"`
+}
+
+type ISMPolicyBody struct {
+	Description       string                 `json:"description,omitempty"`
+	DefaultState      string                 `json:"default_state"`
+	States            []ISMState             `json:"states"`
+	ISMTemplate       []ISMTemplate          `json:"ism_template,omitempty"`
+	ErrorNotification map[string]interface{} `json:"error_notification,omitempty"`
+}
+
+type ISMState struct {
+	Name        string                   `json:"name"`
+	Actions     []map[string]interface{} `json:"actions"`
+	Transitions []ISMTransition          `json:"transitions"`
+}
+
+type ISMTransition struct {
+	StateName  string                 `json:"state_name"`
+	Conditions map[string]interface{} `json:"conditions,omitempty"`
+}
+
+type ISMTemplate struct {
+	IndexPatterns []string `json:"index_patterns"`
+	Priority      int64    `json:"priority"`
+}
+
+type ISMAddPolicy struct {
+	PolicyID string `json:"policy_id"`
+}
@@ -0,0 +1,14 @@
+package requests
+
+// AutoFollowRule creates or deletes an auto-follow rule, the pattern and roles are only needed when creating it
+type AutoFollowRule struct {
+	LeaderAlias string            `json:"leader_alias"`
+	Name        string            `json:"name"`
+	Pattern     string            `json:"pattern,omitempty"`
+	UseRoles    *ReplicationRoles `json:"use_roles,omitempty"`
+}
+
+type ReplicationRoles struct {
+	LeaderClusterRole   string `json:"leader_cluster_role"`
+	FollowerClusterRole string `json:"follower_cluster_role"`
+}
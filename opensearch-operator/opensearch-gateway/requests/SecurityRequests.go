@@ -0,0 +1,36 @@
+package requests
+
+type SecurityUser struct {
+	Password                string            `json:"password,omitempty"`
+	BackendRoles            []string          `json:"backend_roles,omitempty"`
+	OpendistroSecurityRoles []string          `json:"opendistro_security_roles,omitempty"`
+	Attributes              map[string]string `json:"attributes,omitempty"`
+	Description             string            `json:"description,omitempty"`
+}
+
+type SecurityRole struct {
+	ClusterPermissions []string                    `json:"cluster_permissions,omitempty"`
+	IndexPermissions   []SecurityIndexPermissions  `json:"index_permissions,omitempty"`
+	TenantPermissions  []SecurityTenantPermissions `json:"tenant_permissions,omitempty"`
+	Description        string                      `json:"description,omitempty"`
+}
+
+type SecurityIndexPermissions struct {
+	IndexPatterns  []string `json:"index_patterns,omitempty"`
+	AllowedActions []string `json:"allowed_actions,omitempty"`
+	Dls            string   `json:"dls,omitempty"`
+	Fls            []string `json:"fls,omitempty"`
+	MaskedFields   []string `json:"masked_fields,omitempty"`
+}
+
+type SecurityTenantPermissions struct {
+	TenantPatterns []string `json:"tenant_patterns,omitempty"`
+	AllowedActions []string `json:"allowed_actions,omitempty"`
+}
+
+type SecurityRoleMapping struct {
+	Users        []string `json:"users,omitempty"`
+	BackendRoles []string `json:"backend_roles,omitempty"`
+	Hosts        []string `json:"hosts,omitempty"`
+	Description  string   `json:"description,omitempty"`
+}
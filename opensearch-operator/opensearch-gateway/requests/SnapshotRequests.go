@@ -0,0 +1,21 @@
+package requests
+
+type SnapshotRepository struct {
+	Type     string            `json:"type"`
+	Settings map[string]string `json:"settings,omitempty"`
+}
+
+type CreateSnapshot struct {
+	Indices            string `json:"indices,omitempty"`
+	IgnoreUnavailable  bool   `json:"ignore_unavailable"`
+	IncludeGlobalState bool   `json:"include_global_state"`
+	Partial            bool   `json:"partial"`
+}
+
+type RestoreSnapshot struct {
+	Indices            string `json:"indices,omitempty"`
+	IgnoreUnavailable  bool   `json:"ignore_unavailable"`
+	IncludeGlobalState bool   `json:"include_global_state"`
+	RenamePattern      string `json:"rename_pattern,omitempty"`
+	RenameReplacement  string `json:"rename_replacement,omitempty"`
+}
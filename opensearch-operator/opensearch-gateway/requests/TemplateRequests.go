@@ -0,0 +1,20 @@
+package requests
+
+type IndexTemplate struct {
+	IndexPatterns []string  `json:"index_patterns"`
+	ComposedOf    []string  `json:"composed_of,omitempty"`
+	Priority      *int64    `json:"priority,omitempty"`
+	Version       *int64    `json:"version,omitempty"`
+	Template      *Template `json:"template,omitempty"`
+}
+
+type ComponentTemplate struct {
+	Version  *int64    `json:"version,omitempty"`
+	Template *Template `json:"template"`
+}
+
+type Template struct {
+	Settings map[string]interface{} `json:"settings,omitempty"`
+	Mappings map[string]interface{} `json:"mappings,omitempty"`
+	Aliases  map[string]interface{} `json:"aliases,omitempty"`
+}
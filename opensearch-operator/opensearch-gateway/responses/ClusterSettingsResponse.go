@@ -6,8 +6,9 @@ type ClusterSettingsResponse struct {
 }
 
 type FlatClusterSettingsResponse struct {
-	Persistent Settings `json:"persistent,omitempty"`
-	Transient  Settings `json:"transient,omitempty"`
+	// Values are strings or lists of strings
+	Persistent map[string]interface{} `json:"persistent,omitempty"`
+	Transient  Settings               `json:"transient,omitempty"`
 }
 
 type Settings struct {
@@ -0,0 +1,28 @@
+package responses
+
+// ISMPolicyResponse is returned when fetching or writing a policy, the policy document itself is omitted
+type ISMPolicyResponse struct {
+	ID          string `json:"_id"`
+	Version     int64  `json:"_version"`
+	SeqNo       int64  `json:"_seq_no"`
+	PrimaryTerm int64  `json:"_primary_term"`
+}
+
+// ISMIndicesResponse is returned when adding or removing a policy from indices
+type ISMIndicesResponse struct {
+	UpdatedIndices int                  `json:"updated_indices"`
+	Failures       bool                 `json:"failures"`
+	FailedIndices  []ISMFailedIndexInfo `json:"failed_indices"`
+}
+
+type ISMFailedIndexInfo struct {
+	IndexName string `json:"index_name"`
+	IndexUUID string `json:"index_uuid"`
+	Reason    string `json:"reason"`
+}
+
+// ISMExplainIndex contains the policy of a single index in the explain API response
+type ISMExplainIndex struct {
+	PolicyID           *string `json:"index.plugins.index_state_management.policy_id"`
+	OpendistroPolicyID *string `json:"index.opendistro.index_state_management.policy_id"`
+}
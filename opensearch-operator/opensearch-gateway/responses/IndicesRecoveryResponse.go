@@ -0,0 +1,28 @@
+package responses
+
+type IndicesRecoveryResponse map[string]IndexRecovery
+
+type IndexRecovery struct {
+	Shards []ShardRecovery `json:"shards"`
+}
+
+type ShardRecovery struct {
+	Id      int                 `json:"id"`
+	Type    string              `json:"type"`
+	Stage   string              `json:"stage"`
+	Primary bool                `json:"primary"`
+	Source  ShardRecoverySource `json:"source"`
+	Index   ShardRecoveryIndex  `json:"index"`
+}
+
+type ShardRecoverySource struct {
+	Repository string `json:"repository,omitempty"`
+	Snapshot   string `json:"snapshot,omitempty"`
+	Index      string `json:"index,omitempty"`
+}
+
+type ShardRecoveryIndex struct {
+	Size struct {
+		Percent string `json:"percent"`
+	} `json:"size"`
+}
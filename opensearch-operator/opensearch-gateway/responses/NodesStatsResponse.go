@@ -22,11 +22,11 @@ type NodeStatResponse struct {
 	Roles             []string                             `json:"roles"`
 	Attributes        map[string]string                    `json:"attributes"`
 	Indices           map[string]interface{}               `json:"indices"`
-	Os                map[string]interface{}               `json:"os"`
+	Os                NodeStatOs                           `json:"os"`
 	Process           map[string]interface{}               `json:"process"`
-	Jvm               map[string]interface{}               `json:"jvm"`
+	Jvm               NodeStatJvm                          `json:"jvm"`
 	ThreadPool        map[string]NodeStatThreadPool        `json:"thread_pool"`
-	Fs                map[string]interface{}               `json:"fs"`
+	Fs                NodeStatFs                           `json:"fs"`
 	Transport         map[string]interface{}               `json:"transport"`
 	Http              map[string]interface{}               `json:"http"`
 	Breakers          map[string]NodeStatBreakers          `json:"breakers"`
@@ -38,6 +38,42 @@ type NodeStatResponse struct {
 	IndexingPressure  map[string]interface{}               `json:"indexing_pressure"`
 }
 
+type NodeStatOs struct {
+	Timestamp uint64 `json:"timestamp"`
+	Cpu       struct {
+		Percent uint32 `json:"percent"`
+	} `json:"cpu"`
+	Mem struct {
+		TotalInBytes uint64 `json:"total_in_bytes"`
+		FreeInBytes  uint64 `json:"free_in_bytes"`
+		UsedInBytes  uint64 `json:"used_in_bytes"`
+		FreePercent  uint32 `json:"free_percent"`
+		UsedPercent  uint32 `json:"used_percent"`
+	} `json:"mem"`
+}
+
+type NodeStatJvm struct {
+	Timestamp      uint64 `json:"timestamp"`
+	UptimeInMillis uint64 `json:"uptime_in_millis"`
+	Mem            struct {
+		HeapUsedInBytes         uint64 `json:"heap_used_in_bytes"`
+		HeapUsedPercent         uint32 `json:"heap_used_percent"`
+		HeapCommittedInBytes    uint64 `json:"heap_committed_in_bytes"`
+		HeapMaxInBytes          uint64 `json:"heap_max_in_bytes"`
+		NonHeapUsedInBytes      uint64 `json:"non_heap_used_in_bytes"`
+		NonHeapCommittedInBytes uint64 `json:"non_heap_committed_in_bytes"`
+	} `json:"mem"`
+}
+
+type NodeStatFs struct {
+	Timestamp uint64 `json:"timestamp"`
+	Total     struct {
+		TotalInBytes     uint64 `json:"total_in_bytes"`
+		FreeInBytes      uint64 `json:"free_in_bytes"`
+		AvailableInBytes uint64 `json:"available_in_bytes"`
+	} `json:"total"`
+}
+
 type NodeStatThreadPool struct {
 	Threads   uint32 `json:"threads"`
 	Queue     uint32 `json:"queue"`
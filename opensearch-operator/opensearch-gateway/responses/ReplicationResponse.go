@@ -0,0 +1,31 @@
+package responses
+
+// RemoteClusterInfo is the connection state of a remote cluster in the remote info API response
+type RemoteClusterInfo struct {
+	Connected         bool     `json:"connected"`
+	Mode              string   `json:"mode"`
+	Seeds             []string `json:"seeds"`
+	NumNodesConnected int      `json:"num_nodes_connected"`
+}
+
+type AutoFollowStatsResponse struct {
+	AutoFollowStats []AutoFollowRuleStats `json:"autofollow_stats"`
+}
+
+type AutoFollowRuleStats struct {
+	Name                       string   `json:"name"`
+	Pattern                    string   `json:"pattern"`
+	NumSuccessStartReplication int64    `json:"num_success_start_replication"`
+	NumFailedStartReplication  int64    `json:"num_failed_start_replication"`
+	NumFailedLeaderCalls       int64    `json:"num_failed_leader_calls"`
+	FailedIndices              []string `json:"failed_indices"`
+}
+
+// ReplicationStatusResponse is the replication status of a single follower index
+type ReplicationStatusResponse struct {
+	Status        string `json:"status"`
+	Reason        string `json:"reason"`
+	LeaderAlias   string `json:"leader_alias"`
+	LeaderIndex   string `json:"leader_index"`
+	FollowerIndex string `json:"follower_index"`
+}
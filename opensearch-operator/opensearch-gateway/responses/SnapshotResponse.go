@@ -0,0 +1,32 @@
+package responses
+
+import "opensearch.opster.io/opensearch-gateway/requests"
+
+type SnapshotRepositoryResponse map[string]requests.SnapshotRepository
+
+type GetSnapshotsResponse struct {
+	Snapshots []SnapshotResponse `json:"snapshots"`
+}
+
+type SnapshotResponse struct {
+	Snapshot          string   `json:"snapshot"`
+	Uuid              string   `json:"uuid"`
+	State             string   `json:"state"`
+	Indices           []string `json:"indices"`
+	StartTimeInMillis int64    `json:"start_time_in_millis"`
+	EndTimeInMillis   int64    `json:"end_time_in_millis"`
+}
+
+// ClusterStateCustomsResponse is the response of _cluster/state/customs, only the restores in progress are decoded
+type ClusterStateCustomsResponse struct {
+	Restore struct {
+		Snapshots []RestoreInProgress `json:"snapshots"`
+	} `json:"restore"`
+}
+
+type RestoreInProgress struct {
+	Snapshot   string   `json:"snapshot"`
+	Repository string   `json:"repository"`
+	State      string   `json:"state"`
+	Indices    []string `json:"indices"`
+}
@@ -0,0 +1,21 @@
+package responses
+
+import "opensearch.opster.io/opensearch-gateway/requests"
+
+type GetIndexTemplatesResponse struct {
+	IndexTemplates []IndexTemplateResponse `json:"index_templates"`
+}
+
+type IndexTemplateResponse struct {
+	Name          string                 `json:"name"`
+	IndexTemplate requests.IndexTemplate `json:"index_template"`
+}
+
+type GetComponentTemplatesResponse struct {
+	ComponentTemplates []ComponentTemplateResponse `json:"component_templates"`
+}
+
+type ComponentTemplateResponse struct {
+	Name              string                     `json:"name"`
+	ComponentTemplate requests.ComponentTemplate `json:"component_template"`
+}
@@ -9,6 +9,14 @@ var (
 	ErrClusterHealthOperation   = errors.New("cluster health failed")
 	ErrClusterSettingsOperation = errors.New("cluster settings failed")
 	ErrCatIndicesOperation      = errors.New("cat indices failed")
+	ErrSnapshotRepository       = errors.New("snapshot repository operation failed")
+	ErrSnapshotOperation        = errors.New("snapshot operation failed")
+	ErrIndicesRecoveryOperation = errors.New("indices recovery failed")
+	ErrTemplateOperation        = errors.New("template operation failed")
+	ErrISMOperation             = errors.New("ism operation failed")
+	ErrSecurityOperation        = errors.New("security operation failed")
+	ErrSecurityReserved         = errors.New("reserved security resources can not be managed")
+	ErrReplicationOperation     = errors.New("replication operation failed")
 )
 
 func ErrClusterHealthGetFailed(resp string) error {
@@ -19,6 +27,38 @@ func ErrClusterSettingsGetFailed(resp string) error {
 	return fmt.Errorf("get error %w: %s", ErrClusterSettingsOperation, resp)
 }
 
+func ErrClusterSettingsPutFailed(resp string) error {
+	return fmt.Errorf("put error %w: %s", ErrClusterSettingsOperation, resp)
+}
+
 func ErrCatIndicesFailed(resp string) error {
 	return fmt.Errorf("%w: %s", ErrCatIndicesOperation, resp)
 }
+
+func ErrSnapshotRepositoryFailed(resp string) error {
+	return fmt.Errorf("%w: %s", ErrSnapshotRepository, resp)
+}
+
+func ErrSnapshotFailed(resp string) error {
+	return fmt.Errorf("%w: %s", ErrSnapshotOperation, resp)
+}
+
+func ErrIndicesRecoveryFailed(resp string) error {
+	return fmt.Errorf("%w: %s", ErrIndicesRecoveryOperation, resp)
+}
+
+func ErrTemplateFailed(resp string) error {
+	return fmt.Errorf("%w: %s", ErrTemplateOperation, resp)
+}
+
+func ErrISMFailed(resp string) error {
+	return fmt.Errorf("%w: %s", ErrISMOperation, resp)
+}
+
+func ErrSecurityFailed(resp string) error {
+	return fmt.Errorf("%w: %s", ErrSecurityOperation, resp)
+}
+
+func ErrReplicationFailed(resp string) error {
+	return fmt.Errorf("%w: %s", ErrReplicationOperation, resp)
+}
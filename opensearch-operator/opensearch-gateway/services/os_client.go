@@ -4,7 +4,11 @@ import (
 	"context"
 	"crypto/tls"
 	"encoding/json"
+	"fmt"
+	"io"
 	"net/http"
+	"net/url"
+	"strconv"
 	"strings"
 	"time"
 
@@ -12,6 +16,7 @@ import (
 	"github.com/opensearch-project/opensearch-go/opensearchapi"
 	"github.com/opensearch-project/opensearch-go/opensearchutil"
 	"k8s.io/utils/pointer"
+	"opensearch.opster.io/opensearch-gateway/requests"
 	"opensearch.opster.io/opensearch-gateway/responses"
 )
 
@@ -33,32 +38,38 @@ var (
 )
 
 type OsClusterClient struct {
-	client   *opensearch.Client
-	MainPage responses.MainResponse
+	client    *opensearch.Client
+	transport *http.Transport
+	MainPage  responses.MainResponse
 }
 
-func NewOsClusterClient(clusterUrl string, username string, password string) (*OsClusterClient, error) {
+// NewOsClusterClient creates a client for the cluster. The TLS configuration contains the trusted CAs and optionally a client certificate,
+// username and password are only used for basic auth if set.
+func NewOsClusterClient(ctx context.Context, clusterUrl string, username string, password string, tlsConfig *tls.Config) (*OsClusterClient, error) {
 	config := opensearch.Config{
 		Transport: &http.Transport{
-			TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+			TLSClientConfig: tlsConfig,
 		},
 		Addresses: []string{clusterUrl},
 		Username:  username,
 		Password:  password,
 	}
-	return NewOsClusterClientFromConfig(config)
+	return NewOsClusterClientFromConfig(ctx, config)
 }
 
-func NewOsClusterClientFromConfig(config opensearch.Config) (*OsClusterClient, error) {
+func NewOsClusterClientFromConfig(ctx context.Context, config opensearch.Config) (*OsClusterClient, error) {
 	service := new(OsClusterClient)
+	if transport, ok := config.Transport.(*http.Transport); ok {
+		service.transport = transport
+	}
 	client, err := opensearch.NewClient(config)
 	if err == nil {
 		service.client = client
 	}
 	pingReq := opensearchapi.PingRequest{}
-	pingRes, err := pingReq.Do(context.Background(), client)
+	pingRes, err := pingReq.Do(ctx, client)
 	if err == nil && pingRes.StatusCode == 200 {
-		mainPageResponse, err := MainPage(client)
+		mainPageResponse, err := MainPage(ctx, client)
 		if err == nil {
 			service.MainPage = mainPageResponse
 		}
@@ -66,9 +77,16 @@ func NewOsClusterClientFromConfig(config opensearch.Config) (*OsClusterClient, e
 	return service, err
 }
 
-func MainPage(client *opensearch.Client) (responses.MainResponse, error) {
+// Close closes the idle connections of the client, connections in use are closed once their requests are done
+func (client *OsClusterClient) Close() {
+	if client.transport != nil {
+		client.transport.CloseIdleConnections()
+	}
+}
+
+func MainPage(ctx context.Context, client *opensearch.Client) (responses.MainResponse, error) {
 	req := opensearchapi.InfoRequest{}
-	infoRes, err := req.Do(context.Background(), client)
+	infoRes, err := req.Do(ctx, client)
 	var response responses.MainResponse
 	if err == nil {
 		defer infoRes.Body.Close()
@@ -77,9 +95,9 @@ func MainPage(client *opensearch.Client) (responses.MainResponse, error) {
 	return response, err
 }
 
-func (client *OsClusterClient) GetHealth() (responses.CatHealthResponse, error) {
+func (client *OsClusterClient) GetHealth(ctx context.Context) (responses.CatHealthResponse, error) {
 	req := opensearchapi.ClusterHealthRequest{}
-	catNodesRes, err := req.Do(context.Background(), client.client)
+	catNodesRes, err := req.Do(ctx, client.client)
 	var response responses.CatHealthResponse
 	if err == nil {
 		defer catNodesRes.Body.Close()
@@ -88,9 +106,9 @@ func (client *OsClusterClient) GetHealth() (responses.CatHealthResponse, error)
 	return response, err
 }
 
-func (client *OsClusterClient) CatNodes() ([]responses.CatNodesResponse, error) {
+func (client *OsClusterClient) CatNodes(ctx context.Context) ([]responses.CatNodesResponse, error) {
 	req := opensearchapi.CatNodesRequest{Format: "json"}
-	catNodesRes, err := req.Do(context.Background(), client.client)
+	catNodesRes, err := req.Do(ctx, client.client)
 	var response []responses.CatNodesResponse
 	if err == nil {
 		defer catNodesRes.Body.Close()
@@ -99,9 +117,9 @@ func (client *OsClusterClient) CatNodes() ([]responses.CatNodesResponse, error)
 	return response, err
 }
 
-func (client *OsClusterClient) NodesStats() (responses.NodesStatsResponse, error) {
+func (client *OsClusterClient) NodesStats(ctx context.Context) (responses.NodesStatsResponse, error) {
 	req := opensearchapi.NodesStatsRequest{}
-	catNodesRes, err := req.Do(context.Background(), client.client)
+	catNodesRes, err := req.Do(ctx, client.client)
 	var response responses.NodesStatsResponse
 	if err == nil {
 		defer catNodesRes.Body.Close()
@@ -110,9 +128,9 @@ func (client *OsClusterClient) NodesStats() (responses.NodesStatsResponse, error
 	return response, err
 }
 
-func (client *OsClusterClient) CatIndices() ([]responses.CatIndicesResponse, error) {
+func (client *OsClusterClient) CatIndices(ctx context.Context) ([]responses.CatIndicesResponse, error) {
 	req := opensearchapi.CatIndicesRequest{Format: "json"}
-	indicesRes, err := req.Do(context.Background(), client.client)
+	indicesRes, err := req.Do(ctx, client.client)
 	var response []responses.CatIndicesResponse
 	if err != nil {
 		return response, err
@@ -122,9 +140,9 @@ func (client *OsClusterClient) CatIndices() ([]responses.CatIndicesResponse, err
 	return response, err
 }
 
-func (client *OsClusterClient) CatShards(headers []string) ([]responses.CatShardsResponse, error) {
+func (client *OsClusterClient) CatShards(ctx context.Context, headers []string) ([]responses.CatShardsResponse, error) {
 	req := opensearchapi.CatShardsRequest{Format: "json", H: headers}
-	indicesRes, err := req.Do(context.Background(), client.client)
+	indicesRes, err := req.Do(ctx, client.client)
 	var response []responses.CatShardsResponse
 	if err != nil {
 		return response, err
@@ -134,13 +152,13 @@ func (client *OsClusterClient) CatShards(headers []string) ([]responses.CatShard
 	return response, err
 }
 
-func (client *OsClusterClient) CatNamedIndicesShards(headers []string, indices []string) ([]responses.CatShardsResponse, error) {
+func (client *OsClusterClient) CatNamedIndicesShards(ctx context.Context, headers []string, indices []string) ([]responses.CatShardsResponse, error) {
 	req := opensearchapi.CatShardsRequest{
 		Index:  indices,
 		Format: "json",
 		H:      headers,
 	}
-	indicesRes, err := req.Do(context.Background(), client.client)
+	indicesRes, err := req.Do(ctx, client.client)
 	var response []responses.CatShardsResponse
 	if err != nil {
 		return response, err
@@ -150,9 +168,9 @@ func (client *OsClusterClient) CatNamedIndicesShards(headers []string, indices [
 	return response, err
 }
 
-func (client *OsClusterClient) GetClusterSettings() (responses.ClusterSettingsResponse, error) {
+func (client *OsClusterClient) GetClusterSettings(ctx context.Context) (responses.ClusterSettingsResponse, error) {
 	req := opensearchapi.ClusterGetSettingsRequest{Pretty: true}
-	settingsRes, err := req.Do(context.Background(), client.client)
+	settingsRes, err := req.Do(ctx, client.client)
 	var response responses.ClusterSettingsResponse
 	if err != nil {
 		return response, err
@@ -162,11 +180,11 @@ func (client *OsClusterClient) GetClusterSettings() (responses.ClusterSettingsRe
 	return response, err
 }
 
-func (client *OsClusterClient) GetFlatClusterSettings() (responses.FlatClusterSettingsResponse, error) {
+func (client *OsClusterClient) GetFlatClusterSettings(ctx context.Context) (responses.FlatClusterSettingsResponse, error) {
 	req := opensearchapi.ClusterGetSettingsRequest{
 		FlatSettings: pointer.BoolPtr(true),
 	}
-	settingsRes, err := req.Do(context.Background(), client.client)
+	settingsRes, err := req.Do(ctx, client.client)
 	var response responses.FlatClusterSettingsResponse
 	if err != nil {
 		return response, err
@@ -181,23 +199,26 @@ func (client *OsClusterClient) GetFlatClusterSettings() (responses.FlatClusterSe
 	return response, err
 }
 
-func (client *OsClusterClient) PutClusterSettings(settings responses.ClusterSettingsResponse) (responses.ClusterSettingsResponse, error) {
+func (client *OsClusterClient) PutClusterSettings(ctx context.Context, settings responses.ClusterSettingsResponse) (responses.ClusterSettingsResponse, error) {
 	body := opensearchutil.NewJSONReader(settings)
 	req := opensearchapi.ClusterPutSettingsRequest{Body: body}
-	settingsRes, err := req.Do(context.Background(), client.client)
+	settingsRes, err := req.Do(ctx, client.client)
 	var response responses.ClusterSettingsResponse
 	if err != nil {
 		return response, err
 	}
 	defer settingsRes.Body.Close()
+	if settingsRes.IsError() {
+		return response, ErrClusterSettingsPutFailed(settingsRes.String())
+	}
 	err = json.NewDecoder(settingsRes.Body).Decode(&response)
 	return response, err
 }
 
-func (client *OsClusterClient) ReRouteShard(rerouteJson string) (responses.ClusterRerouteResponse, error) {
+func (client *OsClusterClient) ReRouteShard(ctx context.Context, rerouteJson string) (responses.ClusterRerouteResponse, error) {
 	body := strings.NewReader(rerouteJson)
 	req := opensearchapi.ClusterRerouteRequest{Body: body}
-	settingsRes, err := req.Do(context.Background(), client.client)
+	settingsRes, err := req.Do(ctx, client.client)
 	var response responses.ClusterRerouteResponse
 	if err != nil {
 		return response, err
@@ -207,13 +228,13 @@ func (client *OsClusterClient) ReRouteShard(rerouteJson string) (responses.Clust
 	return response, err
 }
 
-func (client *OsClusterClient) GetClusterHealth() (responses.ClusterHealthResponse, error) {
+func (client *OsClusterClient) GetClusterHealth(ctx context.Context) (responses.ClusterHealthResponse, error) {
 	req := opensearchapi.ClusterHealthRequest{
 		Timeout: 10 * time.Second,
 	}
 
 	health := responses.ClusterHealthResponse{}
-	resp, err := req.Do(context.Background(), client.client)
+	resp, err := req.Do(ctx, client.client)
 	if err != nil {
 		return health, err
 	}
@@ -227,14 +248,14 @@ func (client *OsClusterClient) GetClusterHealth() (responses.ClusterHealthRespon
 	return health, err
 }
 
-func (client *OsClusterClient) IndexExists(indexName string) (bool, error) {
+func (client *OsClusterClient) IndexExists(ctx context.Context, indexName string) (bool, error) {
 	req := opensearchapi.CatIndicesRequest{
 		Format: "json",
 		Index: []string{
 			indexName,
 		},
 	}
-	indicesRes, err := req.Do(context.Background(), client.client)
+	indicesRes, err := req.Do(ctx, client.client)
 	if err != nil {
 		return false, err
 	}
@@ -247,3 +268,521 @@ func (client *OsClusterClient) IndexExists(indexName string) (bool, error) {
 
 	return true, nil
 }
+
+func (client *OsClusterClient) GetSnapshotRepository(ctx context.Context, name string) (responses.SnapshotRepositoryResponse, bool, error) {
+	req := opensearchapi.SnapshotGetRepositoryRequest{
+		Repository: []string{name},
+	}
+	var response responses.SnapshotRepositoryResponse
+	repoRes, err := req.Do(ctx, client.client)
+	if err != nil {
+		return response, false, err
+	}
+	defer repoRes.Body.Close()
+	if repoRes.StatusCode == 404 {
+		return response, false, nil
+	} else if repoRes.IsError() {
+		return response, false, ErrSnapshotRepositoryFailed(repoRes.String())
+	}
+	err = json.NewDecoder(repoRes.Body).Decode(&response)
+	return response, err == nil, err
+}
+
+func (client *OsClusterClient) PutSnapshotRepository(ctx context.Context, name string, repository requests.SnapshotRepository) error {
+	body := opensearchutil.NewJSONReader(repository)
+	req := opensearchapi.SnapshotCreateRepositoryRequest{
+		Repository: name,
+		Body:       body,
+	}
+	repoRes, err := req.Do(ctx, client.client)
+	if err != nil {
+		return err
+	}
+	defer repoRes.Body.Close()
+	if repoRes.IsError() {
+		return ErrSnapshotRepositoryFailed(repoRes.String())
+	}
+	return nil
+}
+
+func (client *OsClusterClient) CreateSnapshot(ctx context.Context, repository string, snapshot string, settings requests.CreateSnapshot) error {
+	body := opensearchutil.NewJSONReader(settings)
+	req := opensearchapi.SnapshotCreateRequest{
+		Repository:        repository,
+		Snapshot:          snapshot,
+		Body:              body,
+		WaitForCompletion: pointer.BoolPtr(false),
+	}
+	snapshotRes, err := req.Do(ctx, client.client)
+	if err != nil {
+		return err
+	}
+	defer snapshotRes.Body.Close()
+	if snapshotRes.IsError() {
+		return ErrSnapshotFailed(snapshotRes.String())
+	}
+	return nil
+}
+
+func (client *OsClusterClient) GetSnapshots(ctx context.Context, repository string, snapshots []string) (responses.GetSnapshotsResponse, error) {
+	req := opensearchapi.SnapshotGetRequest{
+		Repository:        repository,
+		Snapshot:          snapshots,
+		IgnoreUnavailable: pointer.BoolPtr(true),
+	}
+	var response responses.GetSnapshotsResponse
+	snapshotRes, err := req.Do(ctx, client.client)
+	if err != nil {
+		return response, err
+	}
+	defer snapshotRes.Body.Close()
+	if snapshotRes.IsError() {
+		return response, ErrSnapshotFailed(snapshotRes.String())
+	}
+	err = json.NewDecoder(snapshotRes.Body).Decode(&response)
+	return response, err
+}
+
+func (client *OsClusterClient) DeleteSnapshot(ctx context.Context, repository string, snapshot string) error {
+	req := opensearchapi.SnapshotDeleteRequest{
+		Repository: repository,
+		Snapshot:   snapshot,
+	}
+	snapshotRes, err := req.Do(ctx, client.client)
+	if err != nil {
+		return err
+	}
+	defer snapshotRes.Body.Close()
+	if snapshotRes.IsError() && snapshotRes.StatusCode != 404 {
+		return ErrSnapshotFailed(snapshotRes.String())
+	}
+	return nil
+}
+
+func (client *OsClusterClient) RestoreSnapshot(ctx context.Context, repository string, snapshot string, settings requests.RestoreSnapshot) error {
+	body := opensearchutil.NewJSONReader(settings)
+	req := opensearchapi.SnapshotRestoreRequest{
+		Repository:        repository,
+		Snapshot:          snapshot,
+		Body:              body,
+		WaitForCompletion: pointer.BoolPtr(false),
+	}
+	restoreRes, err := req.Do(ctx, client.client)
+	if err != nil {
+		return err
+	}
+	defer restoreRes.Body.Close()
+	if restoreRes.IsError() {
+		return ErrSnapshotFailed(restoreRes.String())
+	}
+	return nil
+}
+
+func (client *OsClusterClient) GetIndicesRecovery(ctx context.Context, indices []string) (responses.IndicesRecoveryResponse, error) {
+	req := opensearchapi.IndicesRecoveryRequest{
+		Index: indices,
+	}
+	var response responses.IndicesRecoveryResponse
+	recoveryRes, err := req.Do(ctx, client.client)
+	if err != nil {
+		return response, err
+	}
+	defer recoveryRes.Body.Close()
+	if recoveryRes.IsError() {
+		return response, ErrIndicesRecoveryFailed(recoveryRes.String())
+	}
+	err = json.NewDecoder(recoveryRes.Body).Decode(&response)
+	return response, err
+}
+
+// GetRestoresInProgress returns the snapshot restores listed in the cluster state, a restore is removed once the
+// primaries of all restored indices started
+func (client *OsClusterClient) GetRestoresInProgress(ctx context.Context) ([]responses.RestoreInProgress, error) {
+	req := opensearchapi.ClusterStateRequest{
+		Metric: []string{"customs"},
+	}
+	var response responses.ClusterStateCustomsResponse
+	stateRes, err := req.Do(ctx, client.client)
+	if err != nil {
+		return nil, err
+	}
+	defer stateRes.Body.Close()
+	if stateRes.IsError() {
+		return nil, ErrSnapshotFailed(stateRes.String())
+	}
+	err = json.NewDecoder(stateRes.Body).Decode(&response)
+	return response.Restore.Snapshots, err
+}
+
+func (client *OsClusterClient) GetIndexTemplate(ctx context.Context, name string) (requests.IndexTemplate, bool, error) {
+	req := opensearchapi.IndicesGetIndexTemplateRequest{
+		Name:         []string{name},
+		FlatSettings: pointer.BoolPtr(true),
+	}
+	var response responses.GetIndexTemplatesResponse
+	templateRes, err := req.Do(ctx, client.client)
+	if err != nil {
+		return requests.IndexTemplate{}, false, err
+	}
+	defer templateRes.Body.Close()
+	if templateRes.StatusCode == 404 {
+		return requests.IndexTemplate{}, false, nil
+	}
+	if templateRes.IsError() {
+		return requests.IndexTemplate{}, false, ErrTemplateFailed(templateRes.String())
+	}
+	if err := json.NewDecoder(templateRes.Body).Decode(&response); err != nil {
+		return requests.IndexTemplate{}, false, err
+	}
+	for _, template := range response.IndexTemplates {
+		if template.Name == name {
+			return template.IndexTemplate, true, nil
+		}
+	}
+	return requests.IndexTemplate{}, false, nil
+}
+
+func (client *OsClusterClient) PutIndexTemplate(ctx context.Context, name string, template requests.IndexTemplate) error {
+	req := opensearchapi.IndicesPutIndexTemplateRequest{
+		Name: name,
+		Body: opensearchutil.NewJSONReader(template),
+	}
+	templateRes, err := req.Do(ctx, client.client)
+	if err != nil {
+		return err
+	}
+	defer templateRes.Body.Close()
+	if templateRes.IsError() {
+		return ErrTemplateFailed(templateRes.String())
+	}
+	return nil
+}
+
+func (client *OsClusterClient) DeleteIndexTemplate(ctx context.Context, name string) error {
+	req := opensearchapi.IndicesDeleteIndexTemplateRequest{
+		Name: name,
+	}
+	templateRes, err := req.Do(ctx, client.client)
+	if err != nil {
+		return err
+	}
+	defer templateRes.Body.Close()
+	if templateRes.IsError() && templateRes.StatusCode != 404 {
+		return ErrTemplateFailed(templateRes.String())
+	}
+	return nil
+}
+
+func (client *OsClusterClient) GetComponentTemplate(ctx context.Context, name string) (requests.ComponentTemplate, bool, error) {
+	req := opensearchapi.ClusterGetComponentTemplateRequest{
+		Name: []string{name},
+	}
+	var response responses.GetComponentTemplatesResponse
+	templateRes, err := req.Do(ctx, client.client)
+	if err != nil {
+		return requests.ComponentTemplate{}, false, err
+	}
+	defer templateRes.Body.Close()
+	if templateRes.StatusCode == 404 {
+		return requests.ComponentTemplate{}, false, nil
+	}
+	if templateRes.IsError() {
+		return requests.ComponentTemplate{}, false, ErrTemplateFailed(templateRes.String())
+	}
+	if err := json.NewDecoder(templateRes.Body).Decode(&response); err != nil {
+		return requests.ComponentTemplate{}, false, err
+	}
+	for _, template := range response.ComponentTemplates {
+		if template.Name == name {
+			return template.ComponentTemplate, true, nil
+		}
+	}
+	return requests.ComponentTemplate{}, false, nil
+}
+
+func (client *OsClusterClient) PutComponentTemplate(ctx context.Context, name string, template requests.ComponentTemplate) error {
+	req := opensearchapi.ClusterPutComponentTemplateRequest{
+		Name: name,
+		Body: opensearchutil.NewJSONReader(template),
+	}
+	templateRes, err := req.Do(ctx, client.client)
+	if err != nil {
+		return err
+	}
+	defer templateRes.Body.Close()
+	if templateRes.IsError() {
+		return ErrTemplateFailed(templateRes.String())
+	}
+	return nil
+}
+
+func (client *OsClusterClient) DeleteComponentTemplate(ctx context.Context, name string) error {
+	req := opensearchapi.ClusterDeleteComponentTemplateRequest{
+		Name: name,
+	}
+	templateRes, err := req.Do(ctx, client.client)
+	if err != nil {
+		return err
+	}
+	defer templateRes.Body.Close()
+	if templateRes.IsError() && templateRes.StatusCode != 404 {
+		return ErrTemplateFailed(templateRes.String())
+	}
+	return nil
+}
+
+// doRequest performs a request against an API that is not covered by the opensearch client, e.g. plugin APIs
+func (client *OsClusterClient) doRequest(ctx context.Context, method string, path string, params url.Values, body interface{}) (*opensearchapi.Response, error) {
+	var reader io.Reader
+	if body != nil {
+		reader = opensearchutil.NewJSONReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, path, reader)
+	if err != nil {
+		return nil, err
+	}
+	req.URL.RawQuery = params.Encode()
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	res, err := client.client.Perform(req)
+	if err != nil {
+		return nil, err
+	}
+	return &opensearchapi.Response{
+		StatusCode: res.StatusCode,
+		Body:       res.Body,
+		Header:     res.Header,
+	}, nil
+}
+
+func (client *OsClusterClient) GetISMPolicy(ctx context.Context, policyID string) (responses.ISMPolicyResponse, bool, error) {
+	var response responses.ISMPolicyResponse
+	policyRes, err := client.doRequest(ctx, http.MethodGet, "/_plugins/_ism/policies/"+url.PathEscape(policyID), nil, nil)
+	if err != nil {
+		return response, false, err
+	}
+	defer policyRes.Body.Close()
+	if policyRes.StatusCode == 404 {
+		return response, false, nil
+	}
+	if policyRes.IsError() {
+		return response, false, ErrISMFailed(policyRes.String())
+	}
+	err = json.NewDecoder(policyRes.Body).Decode(&response)
+	return response, true, err
+}
+
+// PutISMPolicy creates or updates a policy. Updates require the sequence number and primary term of the existing policy.
+func (client *OsClusterClient) PutISMPolicy(ctx context.Context, policyID string, policy requests.ISMPolicy, existing *responses.ISMPolicyResponse) (responses.ISMPolicyResponse, error) {
+	var response responses.ISMPolicyResponse
+	params := url.Values{}
+	if existing != nil {
+		params.Set("if_seq_no", strconv.FormatInt(existing.SeqNo, 10))
+		params.Set("if_primary_term", strconv.FormatInt(existing.PrimaryTerm, 10))
+	}
+	policyRes, err := client.doRequest(ctx, http.MethodPut, "/_plugins/_ism/policies/"+url.PathEscape(policyID), params, policy)
+	if err != nil {
+		return response, err
+	}
+	defer policyRes.Body.Close()
+	if policyRes.IsError() {
+		return response, ErrISMFailed(policyRes.String())
+	}
+	err = json.NewDecoder(policyRes.Body).Decode(&response)
+	return response, err
+}
+
+func (client *OsClusterClient) DeleteISMPolicy(ctx context.Context, policyID string) error {
+	policyRes, err := client.doRequest(ctx, http.MethodDelete, "/_plugins/_ism/policies/"+url.PathEscape(policyID), nil, nil)
+	if err != nil {
+		return err
+	}
+	defer policyRes.Body.Close()
+	if policyRes.IsError() && policyRes.StatusCode != 404 {
+		return ErrISMFailed(policyRes.String())
+	}
+	return nil
+}
+
+// ExplainISMIndices returns the policy of all indices matching the patterns, unmanaged indices have an empty policy
+func (client *OsClusterClient) ExplainISMIndices(ctx context.Context, patterns []string) (map[string]string, error) {
+	explainRes, err := client.doRequest(ctx, http.MethodGet, "/_plugins/_ism/explain/"+strings.Join(patterns, ","), nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer explainRes.Body.Close()
+	if explainRes.IsError() {
+		return nil, ErrISMFailed(explainRes.String())
+	}
+	var response map[string]json.RawMessage
+	if err := json.NewDecoder(explainRes.Body).Decode(&response); err != nil {
+		return nil, err
+	}
+	indices := make(map[string]string, len(response))
+	for index, raw := range response {
+		if index == "total_managed_indices" {
+			continue
+		}
+		var explain responses.ISMExplainIndex
+		if err := json.Unmarshal(raw, &explain); err != nil {
+			return nil, err
+		}
+		switch {
+		case explain.PolicyID != nil:
+			indices[index] = *explain.PolicyID
+		case explain.OpendistroPolicyID != nil:
+			indices[index] = *explain.OpendistroPolicyID
+		default:
+			indices[index] = ""
+		}
+	}
+	return indices, nil
+}
+
+func (client *OsClusterClient) AddISMPolicy(ctx context.Context, indices []string, policyID string) (responses.ISMIndicesResponse, error) {
+	return client.changeISMIndices(ctx, "/_plugins/_ism/add/"+strings.Join(indices, ","), requests.ISMAddPolicy{PolicyID: policyID})
+}
+
+func (client *OsClusterClient) RemoveISMPolicy(ctx context.Context, indices []string) (responses.ISMIndicesResponse, error) {
+	return client.changeISMIndices(ctx, "/_plugins/_ism/remove/"+strings.Join(indices, ","), nil)
+}
+
+func (client *OsClusterClient) changeISMIndices(ctx context.Context, path string, body interface{}) (responses.ISMIndicesResponse, error) {
+	var response responses.ISMIndicesResponse
+	indicesRes, err := client.doRequest(ctx, http.MethodPost, path, nil, body)
+	if err != nil {
+		return response, err
+	}
+	defer indicesRes.Body.Close()
+	if indicesRes.IsError() {
+		return response, ErrISMFailed(indicesRes.String())
+	}
+	err = json.NewDecoder(indicesRes.Body).Decode(&response)
+	return response, err
+}
+
+// GetSecurityResource fetches a user, role or role mapping from the security REST API
+func (client *OsClusterClient) GetSecurityResource(ctx context.Context, resourceType string, name string) (map[string]interface{}, bool, error) {
+	securityRes, err := client.doRequest(ctx, http.MethodGet, securityResourcePath(resourceType, name), nil, nil)
+	if err != nil {
+		return nil, false, err
+	}
+	defer securityRes.Body.Close()
+	if securityRes.StatusCode == 404 {
+		return nil, false, nil
+	}
+	if securityRes.IsError() {
+		return nil, false, ErrSecurityFailed(securityRes.String())
+	}
+	var response map[string]map[string]interface{}
+	if err := json.NewDecoder(securityRes.Body).Decode(&response); err != nil {
+		return nil, false, err
+	}
+	resource, found := response[name]
+	return resource, found, nil
+}
+
+func (client *OsClusterClient) PutSecurityResource(ctx context.Context, resourceType string, name string, resource interface{}) error {
+	securityRes, err := client.doRequest(ctx, http.MethodPut, securityResourcePath(resourceType, name), nil, resource)
+	if err != nil {
+		return err
+	}
+	defer securityRes.Body.Close()
+	if securityRes.IsError() {
+		return ErrSecurityFailed(securityRes.String())
+	}
+	return nil
+}
+
+func (client *OsClusterClient) DeleteSecurityResource(ctx context.Context, resourceType string, name string) error {
+	securityRes, err := client.doRequest(ctx, http.MethodDelete, securityResourcePath(resourceType, name), nil, nil)
+	if err != nil {
+		return err
+	}
+	defer securityRes.Body.Close()
+	if securityRes.IsError() && securityRes.StatusCode != 404 {
+		return ErrSecurityFailed(securityRes.String())
+	}
+	return nil
+}
+
+func securityResourcePath(resourceType string, name string) string {
+	return fmt.Sprintf("/_plugins/_security/api/%s/%s", resourceType, url.PathEscape(name))
+}
+
+// GetRemoteClusters returns the connection state of all remote clusters by their alias
+func (client *OsClusterClient) GetRemoteClusters(ctx context.Context) (map[string]responses.RemoteClusterInfo, error) {
+	remoteRes, err := client.doRequest(ctx, http.MethodGet, "/_remote/info", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer remoteRes.Body.Close()
+	if remoteRes.IsError() {
+		return nil, ErrReplicationFailed(remoteRes.String())
+	}
+	var response map[string]responses.RemoteClusterInfo
+	err = json.NewDecoder(remoteRes.Body).Decode(&response)
+	return response, err
+}
+
+func (client *OsClusterClient) CreateAutoFollowRule(ctx context.Context, rule requests.AutoFollowRule) error {
+	return client.replicationRequest(ctx, http.MethodPost, "/_plugins/_replication/_autofollow", rule)
+}
+
+func (client *OsClusterClient) DeleteAutoFollowRule(ctx context.Context, leaderAlias string, name string) error {
+	ruleRes, err := client.doRequest(ctx, http.MethodDelete, "/_plugins/_replication/_autofollow", nil, requests.AutoFollowRule{LeaderAlias: leaderAlias, Name: name})
+	if err != nil {
+		return err
+	}
+	defer ruleRes.Body.Close()
+	if ruleRes.IsError() && ruleRes.StatusCode != 404 {
+		return ErrReplicationFailed(ruleRes.String())
+	}
+	return nil
+}
+
+func (client *OsClusterClient) GetAutoFollowStats(ctx context.Context) (responses.AutoFollowStatsResponse, error) {
+	var response responses.AutoFollowStatsResponse
+	statsRes, err := client.doRequest(ctx, http.MethodGet, "/_plugins/_replication/autofollow_stats", nil, nil)
+	if err != nil {
+		return response, err
+	}
+	defer statsRes.Body.Close()
+	if statsRes.IsError() {
+		return response, ErrReplicationFailed(statsRes.String())
+	}
+	err = json.NewDecoder(statsRes.Body).Decode(&response)
+	return response, err
+}
+
+func (client *OsClusterClient) GetReplicationStatus(ctx context.Context, index string) (responses.ReplicationStatusResponse, error) {
+	var response responses.ReplicationStatusResponse
+	statusRes, err := client.doRequest(ctx, http.MethodGet, "/_plugins/_replication/"+url.PathEscape(index)+"/_status", nil, nil)
+	if err != nil {
+		return response, err
+	}
+	defer statusRes.Body.Close()
+	if statusRes.IsError() {
+		return response, ErrReplicationFailed(statusRes.String())
+	}
+	err = json.NewDecoder(statusRes.Body).Decode(&response)
+	return response, err
+}
+
+// StopReplication stops replicating the follower index, afterwards it is a regular index that accepts writes
+func (client *OsClusterClient) StopReplication(ctx context.Context, index string) error {
+	return client.replicationRequest(ctx, http.MethodPost, "/_plugins/_replication/"+url.PathEscape(index)+"/_stop", struct{}{})
+}
+
+func (client *OsClusterClient) replicationRequest(ctx context.Context, method string, path string, body interface{}) error {
+	replicationRes, err := client.doRequest(ctx, method, path, nil, body)
+	if err != nil {
+		return err
+	}
+	defer replicationRes.Body.Close()
+	if replicationRes.IsError() {
+		return ErrReplicationFailed(replicationRes.String())
+	}
+	return nil
+}
@@ -25,7 +25,7 @@ var _ = Describe("OpensearchCLuster API", func() {
 	BeforeEach(func() {
 		By("Creating open search client ")
 		Eventually(func() bool {
-			clusterClient, err := NewOsClusterClient(TestClusterUrl, TestClusterUserName, TestClusterPassword)
+			clusterClient, err := NewOsClusterClient(TestClusterUrl, TestClusterUserName, TestClusterPassword, TestClusterTLSConfig)
 			if err != nil {
 				return false
 			}
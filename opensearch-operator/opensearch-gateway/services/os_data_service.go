@@ -1,6 +1,8 @@
 package services
 
 import (
+	"context"
+	"strconv"
 	"strings"
 
 	"opensearch.opster.io/opensearch-gateway/responses"
@@ -17,8 +19,8 @@ const (
 	ClusterSettingsAllocationNone      ClusterSettingsAllocation = "none"
 )
 
-func HasIndicesWithNoReplica(service *OsClusterClient) (bool, error) {
-	response, err := service.CatIndices()
+func HasIndicesWithNoReplica(ctx context.Context, service *OsClusterClient) (bool, error) {
+	response, err := service.CatIndices(ctx)
 	if err != nil {
 		return false, err
 	}
@@ -30,9 +32,52 @@ func HasIndicesWithNoReplica(service *OsClusterClient) (bool, error) {
 	return false, err
 }
 
-func HasShardsOnNode(service *OsClusterClient, nodeName string) (bool, error) {
+// MaxIndexReplicas returns the highest number of replicas configured for any index
+func MaxIndexReplicas(ctx context.Context, service *OsClusterClient) (int, error) {
+	response, err := service.CatIndices(ctx)
+	if err != nil {
+		return 0, err
+	}
+	maxReplicas := 0
+	for _, index := range response {
+		replicas, err := strconv.Atoi(index.Rep)
+		if err == nil && replicas > maxReplicas {
+			maxReplicas = replicas
+		}
+	}
+	return maxReplicas, nil
+}
+
+// NodeUsage is the resource usage of one or more nodes in percent
+type NodeUsage struct {
+	DiskPercent    float64
+	CpuPercent     float64
+	JvmHeapPercent float64
+}
+
+// AverageNodeUsage calculates the average disk, cpu and heap usage of the given nodes
+func AverageNodeUsage(nodes []responses.NodeStatResponse) NodeUsage {
+	usage := NodeUsage{}
+	if len(nodes) == 0 {
+		return usage
+	}
+	for _, node := range nodes {
+		if total := node.Fs.Total.TotalInBytes; total > 0 {
+			usage.DiskPercent += float64(total-node.Fs.Total.AvailableInBytes) / float64(total) * 100
+		}
+		usage.CpuPercent += float64(node.Os.Cpu.Percent)
+		usage.JvmHeapPercent += float64(node.Jvm.Mem.HeapUsedPercent)
+	}
+	count := float64(len(nodes))
+	usage.DiskPercent /= count
+	usage.CpuPercent /= count
+	usage.JvmHeapPercent /= count
+	return usage
+}
+
+func HasShardsOnNode(ctx context.Context, service *OsClusterClient, nodeName string) (bool, error) {
 	var headers []string
-	response, err := service.CatShards(headers)
+	response, err := service.CatShards(ctx, headers)
 	if err != nil {
 		return false, err
 	}
@@ -44,9 +89,36 @@ func HasShardsOnNode(service *OsClusterClient, nodeName string) (bool, error) {
 	return false, err
 }
 
-func HasIndexPrimariesOnNode(service *OsClusterClient, nodeName string, indices []string) (bool, error) {
+// MaxShardCopiesOnNodes returns the highest number of copies of a single shard that are allocated to the nodes
+func MaxShardCopiesOnNodes(ctx context.Context, service *OsClusterClient, nodeNames []string) (int, error) {
+	var headers []string
+	response, err := service.CatShards(ctx, headers)
+	if err != nil {
+		return 0, err
+	}
+	return ShardCopiesOnNodes(response, nodeNames), nil
+}
+
+// ShardCopiesOnNodes returns the highest number of copies of a single shard that are allocated to the nodes
+func ShardCopiesOnNodes(shards []responses.CatShardsResponse, nodeNames []string) int {
+	copies := map[string]int{}
+	maxCopies := 0
+	for _, shard := range shards {
+		if !helpers.ContainsString(nodeNames, shard.NodeName) {
+			continue
+		}
+		key := shard.Index + "/" + shard.Shard
+		copies[key]++
+		if copies[key] > maxCopies {
+			maxCopies = copies[key]
+		}
+	}
+	return maxCopies
+}
+
+func HasIndexPrimariesOnNode(ctx context.Context, service *OsClusterClient, nodeName string, indices []string) (bool, error) {
 	var headers []string
-	response, err := service.CatNamedIndicesShards(headers, indices)
+	response, err := service.CatNamedIndicesShards(ctx, headers, indices)
 	if err != nil {
 		return false, err
 	}
@@ -63,8 +135,8 @@ func HasIndexPrimariesOnNode(service *OsClusterClient, nodeName string, indices
 	return false, err
 }
 
-func AppendExcludeNodeHost(service *OsClusterClient, nodeNameToExclude string) (bool, error) {
-	response, err := service.GetClusterSettings()
+func AppendExcludeNodeHost(ctx context.Context, service *OsClusterClient, nodeNameToExclude string) (bool, error) {
+	response, err := service.GetClusterSettings(ctx)
 	if err != nil {
 		return false, err
 	}
@@ -88,13 +160,13 @@ func AppendExcludeNodeHost(service *OsClusterClient, nodeNameToExclude string) (
 	}
 	settings := createClusterSettingsResponseWithExcludeName(valAsString)
 	if err == nil {
-		_, err = service.PutClusterSettings(settings)
+		_, err = service.PutClusterSettings(ctx, settings)
 	}
 	return err == nil, err
 }
 
-func RemoveExcludeNodeHost(service *OsClusterClient, nodeNameToExclude string) (bool, error) {
-	response, err := service.GetClusterSettings()
+func RemoveExcludeNodeHost(ctx context.Context, service *OsClusterClient, nodeNameToExclude string) (bool, error) {
+	response, err := service.GetClusterSettings(ctx)
 	if err != nil {
 		return false, err
 	}
@@ -106,14 +178,14 @@ func RemoveExcludeNodeHost(service *OsClusterClient, nodeNameToExclude string) (
 	valAsString = strings.ReplaceAll(valAsString, ",,", ",")
 	settings := createClusterSettingsResponseWithExcludeName(valAsString)
 	if err == nil {
-		_, err = service.PutClusterSettings(settings)
+		_, err = service.PutClusterSettings(ctx, settings)
 	}
 	return err == nil, err
 }
 
-func SetClusterShardAllocation(service *OsClusterClient, enableType ClusterSettingsAllocation) error {
+func SetClusterShardAllocation(ctx context.Context, service *OsClusterClient, enableType ClusterSettingsAllocation) error {
 	settings := createClusterSettingsAllocationEnable(enableType)
-	_, err := service.PutClusterSettings(settings)
+	_, err := service.PutClusterSettings(ctx, settings)
 	return err
 }
 
@@ -147,8 +219,8 @@ func createClusterSettingsAllocationEnable(enable ClusterSettingsAllocation) res
 	}}
 }
 
-func CheckClusterStatusForRestart(service *OsClusterClient, drainNodes bool) (bool, error) {
-	health, err := service.GetHealth()
+func CheckClusterStatusForRestart(ctx context.Context, service *OsClusterClient, drainNodes bool) (bool, error) {
+	health, err := service.GetHealth(ctx)
 	if err != nil {
 		return false, err
 	}
@@ -161,7 +233,7 @@ func CheckClusterStatusForRestart(service *OsClusterClient, drainNodes bool) (bo
 		return false, nil
 	}
 
-	flatSettings, err := service.GetFlatClusterSettings()
+	flatSettings, err := service.GetFlatClusterSettings(ctx)
 	if err != nil {
 		return false, err
 	}
@@ -171,29 +243,29 @@ func CheckClusterStatusForRestart(service *OsClusterClient, drainNodes bool) (bo
 	}
 
 	// Set shard routing to all
-	if err := SetClusterShardAllocation(service, ClusterSettingsAllocationAll); err != nil {
+	if err := SetClusterShardAllocation(ctx, service, ClusterSettingsAllocationAll); err != nil {
 		return false, err
 	}
 
 	return false, nil
 }
 
-func PreparePodForDelete(service *OsClusterClient, podName string, drainNode bool, nodeCount int32) (bool, error) {
+func PreparePodForDelete(ctx context.Context, service *OsClusterClient, podName string, drainNode bool, nodeCount int32) (bool, error) {
 	if drainNode {
 		// If we are draining nodes then drain the working node
-		_, err := AppendExcludeNodeHost(service, podName)
+		_, err := AppendExcludeNodeHost(ctx, service, podName)
 		if err != nil {
 			return false, err
 		}
 
 		// If there are only 2 data nodes only check for system indics
 		if nodeCount == 2 {
-			systemIndices, err := GetExistingSystemIndices(service)
+			systemIndices, err := GetExistingSystemIndices(ctx, service)
 			if err != nil {
 				return false, err
 			}
 
-			systemPrimaries, err := HasIndexPrimariesOnNode(service, podName, systemIndices)
+			systemPrimaries, err := HasIndexPrimariesOnNode(ctx, service, podName, systemIndices)
 			if err != nil {
 				return false, err
 			}
@@ -201,7 +273,7 @@ func PreparePodForDelete(service *OsClusterClient, podName string, drainNode boo
 		}
 
 		// Check if there are any shards on the node
-		nodeNotEmpty, err := HasShardsOnNode(service, podName)
+		nodeNotEmpty, err := HasShardsOnNode(ctx, service, podName)
 		if err != nil {
 			return false, err
 		}
@@ -209,13 +281,13 @@ func PreparePodForDelete(service *OsClusterClient, podName string, drainNode boo
 		return !nodeNotEmpty, nil
 	}
 	// Update cluster routing before deleting appropriate ordinal pod
-	if err := SetClusterShardAllocation(service, ClusterSettingsAllocationPrimaries); err != nil {
+	if err := SetClusterShardAllocation(ctx, service, ClusterSettingsAllocationPrimaries); err != nil {
 		return false, err
 	}
 	return true, nil
 }
 
-func GetExistingSystemIndices(service *OsClusterClient) ([]string, error) {
+func GetExistingSystemIndices(ctx context.Context, service *OsClusterClient) ([]string, error) {
 	var existing []string
 	systemIndices := []string{
 		".kibana_1",
@@ -224,7 +296,7 @@ func GetExistingSystemIndices(service *OsClusterClient) ([]string, error) {
 	systemIndices = append(systemIndices, AdditionalSystemIndices...)
 
 	for _, systemIndex := range systemIndices {
-		exists, err := service.IndexExists(systemIndex)
+		exists, err := service.IndexExists(ctx, systemIndex)
 		if err != nil {
 			return existing, err
 		}
@@ -26,7 +26,7 @@ var _ = Describe("OpensearchCLuster data service tests", func() {
 	BeforeEach(func() {
 		By("Creating open search client ")
 		Eventually(func() bool {
-			clusterClient, err := NewOsClusterClient(TestClusterUrl, TestClusterUserName, TestClusterPassword)
+			clusterClient, err := NewOsClusterClient(TestClusterUrl, TestClusterUserName, TestClusterPassword, TestClusterTLSConfig)
 			if err != nil {
 				return false
 			}
@@ -0,0 +1,65 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"opensearch.opster.io/opensearch-gateway/responses"
+)
+
+// IndicesWithISMPolicy returns the indices matching the patterns that are managed by the policy, sorted by name.
+// An empty policy ID returns the indices that are not managed by any policy.
+func IndicesWithISMPolicy(ctx context.Context, service *OsClusterClient, patterns []string, policyID string) ([]string, error) {
+	explain, err := service.ExplainISMIndices(ctx, patterns)
+	if err != nil {
+		return nil, err
+	}
+	var indices []string
+	for index, indexPolicy := range explain {
+		if indexPolicy == policyID {
+			indices = append(indices, index)
+		}
+	}
+	sort.Strings(indices)
+	return indices, nil
+}
+
+// AttachISMPolicy attaches the policy to all indices matching the patterns that are not yet managed by a policy.
+// Returns the indices the policy was attached to.
+func AttachISMPolicy(ctx context.Context, service *OsClusterClient, patterns []string, policyID string) ([]string, error) {
+	indices, err := IndicesWithISMPolicy(ctx, service, patterns, "")
+	if err != nil || len(indices) == 0 {
+		return nil, err
+	}
+	response, err := service.AddISMPolicy(ctx, indices, policyID)
+	if err != nil {
+		return nil, err
+	}
+	return indices, ismIndicesError(response)
+}
+
+// DetachISMPolicy removes the policy from all indices that are managed by it
+func DetachISMPolicy(ctx context.Context, service *OsClusterClient, policyID string) error {
+	indices, err := IndicesWithISMPolicy(ctx, service, []string{"*"}, policyID)
+	if err != nil || len(indices) == 0 {
+		return err
+	}
+	response, err := service.RemoveISMPolicy(ctx, indices)
+	if err != nil {
+		return err
+	}
+	return ismIndicesError(response)
+}
+
+func ismIndicesError(response responses.ISMIndicesResponse) error {
+	if !response.Failures {
+		return nil
+	}
+	reasons := make([]string, 0, len(response.FailedIndices))
+	for _, failed := range response.FailedIndices {
+		reasons = append(reasons, fmt.Sprintf("%s: %s", failed.IndexName, failed.Reason))
+	}
+	return ErrISMFailed(strings.Join(reasons, ", "))
+}
@@ -0,0 +1,63 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"sort"
+
+	"opensearch.opster.io/opensearch-gateway/responses"
+)
+
+// ReplicationNotInProgress is the replication status of indices that are not replicated
+const ReplicationNotInProgress = "REPLICATION NOT IN PROGRESS"
+
+// SetRemoteClusterSeeds configures the transport addresses used to connect to remote clusters by their alias.
+// Empty seeds remove the remote cluster.
+func SetRemoteClusterSeeds(ctx context.Context, service *OsClusterClient, seeds map[string][]string) error {
+	settings := make(map[string]interface{}, len(seeds))
+	for alias, aliasSeeds := range seeds {
+		var value interface{}
+		if len(aliasSeeds) > 0 {
+			value = aliasSeeds
+		}
+		settings[fmt.Sprintf("cluster.remote.%s.seeds", alias)] = value
+	}
+	_, err := service.PutClusterSettings(ctx, responses.ClusterSettingsResponse{Persistent: settings})
+	return err
+}
+
+// ReplicatedIndices returns the replication status of all replicated indices matching one of the patterns, sorted by name
+func ReplicatedIndices(ctx context.Context, service *OsClusterClient, patterns []string) ([]responses.ReplicationStatusResponse, error) {
+	if len(patterns) == 0 {
+		return nil, nil
+	}
+	indices, err := service.CatIndices(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, index := range indices {
+		for _, pattern := range patterns {
+			if matched, _ := path.Match(pattern, index.Index); matched {
+				names = append(names, index.Index)
+				break
+			}
+		}
+	}
+	sort.Strings(names)
+
+	var replicated []responses.ReplicationStatusResponse
+	for _, name := range names {
+		status, err := service.GetReplicationStatus(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		if status.Status == ReplicationNotInProgress {
+			continue
+		}
+		status.FollowerIndex = name
+		replicated = append(replicated, status)
+	}
+	return replicated, nil
+}
@@ -0,0 +1,96 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+)
+
+const (
+	SecurityInternalUsers = "internalusers"
+	SecurityRoles         = "roles"
+	SecurityRolesMapping  = "rolesmapping"
+)
+
+// Fields returned by the security API that are not part of the resource definition
+var securityMetadataFields = []string{"reserved", "hidden", "static", "hash", "password"}
+
+// EnsureSecurityResource creates the user, role or role mapping or updates it if it differs from the desired one.
+// Passwords can not be compared, force updates the resource regardless of its current state.
+// Returns true if the resource had to be created or updated.
+func EnsureSecurityResource(ctx context.Context, service *OsClusterClient, resourceType string, name string, resource interface{}, force bool) (bool, error) {
+	existing, found, err := service.GetSecurityResource(ctx, resourceType, name)
+	if err != nil {
+		return false, err
+	}
+	if found && (isTrue(existing["reserved"]) || isTrue(existing["static"])) {
+		return false, ErrSecurityReserved
+	}
+	if found && !force && SecurityResourcesMatch(existing, resource) {
+		return false, nil
+	}
+	return true, service.PutSecurityResource(ctx, resourceType, name, resource)
+}
+
+// SecurityResourcesMatch compares a resource returned by the security API with the desired one.
+// Empty fields and metadata added by the security plugin are ignored.
+func SecurityResourcesMatch(existing map[string]interface{}, desired interface{}) bool {
+	return reflect.DeepEqual(normalizeSecurityResource(existing), normalizeSecurityResource(desired))
+}
+
+func normalizeSecurityResource(resource interface{}) interface{} {
+	encoded, err := json.Marshal(resource)
+	if err != nil {
+		return resource
+	}
+	var normalized map[string]interface{}
+	if err := json.Unmarshal(encoded, &normalized); err != nil {
+		return resource
+	}
+	for _, field := range securityMetadataFields {
+		delete(normalized, field)
+	}
+	return removeEmptyValues(normalized)
+}
+
+func removeEmptyValues(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			item = removeEmptyValues(item)
+			if isEmpty(item) {
+				delete(v, key)
+			} else {
+				v[key] = item
+			}
+		}
+		return v
+	case []interface{}:
+		for i := range v {
+			v[i] = removeEmptyValues(v[i])
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+func isEmpty(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case string:
+		return v == ""
+	case []interface{}:
+		return len(v) == 0
+	case map[string]interface{}:
+		return len(v) == 0
+	default:
+		return false
+	}
+}
+
+func isTrue(value interface{}) bool {
+	b, ok := value.(bool)
+	return ok && b
+}
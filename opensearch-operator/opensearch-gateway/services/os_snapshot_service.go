@@ -0,0 +1,144 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"opensearch.opster.io/opensearch-gateway/requests"
+	"opensearch.opster.io/opensearch-gateway/responses"
+)
+
+const (
+	SnapshotStateInProgress = "IN_PROGRESS"
+	SnapshotStateSuccess    = "SUCCESS"
+	SnapshotStatePartial    = "PARTIAL"
+	SnapshotStateFailed     = "FAILED"
+
+	RecoveryTypeSnapshot = "SNAPSHOT"
+	RecoveryStageDone    = "DONE"
+)
+
+// IndexRestoreProgress summarizes the recovery of all shards of an index that is restored from a snapshot
+type IndexRestoreProgress struct {
+	Index      string
+	Stage      string
+	Percent    string
+	Shards     int
+	DoneShards int
+}
+
+func (p IndexRestoreProgress) Done() bool {
+	return p.Shards > 0 && p.Shards == p.DoneShards
+}
+
+// EnsureSnapshotRepository registers the repository if it does not exist or its settings differ.
+// Returns true if the repository had to be created or updated.
+func EnsureSnapshotRepository(ctx context.Context, service *OsClusterClient, name string, repository requests.SnapshotRepository) (bool, error) {
+	existing, found, err := service.GetSnapshotRepository(ctx, name)
+	if err != nil {
+		return false, err
+	}
+	if found && repositoryMatches(existing[name], repository) {
+		return false, nil
+	}
+	return true, service.PutSnapshotRepository(ctx, name, repository)
+}
+
+func repositoryMatches(existing requests.SnapshotRepository, desired requests.SnapshotRepository) bool {
+	if existing.Type != desired.Type {
+		return false
+	}
+	if len(existing.Settings) == 0 && len(desired.Settings) == 0 {
+		return true
+	}
+	return reflect.DeepEqual(existing.Settings, desired.Settings)
+}
+
+// GetSnapshotsWithPrefix returns all snapshots in the repository whose name starts with prefix, oldest first
+func GetSnapshotsWithPrefix(ctx context.Context, service *OsClusterClient, repository string, prefix string) ([]responses.SnapshotResponse, error) {
+	response, err := service.GetSnapshots(ctx, repository, []string{prefix + "*"})
+	if err != nil {
+		return nil, err
+	}
+	snapshots := response.Snapshots
+	sort.SliceStable(snapshots, func(i, j int) bool {
+		return snapshots[i].StartTimeInMillis < snapshots[j].StartTimeInMillis
+	})
+	return snapshots, nil
+}
+
+// GetSnapshot returns a single snapshot, the second return value is false if it does not exist
+func GetSnapshot(ctx context.Context, service *OsClusterClient, repository string, snapshot string) (responses.SnapshotResponse, bool, error) {
+	response, err := service.GetSnapshots(ctx, repository, []string{snapshot})
+	if err != nil {
+		return responses.SnapshotResponse{}, false, err
+	}
+	for _, s := range response.Snapshots {
+		if s.Snapshot == snapshot {
+			return s, true, nil
+		}
+	}
+	return responses.SnapshotResponse{}, false, nil
+}
+
+// GetSnapshotRestoreProgress returns the recovery progress of all indices restored from the given snapshot, sorted by index name
+func GetSnapshotRestoreProgress(ctx context.Context, service *OsClusterClient, repository string, snapshot string) ([]IndexRestoreProgress, error) {
+	response, err := service.GetIndicesRecovery(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	return SnapshotRestoreProgress(response, repository, snapshot), nil
+}
+
+// SnapshotRestoreProgress summarizes the shard recoveries from the given snapshot per index, sorted by index name.
+// Indices without a recovery from the snapshot are skipped.
+func SnapshotRestoreProgress(response responses.IndicesRecoveryResponse, repository string, snapshot string) []IndexRestoreProgress {
+	var progress []IndexRestoreProgress
+	for index, recovery := range response {
+		indexProgress := IndexRestoreProgress{Index: index, Stage: RecoveryStageDone}
+		var percentSum float64
+		for _, shard := range recovery.Shards {
+			if shard.Type != RecoveryTypeSnapshot || shard.Source.Repository != repository || shard.Source.Snapshot != snapshot {
+				continue
+			}
+			indexProgress.Shards++
+			if shard.Stage == RecoveryStageDone {
+				indexProgress.DoneShards++
+				percentSum += 100
+				continue
+			}
+			indexProgress.Stage = shard.Stage
+			percent, err := strconv.ParseFloat(strings.TrimSuffix(shard.Index.Size.Percent, "%"), 64)
+			if err == nil {
+				percentSum += percent
+			}
+		}
+		if indexProgress.Shards == 0 {
+			continue
+		}
+		indexProgress.Percent = fmt.Sprintf("%.1f%%", percentSum/float64(indexProgress.Shards))
+		progress = append(progress, indexProgress)
+	}
+	sort.Slice(progress, func(i, j int) bool {
+		return progress[i].Index < progress[j].Index
+	})
+	return progress
+}
+
+// SnapshotRestoreInProgress checks if a restore of the snapshot is still listed in the cluster state
+func SnapshotRestoreInProgress(ctx context.Context, service *OsClusterClient, repository string, snapshot string) (bool, error) {
+	restores, err := service.GetRestoresInProgress(ctx)
+	if err != nil {
+		return false, err
+	}
+	for _, restore := range restores {
+		if restore.Repository == repository && restore.Snapshot == snapshot {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,117 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"opensearch.opster.io/opensearch-gateway/requests"
+)
+
+// EnsureIndexTemplate creates the index template or updates it if it differs from the desired one.
+// Returns true if the template had to be created or updated.
+func EnsureIndexTemplate(ctx context.Context, service *OsClusterClient, name string, template requests.IndexTemplate) (bool, error) {
+	existing, found, err := service.GetIndexTemplate(ctx, name)
+	if err != nil {
+		return false, err
+	}
+	if found && indexTemplatesMatch(existing, template) {
+		return false, nil
+	}
+	return true, service.PutIndexTemplate(ctx, name, template)
+}
+
+// EnsureComponentTemplate creates the component template or updates it if it differs from the desired one.
+// Returns true if the template had to be created or updated.
+func EnsureComponentTemplate(ctx context.Context, service *OsClusterClient, name string, template requests.ComponentTemplate) (bool, error) {
+	existing, found, err := service.GetComponentTemplate(ctx, name)
+	if err != nil {
+		return false, err
+	}
+	if found && reflect.DeepEqual(existing.Version, template.Version) && templatesMatch(existing.Template, template.Template) {
+		return false, nil
+	}
+	return true, service.PutComponentTemplate(ctx, name, template)
+}
+
+func indexTemplatesMatch(existing requests.IndexTemplate, desired requests.IndexTemplate) bool {
+	return stringsEqual(existing.IndexPatterns, desired.IndexPatterns) &&
+		stringsEqual(existing.ComposedOf, desired.ComposedOf) &&
+		reflect.DeepEqual(existing.Priority, desired.Priority) &&
+		reflect.DeepEqual(existing.Version, desired.Version) &&
+		templatesMatch(existing.Template, desired.Template)
+}
+
+// templatesMatch compares templates the way opensearch stores them.
+// Settings are returned with string values and the index. prefix, mappings and aliases as they were sent.
+func templatesMatch(existing *requests.Template, desired *requests.Template) bool {
+	if existing == nil {
+		existing = &requests.Template{}
+	}
+	if desired == nil {
+		desired = &requests.Template{}
+	}
+	return reflect.DeepEqual(normalizeSettings(existing.Settings), normalizeSettings(desired.Settings)) &&
+		reflect.DeepEqual(normalizeJSON(existing.Mappings), normalizeJSON(desired.Mappings)) &&
+		reflect.DeepEqual(normalizeJSON(existing.Aliases), normalizeJSON(desired.Aliases))
+}
+
+func normalizeSettings(settings map[string]interface{}) map[string]string {
+	flat := map[string]string{}
+	flattenSettings("", settings, flat)
+	normalized := make(map[string]string, len(flat))
+	for key, value := range flat {
+		if !strings.HasPrefix(key, "index.") {
+			key = "index." + key
+		}
+		normalized[key] = value
+	}
+	return normalized
+}
+
+func flattenSettings(prefix string, settings map[string]interface{}, flat map[string]string) {
+	for key, value := range settings {
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+		switch v := value.(type) {
+		case map[string]interface{}:
+			flattenSettings(key, v, flat)
+		case []interface{}:
+			encoded, _ := json.Marshal(v)
+			flat[key] = string(encoded)
+		default:
+			flat[key] = fmt.Sprint(v)
+		}
+	}
+}
+
+// normalizeJSON converts the value into its generic json representation, empty maps are treated as missing
+func normalizeJSON(value map[string]interface{}) interface{} {
+	if len(value) == 0 {
+		return nil
+	}
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return value
+	}
+	var normalized interface{}
+	if err := json.Unmarshal(encoded, &normalized); err != nil {
+		return value
+	}
+	return normalized
+}
+
+func stringsEqual(left []string, right []string) bool {
+	if len(left) != len(right) {
+		return false
+	}
+	for i := range left {
+		if left[i] != right[i] {
+			return false
+		}
+	}
+	return true
+}
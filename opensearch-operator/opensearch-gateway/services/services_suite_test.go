@@ -3,6 +3,7 @@ package services
 /*
 
    import (
+   	"crypto/tls"
    	"fmt"
    	. "github.com/onsi/ginkgo"
    	. "github.com/onsi/gomega"
@@ -26,6 +27,9 @@ package services
    	TestClusterPassword = "admin"
    )
 
+   // The test cluster uses the demo certificates
+   var TestClusterTLSConfig = &tls.Config{InsecureSkipVerify: true}
+
    var path = filepath.Join(helpers.GetOperatorRootPath(), "test_resources/docker-compose.yml")
 
    var _ = BeforeSuite(func() {
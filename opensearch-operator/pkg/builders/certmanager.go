@@ -0,0 +1,119 @@
+package builders
+
+import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	opsterv1 "opensearch.opster.io/api/v1"
+)
+
+const (
+	certManagerAPIGroup = "cert-manager.io"
+	// Identifies the interface a cert-manager Certificate was created for
+	CertificateInterfaceLabel = "opster.io/certificate-interface"
+)
+
+var CertificateGVK = schema.GroupVersionKind{
+	Group:   certManagerAPIGroup,
+	Version: "v1",
+	Kind:    "Certificate",
+}
+
+var CertificateListGVK = schema.GroupVersionKind{
+	Group:   certManagerAPIGroup,
+	Version: "v1",
+	Kind:    "CertificateList",
+}
+
+// NewCertificate builds a cert-manager Certificate that stores the issued certificate in the given secret.
+// The subject matches the certificates generated by the operator so that the same nodes_dn and admin_dn can be used.
+func NewCertificate(
+	cr *opsterv1.OpenSearchCluster,
+	name string,
+	secretName string,
+	interfaceName string,
+	commonName string,
+	dnsNames []string,
+	config opsterv1.TlsCertificateConfig,
+) *unstructured.Unstructured {
+	issuerRef := config.CertManager.IssuerRef
+	kind := issuerRef.Kind
+	if kind == "" {
+		kind = "Issuer"
+	}
+	group := issuerRef.Group
+	if group == "" {
+		group = certManagerAPIGroup
+	}
+
+	subject := map[string]interface{}{
+		"organizationalUnits": []interface{}{cr.Name},
+	}
+	if config.Subject != nil {
+		if len(config.Subject.Organizations) > 0 {
+			subject["organizations"] = toInterfaceSlice(config.Subject.Organizations)
+		}
+		if len(config.Subject.Countries) > 0 {
+			subject["countries"] = toInterfaceSlice(config.Subject.Countries)
+		}
+		if len(config.Subject.Localities) > 0 {
+			subject["localities"] = toInterfaceSlice(config.Subject.Localities)
+		}
+	}
+	// OpenSearch only accepts keys in PKCS8 format
+	privateKey := map[string]interface{}{
+		"encoding":       "PKCS8",
+		"rotationPolicy": "Always",
+	}
+	if config.PrivateKey != nil {
+		if config.PrivateKey.Algorithm != "" {
+			privateKey["algorithm"] = config.PrivateKey.Algorithm
+		}
+		if config.PrivateKey.Size != 0 {
+			privateKey["size"] = int64(config.PrivateKey.Size)
+		}
+	}
+
+	spec := map[string]interface{}{
+		"secretName": secretName,
+		"commonName": commonName,
+		"subject":    subject,
+		"usages":     []interface{}{"digital signature", "key encipherment", "server auth", "client auth"},
+		"privateKey": privateKey,
+		"issuerRef": map[string]interface{}{
+			"name":  issuerRef.Name,
+			"kind":  kind,
+			"group": group,
+		},
+	}
+	if len(dnsNames) > 0 {
+		spec["dnsNames"] = toInterfaceSlice(dnsNames)
+	}
+	if len(config.IPAddresses) > 0 {
+		spec["ipAddresses"] = toInterfaceSlice(config.IPAddresses)
+	}
+	if config.Duration != nil {
+		spec["duration"] = config.Duration.Duration.String()
+	}
+	if config.RenewBefore != nil {
+		spec["renewBefore"] = config.RenewBefore.Duration.String()
+	}
+
+	certificate := &unstructured.Unstructured{}
+	certificate.SetGroupVersionKind(CertificateGVK)
+	certificate.SetName(name)
+	certificate.SetNamespace(cr.Namespace)
+	certificate.SetLabels(map[string]string{
+		ClusterLabel:              cr.Name,
+		CertificateInterfaceLabel: interfaceName,
+	})
+	certificate.Object["spec"] = spec
+	return certificate
+}
+
+func toInterfaceSlice(values []string) []interface{} {
+	result := make([]interface{}, 0, len(values))
+	for _, value := range values {
+		result = append(result, value)
+	}
+	return result
+}
@@ -8,6 +8,7 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -25,6 +26,17 @@ const (
 	NodePoolLabel                    = "opster.io/opensearch-nodepool"
 	ConfigurationChecksumAnnotation  = "opster.io/config"
 	securityconfigChecksumAnnotation = "securityconfig/checksum"
+	// ZoneAnnotation is set on the pods by the operator with the zone of their Kubernetes node
+	ZoneAnnotation = "opster.io/zone"
+	// DefaultZoneTopologyKey is the node label with the zone if zone awareness does not set another label
+	DefaultZoneTopologyKey = "topology.kubernetes.io/zone"
+	// ConfigVolumeName is the volume with the opensearch.yml files of the bootstrap pod and the node pools
+	ConfigVolumeName = "config"
+	// BootstrapConfigKey is the key of the opensearch.yml of the bootstrap pod in the config map
+	BootstrapConfigKey = "opensearch.yml"
+
+	adminCertVolume = "admin-cert"
+	adminCertPath   = "/usr/share/opensearch/admin-cert"
 )
 
 func NewSTSForNodePool(
@@ -34,7 +46,6 @@ func NewSTSForNodePool(
 	configChecksum string,
 	volumes []corev1.Volume,
 	volumeMounts []corev1.VolumeMount,
-	extraConfig map[string]string,
 ) *appsv1.StatefulSet {
 	//To make sure disksize is not passed as empty
 	var disksize string
@@ -44,25 +55,7 @@ func NewSTSForNodePool(
 		disksize = node.DiskSize
 	}
 
-	availableRoles := []string{
-		"master",
-		"data",
-		//"data_content",
-		//"data_hot",
-		//"data_warm",:
-		//"data_cold",
-		//"data_frozen",
-		"ingest",
-		//"ml",
-		//"remote_cluster_client",
-		//"transform",
-	}
-	var selectedRoles []string
-	for _, role := range node.Roles {
-		if helpers.ContainsString(availableRoles, role) {
-			selectedRoles = append(selectedRoles, role)
-		}
-	}
+	selectedRoles := helpers.MapClusterRoles(node.Roles, cr.Spec.General.Version)
 
 	pvc := corev1.PersistentVolumeClaim{}
 	dataVolume := corev1.Volume{}
@@ -110,7 +103,7 @@ func NewSTSForNodePool(
 		volumes = append(volumes, dataVolume)
 	}
 
-	volumeMounts = append(volumeMounts, corev1.VolumeMount{
+	volumeMounts = append(withConfigVolumeMount(volumes, volumeMounts, NodePoolConfigKey(&node)), corev1.VolumeMount{
 		Name:      "data",
 		MountPath: "/usr/share/opensearch/data",
 	})
@@ -124,7 +117,7 @@ func NewSTSForNodePool(
 		ConfigurationChecksumAnnotation: configChecksum,
 	}
 
-	if helpers.ContainsString(selectedRoles, "master") {
+	if helpers.HasManagerRole(node.Roles) {
 		labels["opensearch.role"] = "master"
 	}
 	runas := int64(0)
@@ -166,7 +159,7 @@ func NewSTSForNodePool(
 				Command: []string{
 					"/bin/bash",
 					"-c",
-					"curl -k -u ${OPENSEARCH_USER}:${OPENSEARCH_PASSWORD} --silent --fail https://localhost:9200",
+					fmt.Sprintf("curl -k -u ${OPENSEARCH_USER}:${OPENSEARCH_PASSWORD} --silent --fail https://localhost:%d", PortForCluster(cr)),
 				},
 			},
 		},
@@ -176,7 +169,7 @@ func NewSTSForNodePool(
 
 	sts := &appsv1.StatefulSet{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      cr.Name + "-" + node.Component,
+			Name:      StsName(cr, &node),
 			Namespace: cr.Namespace,
 			Labels:    labels,
 		},
@@ -305,12 +298,17 @@ func NewSTSForNodePool(
 		},
 	}
 
-	// Append additional config to env vars
-	for k, v := range extraConfig {
-		sts.Spec.Template.Spec.Containers[0].Env = append(sts.Spec.Template.Spec.Containers[0].Env, corev1.EnvVar{
-			Name:  k,
-			Value: v,
-		})
+	if cr.Spec.ConfMgmt.Monitoring {
+		sts.Spec.Template.Spec.Containers = append(sts.Spec.Template.Spec.Containers, NewExporterContainer(cr, username))
+		sts.Spec.Template.Spec.Volumes = append(sts.Spec.Template.Spec.Volumes, NewExporterVolumes(cr)...)
+	}
+
+	if !helpers.AdminPasswordAvailable(cr) {
+		addAdminCertificateProbe(cr, sts)
+	}
+
+	if cr.Spec.ZoneAwareness != nil {
+		addZoneAwareness(cr, labels, sts)
 	}
 
 	if cr.Spec.General.SetVMMaxMapCount {
@@ -330,6 +328,94 @@ func NewSTSForNodePool(
 
 	return sts
 }
+
+// addAdminCertificateProbe authenticates the readiness probe with the admin client certificate, used if the admin
+// password is not known because of a custom securityconfig without admin credentials secret
+func addAdminCertificateProbe(cr *opsterv1.OpenSearchCluster, sts *appsv1.StatefulSet) {
+	podSpec := &sts.Spec.Template.Spec
+	podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+		Name:         adminCertVolume,
+		VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{SecretName: helpers.AdminCertificateSecretName(cr)}},
+	})
+	container := &podSpec.Containers[0]
+	container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+		Name:      adminCertVolume,
+		MountPath: adminCertPath,
+		ReadOnly:  true,
+	})
+	container.ReadinessProbe.Exec.Command = []string{
+		"/bin/bash",
+		"-c",
+		fmt.Sprintf("curl -k --cert %[1]s/%[2]s --key %[1]s/%[3]s --silent --fail https://localhost:%[4]d", adminCertPath, corev1.TLSCertKey, corev1.TLSPrivateKeyKey, PortForCluster(cr)),
+	}
+}
+
+// addZoneAwareness spreads the pods of the statefulset across zones and sets node.attr.zone to the zone of their
+// Kubernetes node. Node labels are not available through the downward API, so the operator copies the zone into an
+// annotation of the pod. An init container waits for it and the zone is passed to OpenSearch as environment variable.
+func addZoneAwareness(cr *opsterv1.OpenSearchCluster, labels map[string]string, sts *appsv1.StatefulSet) {
+	zoneAwareness := cr.Spec.ZoneAwareness
+	maxSkew := zoneAwareness.MaxSkew
+	if maxSkew == 0 {
+		maxSkew = 1
+	}
+	whenUnsatisfiable := zoneAwareness.WhenUnsatisfiable
+	if whenUnsatisfiable == "" {
+		whenUnsatisfiable = corev1.DoNotSchedule
+	}
+	podSpec := &sts.Spec.Template.Spec
+	podSpec.TopologySpreadConstraints = append(podSpec.TopologySpreadConstraints, corev1.TopologySpreadConstraint{
+		MaxSkew:           maxSkew,
+		TopologyKey:       ZoneTopologyKey(cr),
+		WhenUnsatisfiable: whenUnsatisfiable,
+		LabelSelector:     &metav1.LabelSelector{MatchLabels: labels},
+	})
+
+	podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+		Name: "zone",
+		VolumeSource: corev1.VolumeSource{
+			DownwardAPI: &corev1.DownwardAPIVolumeSource{
+				Items: []corev1.DownwardAPIVolumeFile{{
+					Path:     "zone",
+					FieldRef: &corev1.ObjectFieldSelector{APIVersion: "v1", FieldPath: fmt.Sprintf("metadata.annotations['%s']", ZoneAnnotation)},
+				}},
+			},
+		},
+	})
+	zoneMount := corev1.VolumeMount{
+		Name:      "zone",
+		MountPath: "/usr/share/opensearch/zone",
+		ReadOnly:  true,
+	}
+	podSpec.InitContainers = append(podSpec.InitContainers, corev1.Container{
+		Name:    "init-zone",
+		Image:   "public.ecr.aws/opsterio/busybox:1.27.2",
+		Command: []string{"sh", "-c"},
+		Args: []string{
+			"until [ -s /usr/share/opensearch/zone/zone ]; do echo 'waiting for the zone of the node'; sleep 5; done",
+		},
+		VolumeMounts: []corev1.VolumeMount{zoneMount},
+	})
+
+	// Environment variables are resolved when the container starts, after the init container saw the annotation.
+	// The entrypoint of the image passes variables with dots to OpenSearch as settings.
+	container := &podSpec.Containers[0]
+	container.Env = append(container.Env, corev1.EnvVar{
+		Name: "node.attr.zone",
+		ValueFrom: &corev1.EnvVarSource{
+			FieldRef: &corev1.ObjectFieldSelector{APIVersion: "v1", FieldPath: fmt.Sprintf("metadata.annotations['%s']", ZoneAnnotation)},
+		},
+	})
+}
+
+// ZoneTopologyKey returns the node label with the zone of a node
+func ZoneTopologyKey(cr *opsterv1.OpenSearchCluster) string {
+	if cr.Spec.ZoneAwareness == nil || cr.Spec.ZoneAwareness.TopologyKey == "" {
+		return DefaultZoneTopologyKey
+	}
+	return cr.Spec.ZoneAwareness.TopologyKey
+}
+
 func NewHeadlessServiceForNodePool(cr *opsterv1.OpenSearchCluster, nodePool *opsterv1.NodePool) *corev1.Service {
 	labels := map[string]string{
 		ClusterLabel:  cr.Name,
@@ -373,12 +459,58 @@ func NewHeadlessServiceForNodePool(cr *opsterv1.OpenSearchCluster, nodePool *ops
 	}
 }
 
+// NewPDBForNodePool builds the pod disruption budget of the node pool from its roles. Node pools with the cluster
+// manager role keep a quorum of their nodes, data node pools allow one disruption only if dataDisruptionAllowed is set,
+// which the operator does while the cluster is green. Other node pools allow one disruption at a time.
+// Node pools with fewer than three cluster managers or a single data node cannot be protected without blocking every
+// drain of their Kubernetes nodes, they allow one disruption.
+func NewPDBForNodePool(cr *opsterv1.OpenSearchCluster, nodePool *opsterv1.NodePool, dataDisruptionAllowed bool) *policyv1.PodDisruptionBudget {
+	labels := map[string]string{
+		ClusterLabel:  cr.Name,
+		NodePoolLabel: nodePool.Component,
+	}
+
+	maxUnavailable := int32(1)
+	if helpers.HasManagerRole(nodePool.Roles) && nodePool.Replicas >= 3 {
+		maxUnavailable = (nodePool.Replicas - 1) / 2
+	}
+	if helpers.HasRole(nodePool.Roles, "data") {
+		if maxUnavailable > 1 {
+			maxUnavailable = 1
+		}
+		if !dataDisruptionAllowed && nodePool.Replicas > 1 {
+			maxUnavailable = 0
+		}
+	}
+
+	return &policyv1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      PDBName(cr, nodePool),
+			Namespace: cr.Namespace,
+			Labels:    labels,
+		},
+		Spec: policyv1.PodDisruptionBudgetSpec{
+			MaxUnavailable: &intstr.IntOrString{
+				Type:   intstr.Int,
+				IntVal: maxUnavailable,
+			},
+			Selector: &metav1.LabelSelector{
+				MatchLabels: labels,
+			},
+		},
+	}
+}
+
+func PDBName(cr *opsterv1.OpenSearchCluster, nodePool *opsterv1.NodePool) string {
+	return fmt.Sprintf("%s-%s-pdb", cr.Name, nodePool.Component)
+}
+
 func NewServiceForCR(cr *opsterv1.OpenSearchCluster) *corev1.Service {
 	labels := map[string]string{
 		ClusterLabel: cr.Name,
 	}
 
-	return &corev1.Service{
+	service := &corev1.Service{
 		TypeMeta: metav1.TypeMeta{
 			Kind:       "Service",
 			APIVersion: "v1",
@@ -430,6 +562,11 @@ func NewServiceForCR(cr *opsterv1.OpenSearchCluster) *corev1.Service {
 			Type:     "",
 		},
 	}
+
+	if cr.Spec.ConfMgmt.Monitoring {
+		service.Spec.Ports = append(service.Spec.Ports, NewExporterServicePort())
+	}
+	return service
 }
 
 func NewDiscoveryServiceForCR(cr *opsterv1.OpenSearchCluster) *corev1.Service {
@@ -462,38 +599,6 @@ func NewDiscoveryServiceForCR(cr *opsterv1.OpenSearchCluster) *corev1.Service {
 	}
 }
 
-func NewNodePortService(cr *opsterv1.OpenSearchCluster) *corev1.Service {
-	labels := map[string]string{
-		ClusterLabel: cr.Name,
-	}
-
-	return &corev1.Service{
-		TypeMeta: metav1.TypeMeta{
-			Kind:       "Service",
-			APIVersion: "v1",
-		},
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      cr.Spec.General.ServiceName + "-exposed",
-			Namespace: cr.Namespace,
-			Labels:    labels,
-		},
-		Spec: corev1.ServiceSpec{
-			Ports: []corev1.ServicePort{
-				{
-					Name:     "http",
-					Protocol: "TCP",
-					Port:     cr.Spec.General.HttpPort,
-					TargetPort: intstr.IntOrString{
-						IntVal: cr.Spec.General.HttpPort,
-					},
-				},
-			},
-			Selector: labels,
-			Type:     "NodePort",
-		},
-	}
-}
-
 func NewBootstrapPod(
 	cr *opsterv1.OpenSearchCluster,
 	volumes []corev1.Volume,
@@ -521,7 +626,7 @@ func NewBootstrapPod(
 		},
 	})
 
-	volumeMounts = append(volumeMounts, corev1.VolumeMount{
+	volumeMounts = append(withConfigVolumeMount(volumes, volumeMounts, BootstrapConfigKey), corev1.VolumeMount{
 		Name:      "data",
 		MountPath: "/usr/share/opensearch/data",
 	})
@@ -656,9 +761,42 @@ func DnsOfService(cr *opsterv1.OpenSearchCluster) string {
 }
 
 func StsName(cr *opsterv1.OpenSearchCluster, nodePool *opsterv1.NodePool) string {
+	for _, migration := range cr.Status.StorageMigrations {
+		if migration.Component == nodePool.Component {
+			return migration.StatefulSet
+		}
+	}
 	return cr.Name + "-" + nodePool.Component
 }
 
+// NodePoolPodNames returns the names of the pods of the node pool. While the node pool is migrated to new storage the
+// pods of both statefulsets are returned.
+func NodePoolPodNames(cr *opsterv1.OpenSearchCluster, nodePool *opsterv1.NodePool) []string {
+	stsNames := []string{StsName(cr, nodePool)}
+	for _, migration := range cr.Status.StorageMigrations {
+		if migration.Component == nodePool.Component && migration.Source != "" && migration.Source != stsNames[0] {
+			stsNames = append(stsNames, migration.Source)
+		}
+	}
+	var podNames []string
+	for _, stsName := range stsNames {
+		for i := int32(0); i < nodePool.Replicas; i++ {
+			podNames = append(podNames, fmt.Sprintf("%s-%d", stsName, i))
+		}
+	}
+	return podNames
+}
+
+// MigrationStsName returns the name of the statefulset the node pool is migrated to when its storage changes.
+// Node pools alternate between the default name and the name with a -b suffix.
+func MigrationStsName(cr *opsterv1.OpenSearchCluster, nodePool *opsterv1.NodePool) string {
+	name := cr.Name + "-" + nodePool.Component
+	if StsName(cr, nodePool) == name {
+		return name + "-b"
+	}
+	return name
+}
+
 func ReplicaHostName(currentSts appsv1.StatefulSet, repNum int32) string {
 	return fmt.Sprintf("%s-%d", currentSts.ObjectMeta.Name, repNum)
 }
@@ -667,6 +805,30 @@ func DiscoveryServiceName(cr *opsterv1.OpenSearchCluster) string {
 	return fmt.Sprintf("%s-discovery", cr.Name)
 }
 
+// TransportSeeds returns the transport addresses other clusters use to discover the nodes of the cluster
+func TransportSeeds(cr *opsterv1.OpenSearchCluster) []string {
+	return []string{fmt.Sprintf("%s.%s.svc.cluster.local:9300", DiscoveryServiceName(cr), cr.Namespace)}
+}
+
+// NodePoolConfigKey returns the key of the opensearch.yml of the node pool in the config map
+func NodePoolConfigKey(nodePool *opsterv1.NodePool) string {
+	return fmt.Sprintf("opensearch-%s.yml", nodePool.Component)
+}
+
+// withConfigVolumeMount mounts the opensearch.yml with the given key if the config volume exists
+func withConfigVolumeMount(volumes []corev1.Volume, volumeMounts []corev1.VolumeMount, key string) []corev1.VolumeMount {
+	for _, volume := range volumes {
+		if volume.Name == ConfigVolumeName {
+			return append(append([]corev1.VolumeMount{}, volumeMounts...), corev1.VolumeMount{
+				Name:      ConfigVolumeName,
+				MountPath: "/usr/share/opensearch/config/opensearch.yml",
+				SubPath:   key,
+			})
+		}
+	}
+	return volumeMounts
+}
+
 func BootstrapPodName(cr *opsterv1.OpenSearchCluster) string {
 	return fmt.Sprintf("%s-bootstrap-0", cr.Name)
 }
@@ -676,9 +838,9 @@ func WorkingPodForRollingRestart(sts *appsv1.StatefulSet) string {
 	return ReplicaHostName(*sts, ordinal)
 }
 
-func STSInNodePools(sts appsv1.StatefulSet, nodepools []opsterv1.NodePool) bool {
+func STSInNodePools(cr *opsterv1.OpenSearchCluster, sts appsv1.StatefulSet, nodepools []opsterv1.NodePool) bool {
 	for _, nodepool := range nodepools {
-		if sts.Labels[NodePoolLabel] == nodepool.Component {
+		if sts.Labels[NodePoolLabel] == nodepool.Component && sts.Name == StsName(cr, &nodepool) {
 			return true
 		}
 	}
@@ -770,7 +932,7 @@ func NewSecurityconfigUpdateJob(
 
 func AllMastersReady(ctx context.Context, k8sClient client.Client, cr *opsterv1.OpenSearchCluster) bool {
 	for _, nodePool := range cr.Spec.NodePools {
-		if helpers.ContainsString(nodePool.Roles, "master") {
+		if helpers.HasManagerRole(nodePool.Roles) {
 			sts := &appsv1.StatefulSet{}
 			if err := k8sClient.Get(ctx, types.NamespacedName{
 				Name:      StsName(cr, &nodePool),
@@ -789,7 +951,7 @@ func AllMastersReady(ctx context.Context, k8sClient client.Client, cr *opsterv1.
 func DataNodesCount(ctx context.Context, k8sClient client.Client, cr *opsterv1.OpenSearchCluster) int32 {
 	count := int32(0)
 	for _, nodePool := range cr.Spec.NodePools {
-		if helpers.ContainsString(nodePool.Roles, "data") {
+		if helpers.HasRole(nodePool.Roles, "data") {
 			sts := &appsv1.StatefulSet{}
 			if err := k8sClient.Get(ctx, types.NamespacedName{
 				Name:      StsName(cr, &nodePool),
@@ -0,0 +1,151 @@
+package builders
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	opsterv1 "opensearch.opster.io/api/v1"
+	"opensearch.opster.io/pkg/helpers"
+)
+
+const (
+	DefaultExporterImage   = "quay.io/prometheuscommunity/elasticsearch-exporter:v1.5.0"
+	ExporterPort           = 9114
+	ExporterPortName       = "http-metrics"
+	defaultScrapeInterval  = "30s"
+	exporterContainerName  = "metrics-exporter"
+	serviceMonitorAPIGroup = "monitoring.coreos.com"
+	exporterCertVolume     = "exporter-admin-cert"
+	exporterCertPath       = "/usr/share/exporter/certs"
+)
+
+var ServiceMonitorGVK = schema.GroupVersionKind{
+	Group:   serviceMonitorAPIGroup,
+	Version: "v1",
+	Kind:    "ServiceMonitor",
+}
+
+// NewExporterContainer builds the prometheus exporter sidecar that is added to every opensearch pod if monitoring is enabled
+func NewExporterContainer(cr *opsterv1.OpenSearchCluster, username string) corev1.Container {
+	image := DefaultExporterImage
+	resources := corev1.ResourceRequirements{}
+	if config := cr.Spec.ConfMgmt.MonitoringConfig; config != nil {
+		if config.ExporterImage != "" {
+			image = config.ExporterImage
+		}
+		resources = config.Resources
+	}
+
+	container := corev1.Container{
+		Name:  exporterContainerName,
+		Image: image,
+		Args: []string{
+			fmt.Sprintf("--es.uri=https://localhost:%d", PortForCluster(cr)),
+			// The exporter connects via localhost which is not part of the node certificate
+			"--es.ssl-skip-verify",
+			fmt.Sprintf("--web.listen-address=:%d", ExporterPort),
+		},
+		Ports: []corev1.ContainerPort{
+			{
+				Name:          ExporterPortName,
+				ContainerPort: ExporterPort,
+			},
+		},
+		Resources: resources,
+	}
+	if !helpers.UsesAdminCertificate(cr) {
+		container.Env = []corev1.EnvVar{
+			{
+				Name:  "ES_USERNAME",
+				Value: username,
+			},
+			{
+				Name: "ES_PASSWORD",
+				ValueFrom: &corev1.EnvVarSource{
+					SecretKeyRef: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{
+							Name: fmt.Sprintf("%s-admin-password", cr.Name),
+						},
+						Key: "password",
+					},
+				},
+			},
+		}
+	} else {
+		// Without an admin credentials secret the exporter authenticates with the admin client certificate
+		container.Args = append(container.Args,
+			fmt.Sprintf("--es.client-cert=%s/%s", exporterCertPath, corev1.TLSCertKey),
+			fmt.Sprintf("--es.client-private-key=%s/%s", exporterCertPath, corev1.TLSPrivateKeyKey),
+		)
+		container.VolumeMounts = []corev1.VolumeMount{{Name: exporterCertVolume, MountPath: exporterCertPath}}
+	}
+	return container
+}
+
+// NewExporterVolumes builds the volumes used by the exporter sidecar
+func NewExporterVolumes(cr *opsterv1.OpenSearchCluster) []corev1.Volume {
+	if !helpers.UsesAdminCertificate(cr) {
+		return nil
+	}
+	return []corev1.Volume{{
+		Name:         exporterCertVolume,
+		VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{SecretName: helpers.AdminCertificateSecretName(cr)}},
+	}}
+}
+
+// NewExporterServicePort builds the port of the cluster service that points to the exporter sidecars
+func NewExporterServicePort() corev1.ServicePort {
+	return corev1.ServicePort{
+		Name:     ExporterPortName,
+		Protocol: "TCP",
+		Port:     ExporterPort,
+		TargetPort: intstr.IntOrString{
+			IntVal: ExporterPort,
+			StrVal: fmt.Sprint(ExporterPort),
+		},
+	}
+}
+
+// NewServiceMonitor builds a prometheus-operator ServiceMonitor for the exporter sidecars.
+// An unstructured object is used as the ServiceMonitor CRD is optional in the cluster.
+func NewServiceMonitor(cr *opsterv1.OpenSearchCluster) *unstructured.Unstructured {
+	interval := defaultScrapeInterval
+	labels := map[string]string{
+		ClusterLabel: cr.Name,
+	}
+	if config := cr.Spec.ConfMgmt.MonitoringConfig; config != nil {
+		if config.ScrapeInterval != "" {
+			interval = config.ScrapeInterval
+		}
+		for k, v := range config.ServiceMonitorLabels {
+			labels[k] = v
+		}
+	}
+
+	serviceMonitor := &unstructured.Unstructured{}
+	serviceMonitor.SetGroupVersionKind(ServiceMonitorGVK)
+	serviceMonitor.SetName(cr.Name + "-monitor")
+	serviceMonitor.SetNamespace(cr.Namespace)
+	serviceMonitor.SetLabels(labels)
+	serviceMonitor.Object["spec"] = map[string]interface{}{
+		"endpoints": []interface{}{
+			map[string]interface{}{
+				"port":     ExporterPortName,
+				"interval": interval,
+				"path":     "/metrics",
+			},
+		},
+		"namespaceSelector": map[string]interface{}{
+			"matchNames": []interface{}{cr.Namespace},
+		},
+		"selector": map[string]interface{}{
+			"matchLabels": map[string]interface{}{
+				ClusterLabel: cr.Name,
+			},
+		},
+	}
+	return serviceMonitor
+}
@@ -3,8 +3,11 @@ package helpers
 import (
 	"context"
 	"errors"
+	"fmt"
 	"reflect"
+	"strings"
 
+	"github.com/Masterminds/semver"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	opsterv1 "opensearch.opster.io/api/v1"
@@ -74,23 +77,108 @@ func FindByPath(obj interface{}, keys []string) (interface{}, bool) {
 	return val, ok
 }
 
+// ErrAdminCredentialsRequired is returned for clusters with a custom securityconfig but neither an admin credentials
+// secret nor an admin client certificate, the demo credentials are not available in that case
+var ErrAdminCredentialsRequired = errors.New("security.config.adminCredentialsSecret is required if security.config.securityConfigSecret is set and no admin certificate is available")
+
+// UsernameAndPassword returns the admin credentials configured for the cluster.
+// Without an admin credentials secret the demo credentials of the security plugin are returned. A custom securityconfig
+// has no demo credentials, empty credentials are returned then and the admin client certificate has to be used.
 func UsernameAndPassword(ctx context.Context, k8sClient client.Client, cr *opsterv1.OpenSearchCluster) (string, string, error) {
-	if cr.Spec.Security != nil && cr.Spec.Security.Config != nil && cr.Spec.Security.Config.AdminCredentialsSecret.Name != "" {
-		// Read credentials from secret
-		credentialsSecret := corev1.Secret{}
-		if err := k8sClient.Get(ctx, client.ObjectKey{Name: cr.Spec.Security.Config.AdminCredentialsSecret.Name, Namespace: cr.Namespace}, &credentialsSecret); err != nil {
-			return "", "", err
+	if !AdminCredentialsConfigured(cr) {
+		if AdminPasswordAvailable(cr) {
+			// Use default demo credentials
+			return "admin", "admin", nil
 		}
-		username, usernameExists := credentialsSecret.Data["username"]
-		password, passwordExists := credentialsSecret.Data["password"]
-		if !usernameExists || !passwordExists {
-			return "", "", errors.New("username or password field missing")
+		if AdminCertificateSecretName(cr) == "" {
+			return "", "", ErrAdminCredentialsRequired
 		}
-		return string(username), string(password), nil
-	} else {
-		// Use default demo credentials
-		return "admin", "admin", nil
+		return "", "", nil
+	}
+	// Read credentials from secret
+	credentialsSecret := corev1.Secret{}
+	if err := k8sClient.Get(ctx, client.ObjectKey{Name: cr.Spec.Security.Config.AdminCredentialsSecret.Name, Namespace: cr.Namespace}, &credentialsSecret); err != nil {
+		return "", "", err
+	}
+	username, usernameExists := credentialsSecret.Data["username"]
+	password, passwordExists := credentialsSecret.Data["password"]
+	if !usernameExists || !passwordExists {
+		return "", "", errors.New("username or password field missing")
+	}
+	return string(username), string(password), nil
+}
+
+func AdminCredentialsConfigured(cr *opsterv1.OpenSearchCluster) bool {
+	return cr.Spec.Security != nil && cr.Spec.Security.Config != nil && cr.Spec.Security.Config.AdminCredentialsSecret.Name != ""
+}
+
+// AdminPasswordAvailable checks if the admin can authenticate with a password, either from the admin credentials
+// secret or the demo credentials. A custom securityconfig without admin credentials secret has no known password.
+func AdminPasswordAvailable(cr *opsterv1.OpenSearchCluster) bool {
+	if AdminCredentialsConfigured(cr) {
+		return true
+	}
+	return cr.Spec.Security == nil || cr.Spec.Security.Config == nil || cr.Spec.Security.Config.SecurityconfigSecret.Name == ""
+}
+
+// UsesAdminCertificate checks if the operator and the exporter authenticate with the admin client certificate instead
+// of the demo credentials, which is the case if no admin credentials are configured but an admin certificate exists
+func UsesAdminCertificate(cr *opsterv1.OpenSearchCluster) bool {
+	return !AdminCredentialsConfigured(cr) && AdminCertificateSecretName(cr) != ""
+}
+
+// AdminCertificateSecretName returns the name of the secret with the admin client certificate, empty if the cluster has none
+func AdminCertificateSecretName(cr *opsterv1.OpenSearchCluster) string {
+	if cr.Spec.Security == nil {
+		return ""
+	}
+	if cr.Spec.Security.Config != nil && cr.Spec.Security.Config.AdminSecret.Name != "" {
+		return cr.Spec.Security.Config.AdminSecret.Name
+	}
+	if cr.Spec.Security.Tls != nil && cr.Spec.Security.Tls.Transport != nil &&
+		(cr.Spec.Security.Tls.Transport.Generate || cr.Spec.Security.Tls.Transport.CertificateConfig.CertManager != nil) {
+		return fmt.Sprintf("%s-admin-cert", cr.Name)
+	}
+	return ""
+}
+
+// HttpCaSecretName returns the name of the secret whose ca.crt is trusted by the http interface of the nodes.
+// It is empty if the http interface uses the demo certificates.
+func HttpCaSecretName(cr *opsterv1.OpenSearchCluster) string {
+	if cr.Spec.Security == nil || cr.Spec.Security.Tls == nil || cr.Spec.Security.Tls.Http == nil {
+		return ""
+	}
+	config := cr.Spec.Security.Tls.Http.CertificateConfig
+	// Generated certificates are stored together with the CAs they are trusted by
+	if cr.Spec.Security.Tls.Http.Generate && config.CertManager == nil {
+		return fmt.Sprintf("%s-http-cert", cr.Name)
+	}
+	if config.CaSecret.Name != "" {
+		return config.CaSecret.Name
+	}
+	if config.CertManager != nil {
+		return fmt.Sprintf("%s-http-cert", cr.Name)
+	}
+	return config.Secret.Name
+}
+
+// TransportCaSecretName returns the name of the secret whose ca.crt is trusted by the transport interface of the nodes.
+// It is empty if the transport interface uses the demo certificates.
+func TransportCaSecretName(cr *opsterv1.OpenSearchCluster) string {
+	if cr.Spec.Security == nil || cr.Spec.Security.Tls == nil || cr.Spec.Security.Tls.Transport == nil {
+		return ""
 	}
+	config := cr.Spec.Security.Tls.Transport.CertificateConfig
+	if cr.Spec.Security.Tls.Transport.Generate && config.CertManager == nil {
+		return fmt.Sprintf("%s-transport-cert", cr.Name)
+	}
+	if config.CaSecret.Name != "" {
+		return config.CaSecret.Name
+	}
+	if config.CertManager != nil {
+		return fmt.Sprintf("%s-transport-cert", cr.Name)
+	}
+	return config.Secret.Name
 }
 
 func GetByDescriptionAndGroup(left opsterv1.ComponentStatus, right opsterv1.ComponentStatus) (opsterv1.ComponentStatus, bool) {
@@ -109,3 +197,120 @@ func MergeConfigs(left map[string]string, right map[string]string) map[string]st
 	}
 	return left
 }
+
+// dynamicSettingPrefixes lists the settings that can be updated using the cluster settings API.
+// A prefix ending in a dot matches all settings below it.
+var dynamicSettingPrefixes = []string{
+	"action.auto_create_index",
+	"action.destructive_requires_name",
+	"cluster.blocks.",
+	"cluster.indices.close.enable",
+	"cluster.info.update.interval",
+	"cluster.max_shards_per_node",
+	"cluster.persistent_tasks.allocation.",
+	"cluster.remote.",
+	"cluster.routing.allocation.",
+	"cluster.routing.rebalance.enable",
+	"indices.breaker.",
+	"indices.recovery.",
+	"logger.",
+	"script.max_compilations_rate",
+	"search.allow_expensive_queries",
+	"search.default_search_timeout",
+	"search.low_level_cancellation",
+	"search.max_buckets",
+}
+
+// staticSettings are settings matching a dynamic prefix that can only be set in opensearch.yml
+var staticSettings = []string{
+	"indices.breaker.total.use_real_memory",
+}
+
+// IsDynamicSetting returns true if the setting can be changed at runtime without restarting the nodes
+func IsDynamicSetting(key string) bool {
+	if ContainsString(staticSettings, key) {
+		return false
+	}
+	for _, prefix := range dynamicSettingPrefixes {
+		if strings.HasSuffix(prefix, ".") && strings.HasPrefix(key, prefix) || key == prefix {
+			return true
+		}
+	}
+	return false
+}
+
+// SplitDynamicSettings splits the config into dynamic settings and static settings that require a restart
+func SplitDynamicSettings(config map[string]string) (map[string]string, map[string]string) {
+	dynamic := map[string]string{}
+	static := map[string]string{}
+	for key, value := range config {
+		if IsDynamicSetting(key) {
+			dynamic[key] = value
+		} else {
+			static[key] = value
+		}
+	}
+	return dynamic, static
+}
+
+// NodeRoles are the node roles supported by OpenSearch, data tiers are configured using node attributes
+var NodeRoles = []string{
+	"cluster_manager",
+	"master",
+	"data",
+	"ingest",
+	"ml",
+	"remote_cluster_client",
+	"search",
+}
+
+// UnknownRoles returns the roles that are not supported by OpenSearch. The roles are validated by the CRD, this only
+// catches node pools that were stored before.
+func UnknownRoles(roles []opsterv1.NodeRole) []string {
+	var unknown []string
+	for _, role := range roles {
+		if !ContainsString(NodeRoles, string(role)) {
+			unknown = append(unknown, string(role))
+		}
+	}
+	return unknown
+}
+
+// HasRole checks if the roles of a node pool contain the role
+func HasRole(roles []opsterv1.NodeRole, role string) bool {
+	for _, r := range roles {
+		if string(r) == role {
+			return true
+		}
+	}
+	return false
+}
+
+// HasManagerRole returns true if the roles contain the cluster manager role or its deprecated name master
+func HasManagerRole(roles []opsterv1.NodeRole) bool {
+	return HasRole(roles, "cluster_manager") || HasRole(roles, "master")
+}
+
+// MapClusterRoles returns the supported roles for the OpenSearch version. The cluster manager role is called master
+// before OpenSearch 2.0 and cluster_manager since, exactly one of them is returned as OpenSearch refuses to start with
+// both.
+func MapClusterRoles(roles []opsterv1.NodeRole, version string) []string {
+	managerRole := "cluster_manager"
+	if parsed, err := semver.NewVersion(version); err == nil && parsed.Major() < 2 {
+		managerRole = "master"
+	}
+	var mapped []string
+	for _, r := range roles {
+		role := string(r)
+		if !ContainsString(NodeRoles, role) {
+			continue
+		}
+		if role == "cluster_manager" || role == "master" {
+			role = managerRole
+		}
+		if !ContainsString(mapped, role) {
+			mapped = append(mapped, role)
+		}
+	}
+	return mapped
+}
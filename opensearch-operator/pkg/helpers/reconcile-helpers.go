@@ -3,6 +3,7 @@ package helpers
 import (
 	"context"
 	"fmt"
+	"net"
 	"path"
 
 	appsv1 "k8s.io/api/apps/v1"
@@ -57,7 +58,11 @@ func ReadOrGenerateCaCert(pki tls.PKI, k8sClient client.Client, ctx context.Cont
 	var ca tls.Cert
 	if err := k8sClient.Get(ctx, client.ObjectKey{Name: secretName, Namespace: namespace}, &caSecret); err != nil {
 		// Generate CA cert and put it into secret
-		ca, err = pki.GenerateCA(clusterName)
+		options, err := CaCertificateOptions(instance)
+		if err != nil {
+			return ca, err
+		}
+		ca, err = pki.GenerateCA(options)
 		if err != nil {
 			logger.Error(err, "Failed to create CA")
 			return ca, err
@@ -76,6 +81,57 @@ func ReadOrGenerateCaCert(pki tls.PKI, k8sClient client.Client, ctx context.Cont
 	return ca, nil
 }
 
+// CertificateOptions converts the configuration of generated certificates into the options of the tls package.
+// Validity and names depend on the certificate and must be set by the caller.
+func CertificateOptions(config opsterv1.TlsCertificateConfig) (tls.CertificateOptions, error) {
+	options := tls.CertificateOptions{}
+	if config.PrivateKey != nil {
+		options.Key = tls.KeyOptions{Algorithm: config.PrivateKey.Algorithm, Size: config.PrivateKey.Size}
+	}
+	options.Subject = CertificateSubject(config)
+	for _, address := range config.IPAddresses {
+		ip := net.ParseIP(address)
+		if ip == nil {
+			return options, fmt.Errorf("invalid ip address %s", address)
+		}
+		options.IPAddresses = append(options.IPAddresses, ip)
+	}
+	return options, nil
+}
+
+// CertificateSubject returns the additional subject fields of generated certificates
+func CertificateSubject(config opsterv1.TlsCertificateConfig) tls.Subject {
+	if config.Subject == nil {
+		return tls.Subject{}
+	}
+	return tls.Subject{
+		Organizations: config.Subject.Organizations,
+		Countries:     config.Subject.Countries,
+		Localities:    config.Subject.Localities,
+	}
+}
+
+// CaCertificateOptions returns the options for the CA generated by the operator.
+// The CA is shared by all interfaces, it uses the key and subject configured for the transport interface or otherwise for http.
+func CaCertificateOptions(instance *opsterv1.OpenSearchCluster) (tls.CertificateOptions, error) {
+	config := opsterv1.TlsCertificateConfig{}
+	if instance.Spec.Security != nil && instance.Spec.Security.Tls != nil {
+		if instance.Spec.Security.Tls.Transport != nil {
+			config = instance.Spec.Security.Tls.Transport.CertificateConfig
+		} else if instance.Spec.Security.Tls.Http != nil {
+			config = instance.Spec.Security.Tls.Http.CertificateConfig
+		}
+	}
+	options, err := CertificateOptions(config)
+	if err != nil {
+		return options, err
+	}
+	// IP addresses only make sense for the certificates signed by the CA
+	options.IPAddresses = nil
+	options.CommonName = instance.Name
+	return options, nil
+}
+
 func ResolveImage(cr *opsterv1.OpenSearchCluster, nodePool *opsterv1.NodePool) (result opsterv1.ImageSpec) {
 	defaultRepo := "docker.io/opensearchproject"
 	defaultImage := "opensearch"
@@ -74,11 +74,11 @@ func (cert *CertMock) CertData() []byte {
 	return []byte("tls.crt")
 }
 
-func (ca *CertMock) CreateAndSignCertificate(commonName string, orgUnit string, dnsnames []string) (cert tls.Cert, err error) {
+func (ca *CertMock) CreateAndSignCertificate(options tls.CertificateOptions) (cert tls.Cert, err error) {
 	return &CertMock{}, nil
 }
 
-func (pki *PkiMock) GenerateCA(name string) (ca tls.Cert, err error) {
+func (pki *PkiMock) GenerateCA(options tls.CertificateOptions) (ca tls.Cert, err error) {
 	return &CertMock{}, nil
 }
 
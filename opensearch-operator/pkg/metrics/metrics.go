@@ -0,0 +1,191 @@
+package metrics
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/types"
+	opsterv1 "opensearch.opster.io/api/v1"
+	"opensearch.opster.io/opensearch-gateway/responses"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const namespace = "opensearch_operator"
+
+var healthStatuses = []string{"green", "yellow", "red"}
+
+var (
+	reconcileDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "component_reconcile_duration_seconds",
+			Help:      "Duration of a single component reconciler run",
+			Buckets:   []float64{0.01, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30},
+		},
+		[]string{"namespace", "cluster", "component"},
+	)
+	reconcileErrors = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "component_reconcile_errors_total",
+			Help:      "Number of component reconciler runs that returned an error",
+		},
+		[]string{"namespace", "cluster", "component"},
+	)
+
+	clusterHealthDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "cluster", "health_status"),
+		"Health of the opensearch cluster, 1 for the current status",
+		[]string{"namespace", "cluster", "status"}, nil,
+	)
+	clusterNodesDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "cluster", "nodes"),
+		"Number of nodes in the opensearch cluster",
+		[]string{"namespace", "cluster"}, nil,
+	)
+	clusterDataNodesDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "cluster", "data_nodes"),
+		"Number of data nodes in the opensearch cluster",
+		[]string{"namespace", "cluster"}, nil,
+	)
+	clusterUnassignedShardsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "cluster", "unassigned_shards"),
+		"Number of unassigned shards in the opensearch cluster",
+		[]string{"namespace", "cluster"}, nil,
+	)
+	nodeHeapUsedDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "node", "jvm_heap_used_bytes"),
+		"JVM heap used by an opensearch node",
+		[]string{"namespace", "cluster", "node"}, nil,
+	)
+	nodeHeapMaxDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "node", "jvm_heap_max_bytes"),
+		"Maximum JVM heap of an opensearch node",
+		[]string{"namespace", "cluster", "node"}, nil,
+	)
+
+	clusters = &clusterCollector{stats: map[types.NamespacedName]clusterStats{}}
+
+	// Components that have been observed per cluster, used to delete the label values of removed clusters
+	components   = map[types.NamespacedName]map[string]struct{}{}
+	componentsMu sync.Mutex
+)
+
+func init() {
+	metrics.Registry.MustRegister(reconcileDuration, reconcileErrors, clusters)
+}
+
+// InstrumentReconciler wraps a component reconciler to record its duration and errors
+func InstrumentReconciler(
+	instance *opsterv1.OpenSearchCluster,
+	component string,
+	rec func() (reconcile.Result, error),
+) func() (reconcile.Result, error) {
+	name := types.NamespacedName{Namespace: instance.Namespace, Name: instance.Name}
+	return func() (reconcile.Result, error) {
+		componentsMu.Lock()
+		if _, ok := components[name]; !ok {
+			components[name] = map[string]struct{}{}
+		}
+		components[name][component] = struct{}{}
+		componentsMu.Unlock()
+
+		start := time.Now()
+		result, err := rec()
+		reconcileDuration.WithLabelValues(instance.Namespace, instance.Name, component).Observe(time.Since(start).Seconds())
+		if err != nil {
+			reconcileErrors.WithLabelValues(instance.Namespace, instance.Name, component).Inc()
+		}
+		return result, err
+	}
+}
+
+// UpdateClusterStats stores the latest health and node stats of a cluster to be exposed on the next scrape
+func UpdateClusterStats(instance *opsterv1.OpenSearchCluster, health responses.ClusterHealthResponse, nodes responses.NodesStatsResponse) {
+	stats := clusterStats{
+		health:           health.Status,
+		nodes:            health.NumberOfNodes,
+		dataNodes:        health.NumberOfDataNodes,
+		unassignedShards: health.UnassignedShards,
+		heapUsed:         map[string]uint64{},
+		heapMax:          map[string]uint64{},
+	}
+	for _, node := range nodes.Nodes {
+		stats.heapUsed[node.Name] = node.Jvm.Mem.HeapUsedInBytes
+		stats.heapMax[node.Name] = node.Jvm.Mem.HeapMaxInBytes
+	}
+	clusters.mu.Lock()
+	defer clusters.mu.Unlock()
+	clusters.stats[types.NamespacedName{Namespace: instance.Namespace, Name: instance.Name}] = stats
+}
+
+// DeleteClusterStats removes the health and node stats of a cluster, e.g. after monitoring was disabled
+func DeleteClusterStats(instance *opsterv1.OpenSearchCluster) {
+	clusters.mu.Lock()
+	defer clusters.mu.Unlock()
+	delete(clusters.stats, types.NamespacedName{Namespace: instance.Namespace, Name: instance.Name})
+}
+
+// DeleteClusterMetrics removes all metrics of a cluster after it was deleted
+func DeleteClusterMetrics(instance *opsterv1.OpenSearchCluster) {
+	DeleteClusterStats(instance)
+
+	name := types.NamespacedName{Namespace: instance.Namespace, Name: instance.Name}
+	componentsMu.Lock()
+	defer componentsMu.Unlock()
+	for component := range components[name] {
+		reconcileDuration.DeleteLabelValues(instance.Namespace, instance.Name, component)
+		reconcileErrors.DeleteLabelValues(instance.Namespace, instance.Name, component)
+	}
+	delete(components, name)
+}
+
+type clusterStats struct {
+	health           string
+	nodes            int
+	dataNodes        int
+	unassignedShards int
+	heapUsed         map[string]uint64
+	heapMax          map[string]uint64
+}
+
+// clusterCollector exposes the last known stats of all monitored clusters.
+// A custom collector is used so that metrics of removed nodes and clusters disappear without tracking label values.
+type clusterCollector struct {
+	mu    sync.RWMutex
+	stats map[types.NamespacedName]clusterStats
+}
+
+func (c *clusterCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- clusterHealthDesc
+	ch <- clusterNodesDesc
+	ch <- clusterDataNodesDesc
+	ch <- clusterUnassignedShardsDesc
+	ch <- nodeHeapUsedDesc
+	ch <- nodeHeapMaxDesc
+}
+
+func (c *clusterCollector) Collect(ch chan<- prometheus.Metric) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	for name, stats := range c.stats {
+		for _, status := range healthStatuses {
+			value := 0.0
+			if status == stats.health {
+				value = 1
+			}
+			ch <- prometheus.MustNewConstMetric(clusterHealthDesc, prometheus.GaugeValue, value, name.Namespace, name.Name, status)
+		}
+		ch <- prometheus.MustNewConstMetric(clusterNodesDesc, prometheus.GaugeValue, float64(stats.nodes), name.Namespace, name.Name)
+		ch <- prometheus.MustNewConstMetric(clusterDataNodesDesc, prometheus.GaugeValue, float64(stats.dataNodes), name.Namespace, name.Name)
+		ch <- prometheus.MustNewConstMetric(clusterUnassignedShardsDesc, prometheus.GaugeValue, float64(stats.unassignedShards), name.Namespace, name.Name)
+		for node, used := range stats.heapUsed {
+			ch <- prometheus.MustNewConstMetric(nodeHeapUsedDesc, prometheus.GaugeValue, float64(used), name.Namespace, name.Name, node)
+		}
+		for node, max := range stats.heapMax {
+			ch <- prometheus.MustNewConstMetric(nodeHeapMaxDesc, prometheus.GaugeValue, float64(max), name.Namespace, name.Name, node)
+		}
+	}
+}
@@ -0,0 +1,291 @@
+package reconcilers
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/banzaicloud/operator-tools/pkg/reconciler"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/retry"
+	opsterv1 "opensearch.opster.io/api/v1"
+	"opensearch.opster.io/opensearch-gateway/responses"
+	"opensearch.opster.io/opensearch-gateway/services"
+	"opensearch.opster.io/pkg/builders"
+	"opensearch.opster.io/pkg/helpers"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const defaultAutoScalingCooldown = 10 * time.Minute
+
+type AutoScalerReconciler struct {
+	client.Client
+	reconciler.ResourceReconciler
+	ctx               context.Context
+	osClient          *services.OsClusterClient
+	recorder          record.EventRecorder
+	reconcilerContext *ReconcilerContext
+	instance          *opsterv1.OpenSearchCluster
+}
+
+func NewAutoScalerReconciler(
+	client client.Client,
+	ctx context.Context,
+	recorder record.EventRecorder,
+	reconcilerContext *ReconcilerContext,
+	instance *opsterv1.OpenSearchCluster,
+	opts ...reconciler.ResourceReconcilerOption,
+) *AutoScalerReconciler {
+	return &AutoScalerReconciler{
+		Client: client,
+		ResourceReconciler: reconciler.NewReconcilerWith(client,
+			append(opts, reconciler.WithLog(log.FromContext(ctx).WithValues("reconciler", "autoscaler")))...),
+		ctx:               ctx,
+		recorder:          recorder,
+		reconcilerContext: reconcilerContext,
+		instance:          instance,
+	}
+}
+
+// Reconcile adjusts the replicas of node pools with an autoscaling policy.
+// Only the replicas in the spec are changed, the actual scaling is done by the ScalerReconciler.
+func (r *AutoScalerReconciler) Reconcile() (ctrl.Result, error) {
+	if !r.instance.Spec.ConfMgmt.AutoScaler || !r.instance.Status.Initialized || !r.hasAutoScalingPolicies() {
+		return ctrl.Result{}, nil
+	}
+
+	lg := log.FromContext(r.ctx)
+	var err error
+	r.osClient, err = newOsClientForCluster(r.ctx, r.Client, r.instance)
+	if err != nil {
+		lg.Error(err, "failed to create os client")
+		return ctrl.Result{}, nil
+	}
+	stats, err := r.osClient.NodesStats(r.ctx)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	for _, nodePool := range r.instance.Spec.NodePools {
+		if nodePool.AutoScaling == nil {
+			continue
+		}
+		if !helpers.HasRole(nodePool.Roles, "data") {
+			lg.Info("autoscaling is only supported for data node pools, ignoring node pool", "nodePool", nodePool.Component)
+			continue
+		}
+		if err := r.reconcileNodePool(nodePool, stats); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+	return ctrl.Result{}, nil
+}
+
+func (r *AutoScalerReconciler) hasAutoScalingPolicies() bool {
+	for _, nodePool := range r.instance.Spec.NodePools {
+		if nodePool.AutoScaling != nil {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *AutoScalerReconciler) reconcileNodePool(nodePool opsterv1.NodePool, stats responses.NodesStatsResponse) error {
+	lg := log.FromContext(r.ctx)
+
+	// Never interfere with a scaling operation that is still in progress
+	_, scaling := helpers.FindFirstPartial(r.instance.Status.ComponentsStatus, opsterv1.ComponentStatus{
+		Component:   "Scaler",
+		Description: nodePool.Component,
+	}, helpers.GetByDescriptionAndGroup)
+	if scaling {
+		return nil
+	}
+	sts := appsv1.StatefulSet{}
+	if err := r.Get(r.ctx, client.ObjectKey{Name: builders.StsName(r.instance, &nodePool), Namespace: r.instance.Namespace}, &sts); err != nil {
+		return err
+	}
+	if sts.Spec.Replicas == nil || *sts.Spec.Replicas != nodePool.Replicas || sts.Status.ReadyReplicas != nodePool.Replicas {
+		return nil
+	}
+
+	status := r.autoScalerStatus(nodePool.Component)
+	if status.LastScaleTime != nil && time.Since(status.LastScaleTime.Time) < autoScalingCooldown(nodePool.AutoScaling) {
+		return nil
+	}
+
+	nodes := nodesOfNodePool(stats, builders.StsName(r.instance, &nodePool))
+	if len(nodes) == 0 {
+		return nil
+	}
+	usage := services.AverageNodeUsage(nodes)
+	replicas, reason := AutoScaleReplicas(*nodePool.AutoScaling, nodePool.Replicas, usage)
+	if replicas == nodePool.Replicas {
+		return nil
+	}
+
+	if replicas < nodePool.Replicas {
+		safe, err := r.canScaleDown(nodes)
+		if err != nil {
+			return err
+		}
+		if !safe {
+			lg.V(1).Info("not scaling down node pool, removing a node is not safe", "nodePool", nodePool.Component)
+			return nil
+		}
+	}
+
+	r.recorder.Eventf(r.instance, "Normal", "autoscaler", "scaling node pool %s from %d to %d replicas: %s", nodePool.Component, nodePool.Replicas, replicas, reason)
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if err := r.Get(r.ctx, client.ObjectKeyFromObject(r.instance), r.instance); err != nil {
+			return err
+		}
+		for i := range r.instance.Spec.NodePools {
+			if r.instance.Spec.NodePools[i].Component == nodePool.Component {
+				r.instance.Spec.NodePools[i].Replicas = replicas
+			}
+		}
+		if err := r.Update(r.ctx, r.instance); err != nil {
+			return err
+		}
+		r.setAutoScalerStatus(opsterv1.AutoScalerStatus{
+			Component:       nodePool.Component,
+			LastScaleTime:   &metav1.Time{Time: time.Now()},
+			LastScaleReason: reason,
+		})
+		return r.Status().Update(r.ctx, r.instance)
+	})
+}
+
+// canScaleDown checks that removing a node of the node pool can not lose data or leave replicas unassigned
+func (r *AutoScalerReconciler) canScaleDown(nodes []responses.NodeStatResponse) (bool, error) {
+	// Without the smart scaler nodes are removed without draining them first
+	if !r.instance.Spec.ConfMgmt.SmartScaler {
+		return false, nil
+	}
+	health, err := r.osClient.GetClusterHealth(r.ctx)
+	if err != nil {
+		return false, err
+	}
+	if health.Status != "green" {
+		return false, nil
+	}
+	noReplica, err := services.HasIndicesWithNoReplica(r.ctx, r.osClient)
+	if err != nil || noReplica {
+		return false, err
+	}
+	// Shards can be bound to the node pool by allocation filtering or awareness, so all copies a shard has in the node
+	// pool need to fit on the remaining nodes of the node pool
+	nodeNames := make([]string, 0, len(nodes))
+	for _, node := range nodes {
+		nodeNames = append(nodeNames, node.Name)
+	}
+	poolCopies, err := services.MaxShardCopiesOnNodes(r.ctx, r.osClient, nodeNames)
+	if err != nil || poolCopies > len(nodes)-1 {
+		return false, err
+	}
+	maxReplicas, err := services.MaxIndexReplicas(r.ctx, r.osClient)
+	if err != nil {
+		return false, err
+	}
+	// Every copy of a shard needs its own data node
+	return health.NumberOfDataNodes-1 > maxReplicas, nil
+}
+
+func (r *AutoScalerReconciler) autoScalerStatus(component string) opsterv1.AutoScalerStatus {
+	for _, status := range r.instance.Status.AutoScaler {
+		if status.Component == component {
+			return status
+		}
+	}
+	return opsterv1.AutoScalerStatus{Component: component}
+}
+
+func (r *AutoScalerReconciler) setAutoScalerStatus(status opsterv1.AutoScalerStatus) {
+	for i := range r.instance.Status.AutoScaler {
+		if r.instance.Status.AutoScaler[i].Component == status.Component {
+			r.instance.Status.AutoScaler[i] = status
+			return
+		}
+	}
+	r.instance.Status.AutoScaler = append(r.instance.Status.AutoScaler, status)
+}
+
+func autoScalingCooldown(policy *opsterv1.AutoScalingPolicy) time.Duration {
+	if policy.Cooldown != nil {
+		return policy.Cooldown.Duration
+	}
+	return defaultAutoScalingCooldown
+}
+
+// nodesOfNodePool returns the stats of all nodes that belong to the statefulset
+func nodesOfNodePool(stats responses.NodesStatsResponse, stsName string) []responses.NodeStatResponse {
+	var nodes []responses.NodeStatResponse
+	for _, node := range stats.Nodes {
+		ordinal := strings.TrimPrefix(node.Name, stsName+"-")
+		if ordinal == node.Name {
+			continue
+		}
+		if _, err := strconv.Atoi(ordinal); err == nil {
+			nodes = append(nodes, node)
+		}
+	}
+	return nodes
+}
+
+// AutoScaleReplicas calculates the desired replicas of a node pool based on the usage of its nodes.
+// The node pool is scaled by at most one node, also when it is outside of the replica bounds, so every removed node
+// passes the scale down checks. The second return value describes the reason for scaling.
+func AutoScaleReplicas(policy opsterv1.AutoScalingPolicy, replicas int32, usage services.NodeUsage) (int32, string) {
+	if replicas < policy.MinReplicas {
+		return replicas + 1, fmt.Sprintf("below minReplicas %d", policy.MinReplicas)
+	}
+	if replicas > policy.MaxReplicas {
+		return replicas - 1, fmt.Sprintf("above maxReplicas %d", policy.MaxReplicas)
+	}
+
+	if replicas < policy.MaxReplicas {
+		if exceeded, reason := anyThresholdExceeded(policy.ScaleUp, usage); exceeded {
+			return replicas + 1, reason
+		}
+	}
+	if replicas > policy.MinReplicas && allBelowThresholds(policy.ScaleDown, usage) {
+		return replicas - 1, "usage below scale down thresholds"
+	}
+	return replicas, ""
+}
+
+func anyThresholdExceeded(thresholds opsterv1.AutoScalingThresholds, usage services.NodeUsage) (bool, string) {
+	if thresholds.DiskPercent != nil && usage.DiskPercent > float64(*thresholds.DiskPercent) {
+		return true, fmt.Sprintf("disk usage %.0f%% above %d%%", usage.DiskPercent, *thresholds.DiskPercent)
+	}
+	if thresholds.CpuPercent != nil && usage.CpuPercent > float64(*thresholds.CpuPercent) {
+		return true, fmt.Sprintf("cpu usage %.0f%% above %d%%", usage.CpuPercent, *thresholds.CpuPercent)
+	}
+	if thresholds.JvmHeapPercent != nil && usage.JvmHeapPercent > float64(*thresholds.JvmHeapPercent) {
+		return true, fmt.Sprintf("jvm heap usage %.0f%% above %d%%", usage.JvmHeapPercent, *thresholds.JvmHeapPercent)
+	}
+	return false, ""
+}
+
+func allBelowThresholds(thresholds opsterv1.AutoScalingThresholds, usage services.NodeUsage) bool {
+	if thresholds.DiskPercent == nil && thresholds.CpuPercent == nil && thresholds.JvmHeapPercent == nil {
+		return false
+	}
+	if thresholds.DiskPercent != nil && usage.DiskPercent >= float64(*thresholds.DiskPercent) {
+		return false
+	}
+	if thresholds.CpuPercent != nil && usage.CpuPercent >= float64(*thresholds.CpuPercent) {
+		return false
+	}
+	if thresholds.JvmHeapPercent != nil && usage.JvmHeapPercent >= float64(*thresholds.JvmHeapPercent) {
+		return false
+	}
+	return true
+}
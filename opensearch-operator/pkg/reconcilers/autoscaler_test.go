@@ -0,0 +1,76 @@
+package reconcilers
+
+import (
+	"k8s.io/utils/pointer"
+	opsterv1 "opensearch.opster.io/api/v1"
+	"opensearch.opster.io/opensearch-gateway/responses"
+	"opensearch.opster.io/opensearch-gateway/services"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("AutoScaler Reconciler", func() {
+
+	policy := opsterv1.AutoScalingPolicy{
+		MinReplicas: 2,
+		MaxReplicas: 5,
+		ScaleUp: opsterv1.AutoScalingThresholds{
+			DiskPercent:    pointer.Int32(80),
+			JvmHeapPercent: pointer.Int32(85),
+		},
+		ScaleDown: opsterv1.AutoScalingThresholds{
+			DiskPercent:    pointer.Int32(40),
+			JvmHeapPercent: pointer.Int32(50),
+		},
+	}
+
+	Context("When calculating the desired replicas", func() {
+		It("should add a node if any scale up threshold is exceeded", func() {
+			replicas, reason := AutoScaleReplicas(policy, 3, services.NodeUsage{DiskPercent: 90, JvmHeapPercent: 20})
+			Expect(replicas).To(Equal(int32(4)))
+			Expect(reason).To(ContainSubstring("disk"))
+		})
+
+		It("should not scale above the max replicas", func() {
+			replicas, _ := AutoScaleReplicas(policy, 5, services.NodeUsage{DiskPercent: 90})
+			Expect(replicas).To(Equal(int32(5)))
+		})
+
+		It("should remove a node only if all scale down thresholds are undercut", func() {
+			replicas, _ := AutoScaleReplicas(policy, 3, services.NodeUsage{DiskPercent: 30, JvmHeapPercent: 60})
+			Expect(replicas).To(Equal(int32(3)))
+			replicas, _ = AutoScaleReplicas(policy, 3, services.NodeUsage{DiskPercent: 30, JvmHeapPercent: 30})
+			Expect(replicas).To(Equal(int32(2)))
+		})
+
+		It("should not scale below the min replicas", func() {
+			replicas, _ := AutoScaleReplicas(policy, 2, services.NodeUsage{})
+			Expect(replicas).To(Equal(int32(2)))
+			replicas, _ = AutoScaleReplicas(policy, 1, services.NodeUsage{DiskPercent: 50})
+			Expect(replicas).To(Equal(int32(2)))
+		})
+
+		It("should move one node at a time towards the replica bounds", func() {
+			replicas, reason := AutoScaleReplicas(policy, 8, services.NodeUsage{DiskPercent: 90})
+			Expect(replicas).To(Equal(int32(7)))
+			Expect(reason).To(ContainSubstring("maxReplicas"))
+			replicas, _ = AutoScaleReplicas(policy, 6, services.NodeUsage{})
+			Expect(replicas).To(Equal(int32(5)))
+		})
+	})
+
+	Context("When checking if a node can be removed", func() {
+		It("should count the copies of each shard on the nodes of the node pool", func() {
+			shards := []responses.CatShardsResponse{
+				{Index: "logs", Shard: "0", NodeName: "cluster-warm-0"},
+				{Index: "logs", Shard: "0", NodeName: "cluster-warm-1"},
+				{Index: "logs", Shard: "1", NodeName: "cluster-warm-1"},
+				{Index: "logs", Shard: "1", NodeName: "cluster-hot-0"},
+			}
+			Expect(services.ShardCopiesOnNodes(shards, []string{"cluster-warm-0", "cluster-warm-1"})).To(Equal(2))
+			Expect(services.ShardCopiesOnNodes(shards, []string{"cluster-hot-0"})).To(Equal(1))
+			Expect(services.ShardCopiesOnNodes(shards, []string{"cluster-other-0"})).To(Equal(0))
+		})
+	})
+})
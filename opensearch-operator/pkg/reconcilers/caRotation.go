@@ -0,0 +1,283 @@
+package reconcilers
+
+import (
+	"bytes"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/utils/pointer"
+	opsterv1 "opensearch.opster.io/api/v1"
+	"opensearch.opster.io/pkg/builders"
+	"opensearch.opster.io/pkg/helpers"
+	"opensearch.opster.io/pkg/tls"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// The CA generated by the operator is rotated when it expires within this time
+	caRenewBefore = 90 * 24 * time.Hour
+)
+
+// reconcileCaRotation advances a running rotation of the CA generated by the operator and starts a new one
+// if it was requested or the CA is about to expire. A rotation runs through the following phases, each of
+// them ends once all nodes were restarted:
+//  1. Trusting: a new CA is generated, nodes are restarted to trust both the old and the new CA
+//  2. Reissuing: the new CA replaces the old one, all certificates are re-issued and nodes restarted to use them
+//  3. Finalizing: the old CA is dropped from the trust bundle and nodes are restarted a last time
+func (r *TLSReconciler) reconcileCaRotation() error {
+	if !r.usesGeneratedCa() || !r.instance.Status.Initialized {
+		return nil
+	}
+	ca, err := helpers.ReadOrGenerateCaCert(r.pki, r.Client, r.ctx, r.instance)
+	if err != nil {
+		return err
+	}
+
+	rotation := opsterv1.CaRotationStatus{}
+	if r.instance.Status.CaRotation != nil {
+		rotation = *r.instance.Status.CaRotation
+	}
+	switch rotation.Phase {
+	case "", opsterv1.CaRotationPhaseCompleted:
+		if r.caRotationRequested(ca, rotation) {
+			if err := r.startCaRotation(); err != nil {
+				return err
+			}
+			if err := r.updateCaRotationStatus(opsterv1.CaRotationPhaseTrusting, r.instance.Spec.Security.Tls.RotateCa); err != nil {
+				return err
+			}
+		}
+	case opsterv1.CaRotationPhaseTrusting:
+		restarted, err := r.nodesRestartedSince(rotation.PhaseStartTime)
+		if err != nil || !restarted {
+			return err
+		}
+		if err := r.swapCa(); err != nil {
+			return err
+		}
+		if err := r.updateCaRotationStatus(opsterv1.CaRotationPhaseReissuing, rotation.Token); err != nil {
+			return err
+		}
+	case opsterv1.CaRotationPhaseReissuing:
+		restarted, err := r.nodesRestartedSince(rotation.PhaseStartTime)
+		if err != nil || !restarted {
+			return err
+		}
+		if err := r.deleteCaSecret(r.previousCaSecretName()); err != nil {
+			return err
+		}
+		if err := r.updateCaRotationStatus(opsterv1.CaRotationPhaseFinalizing, rotation.Token); err != nil {
+			return err
+		}
+	case opsterv1.CaRotationPhaseFinalizing:
+		restarted, err := r.nodesRestartedSince(rotation.PhaseStartTime)
+		if err != nil || !restarted {
+			return err
+		}
+		if err := r.updateCaRotationStatus(opsterv1.CaRotationPhaseCompleted, rotation.Token); err != nil {
+			return err
+		}
+	}
+
+	if err := r.buildTrustBundle(); err != nil {
+		return err
+	}
+	// Every phase change restarts the nodes, the start time of the last phase is kept once the rotation is completed
+	if status := r.instance.Status.CaRotation; status != nil && status.PhaseStartTime != nil {
+		r.reconcilerContext.AddCertificateRevision("ca", status.PhaseStartTime.UTC().Format(time.RFC3339))
+	}
+	return nil
+}
+
+// usesGeneratedCa checks if any certificates are signed by the CA generated by the operator
+func (r *TLSReconciler) usesGeneratedCa() bool {
+	tlsConfig := r.instance.Spec.Security.Tls
+	generated := func(generate bool, config opsterv1.TlsCertificateConfig) bool {
+		return generate && config.CertManager == nil && config.CaSecret.Name == ""
+	}
+	if tlsConfig.Transport != nil && generated(tlsConfig.Transport.Generate, tlsConfig.Transport.CertificateConfig) {
+		return true
+	}
+	return tlsConfig.Http != nil && generated(tlsConfig.Http.Generate, tlsConfig.Http.CertificateConfig)
+}
+
+func (r *TLSReconciler) caRotationRequested(ca tls.Cert, rotation opsterv1.CaRotationStatus) bool {
+	if token := r.instance.Spec.Security.Tls.RotateCa; token != "" && token != rotation.Token {
+		r.logger.Info("CA rotation requested")
+		return true
+	}
+	notAfter, err := tls.CertificateNotAfter(ca.CertData())
+	if err != nil {
+		return false
+	}
+	if CertificateNeedsRenewal(notAfter, caRenewBefore, time.Now()) {
+		r.logger.Info("CA is about to expire, starting rotation", "notAfter", notAfter)
+		return true
+	}
+	return false
+}
+
+// startCaRotation generates the new CA, it is only used for signing once all nodes trust it
+func (r *TLSReconciler) startCaRotation() error {
+	nextSecret := corev1.Secret{}
+	err := r.Get(r.ctx, client.ObjectKey{Name: r.nextCaSecretName(), Namespace: r.instance.Namespace}, &nextSecret)
+	if err == nil || !k8serrors.IsNotFound(err) {
+		return err
+	}
+	options, err := helpers.CaCertificateOptions(r.instance)
+	if err != nil {
+		return err
+	}
+	next, err := r.pki.GenerateCA(options)
+	if err != nil {
+		r.logger.Error(err, "Failed to create CA")
+		return err
+	}
+	return r.createCaSecret(r.nextCaSecretName(), next.SecretDataCA())
+}
+
+// swapCa replaces the current CA with the new one and keeps the old one to be trusted until all certificates are re-issued.
+// Every step can be repeated if the operator is interrupted.
+func (r *TLSReconciler) swapCa() error {
+	nextSecret := corev1.Secret{}
+	if err := r.Get(r.ctx, client.ObjectKey{Name: r.nextCaSecretName(), Namespace: r.instance.Namespace}, &nextSecret); err != nil {
+		if k8serrors.IsNotFound(err) {
+			// Already swapped
+			return nil
+		}
+		return err
+	}
+	caSecret := corev1.Secret{}
+	if err := r.Get(r.ctx, client.ObjectKey{Name: r.caSecretName(), Namespace: r.instance.Namespace}, &caSecret); err != nil {
+		return err
+	}
+
+	previousSecret := corev1.Secret{}
+	err := r.Get(r.ctx, client.ObjectKey{Name: r.previousCaSecretName(), Namespace: r.instance.Namespace}, &previousSecret)
+	if k8serrors.IsNotFound(err) {
+		err = r.createCaSecret(r.previousCaSecretName(), caSecret.Data)
+	}
+	if err != nil {
+		return err
+	}
+
+	r.logger.Info("Replacing CA")
+	caSecret.Data = nextSecret.Data
+	if err := r.Update(r.ctx, &caSecret); err != nil {
+		return err
+	}
+	return r.deleteCaSecret(r.nextCaSecretName())
+}
+
+// buildTrustBundle combines all CAs that are currently in use
+func (r *TLSReconciler) buildTrustBundle() error {
+	var bundle [][]byte
+	for _, name := range []string{r.caSecretName(), r.nextCaSecretName(), r.previousCaSecretName()} {
+		secret := corev1.Secret{}
+		if err := r.Get(r.ctx, client.ObjectKey{Name: name, Namespace: r.instance.Namespace}, &secret); err != nil {
+			if k8serrors.IsNotFound(err) {
+				continue
+			}
+			return err
+		}
+		bundle = append(bundle, bytes.TrimSpace(secret.Data[CaCertKey]))
+	}
+	if len(bundle) > 1 {
+		r.trustBundle = append(bytes.Join(bundle, []byte("\n")), '\n')
+	}
+	return nil
+}
+
+// caBundle returns the CA certificates to put into secrets with certificates signed by the CA
+func (r *TLSReconciler) caBundle(ca tls.Cert) []byte {
+	if r.trustBundle != nil && bytes.Contains(r.trustBundle, bytes.TrimSpace(ca.CertData())) {
+		return r.trustBundle
+	}
+	return ca.CertData()
+}
+
+// nodesRestartedSince checks if all nodes are ready and were started after the given time
+func (r *TLSReconciler) nodesRestartedSince(since *metav1.Time) (bool, error) {
+	if since == nil {
+		return true, nil
+	}
+	for _, nodePool := range r.instance.Spec.NodePools {
+		sts := appsv1.StatefulSet{}
+		if err := r.Get(r.ctx, client.ObjectKey{Name: builders.StsName(r.instance, &nodePool), Namespace: r.instance.Namespace}, &sts); err != nil {
+			if k8serrors.IsNotFound(err) {
+				return false, nil
+			}
+			return false, err
+		}
+		if sts.Status.ReadyReplicas != pointer.Int32Deref(sts.Spec.Replicas, 1) {
+			return false, nil
+		}
+		pods := corev1.PodList{}
+		if err := r.List(r.ctx, &pods, client.InNamespace(r.instance.Namespace), client.MatchingLabels{
+			builders.ClusterLabel:  r.instance.Name,
+			builders.NodePoolLabel: nodePool.Component,
+		}); err != nil {
+			return false, err
+		}
+		for _, pod := range pods.Items {
+			if pod.CreationTimestamp.Before(since) {
+				return false, nil
+			}
+		}
+	}
+	return true, nil
+}
+
+func (r *TLSReconciler) updateCaRotationStatus(phase string, token string) error {
+	r.logger.Info("CA rotation changed phase", "phase", phase)
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if err := r.Get(r.ctx, client.ObjectKeyFromObject(r.instance), r.instance); err != nil {
+			return err
+		}
+		rotation := r.instance.Status.CaRotation
+		if rotation == nil {
+			rotation = &opsterv1.CaRotationStatus{}
+		}
+		rotation.Phase = phase
+		rotation.Token = token
+		// Completing the rotation must not restart the nodes again
+		if phase != opsterv1.CaRotationPhaseCompleted {
+			rotation.PhaseStartTime = &metav1.Time{Time: time.Now().Truncate(time.Second)}
+		}
+		r.instance.Status.CaRotation = rotation
+		return r.Status().Update(r.ctx, r.instance)
+	})
+}
+
+func (r *TLSReconciler) createCaSecret(name string, data map[string][]byte) error {
+	secret := corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: r.instance.Namespace}, Data: data}
+	if err := ctrl.SetControllerReference(r.instance, &secret, r.Client.Scheme()); err != nil {
+		return err
+	}
+	return r.Create(r.ctx, &secret)
+}
+
+func (r *TLSReconciler) deleteCaSecret(name string) error {
+	secret := corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: r.instance.Namespace}}
+	if err := r.Delete(r.ctx, &secret); err != nil && !k8serrors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+func (r *TLSReconciler) caSecretName() string {
+	return r.instance.Name + "-ca"
+}
+
+func (r *TLSReconciler) nextCaSecretName() string {
+	return r.instance.Name + "-ca-next"
+}
+
+func (r *TLSReconciler) previousCaSecretName() string {
+	return r.instance.Name + "-ca-previous"
+}
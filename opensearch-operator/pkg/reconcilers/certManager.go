@@ -0,0 +1,245 @@
+package reconcilers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"time"
+
+	"github.com/banzaicloud/operator-tools/pkg/reconciler"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	opsterv1 "opensearch.opster.io/api/v1"
+	"opensearch.opster.io/pkg/builders"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	certificateIssueWaitInterval = 10 * time.Second
+)
+
+var (
+	errCertificateNotIssued    = errors.New("certificate not yet issued by cert-manager")
+	errCertManagerNotInstalled = errors.New("cert-manager is configured as certificate source but its CRDs are not installed")
+)
+
+// issuedCertificate is a certificate issued by cert-manager
+type issuedCertificate struct {
+	secret corev1.Secret
+	// Number of times the certificate was re-issued after it was first issued
+	renewals int64
+}
+
+// reconcileCertManagerCertificate creates or updates a cert-manager Certificate and returns the issued certificate.
+// Until cert-manager has stored the certificate in the secret errCertificateNotIssued is returned.
+func reconcileCertManagerCertificate(
+	ctx context.Context,
+	k8sClient client.Client,
+	resourceReconciler reconciler.ResourceReconciler,
+	instance *opsterv1.OpenSearchCluster,
+	certificate *unstructured.Unstructured,
+) (issuedCertificate, error) {
+	issued := issuedCertificate{}
+	_, err := k8sClient.RESTMapper().RESTMapping(builders.CertificateGVK.GroupKind(), builders.CertificateGVK.Version)
+	if meta.IsNoMatchError(err) {
+		return issued, errCertManagerNotInstalled
+	}
+	if err != nil {
+		return issued, err
+	}
+
+	if err := ctrl.SetControllerReference(instance, certificate, k8sClient.Scheme()); err != nil {
+		return issued, err
+	}
+	if _, err := resourceReconciler.ReconcileResource(certificate, reconciler.StatePresent); err != nil {
+		return issued, err
+	}
+
+	current := &unstructured.Unstructured{}
+	current.SetGroupVersionKind(builders.CertificateGVK)
+	if err := k8sClient.Get(ctx, client.ObjectKeyFromObject(certificate), current); err != nil {
+		return issued, err
+	}
+	if revision, found, _ := unstructured.NestedInt64(current.Object, "status", "revision"); found && revision > 1 {
+		issued.renewals = revision - 1
+	}
+
+	secretName, _, _ := unstructured.NestedString(certificate.Object, "spec", "secretName")
+	if err := k8sClient.Get(ctx, client.ObjectKey{Name: secretName, Namespace: instance.Namespace}, &issued.secret); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return issued, errCertificateNotIssued
+		}
+		return issued, err
+	}
+	if len(issued.secret.Data[corev1.TLSCertKey]) == 0 || len(issued.secret.Data[corev1.TLSPrivateKeyKey]) == 0 {
+		return issued, errCertificateNotIssued
+	}
+	return issued, nil
+}
+
+// certificateResult requeues the reconcile if certificates are not yet issued
+func certificateResult(err error, logger logr.Logger) (ctrl.Result, error) {
+	if errors.Is(err, errCertificateNotIssued) {
+		logger.Info("Waiting for cert-manager to issue certificates")
+		return ctrl.Result{Requeue: true, RequeueAfter: certificateIssueWaitInterval}, nil
+	}
+	return ctrl.Result{}, err
+}
+
+func (r *TLSReconciler) issueCertificate(name string, secretName string, interfaceName string, commonName string, dnsNames []string, config opsterv1.TlsCertificateConfig) (issuedCertificate, error) {
+	certificate := builders.NewCertificate(r.instance, name, secretName, interfaceName, commonName, dnsNames, config)
+	issued, err := reconcileCertManagerCertificate(r.ctx, r.Client, r.ResourceReconciler, r.instance, certificate)
+	if err != nil {
+		return issued, err
+	}
+	// cert-manager renews certificates on its own, they are only tracked in the status
+	r.trackCertificate(interfaceName, &issued.secret, issued.secret.Data[corev1.TLSCertKey], false)
+	return issued, nil
+}
+
+func (r *TLSReconciler) handleTransportCertManager() error {
+	tlsConfig := r.instance.Spec.Security.Tls.Transport
+	clusterName := r.instance.Name
+	namespace := r.instance.Namespace
+	nodeSecretName := clusterName + "-transport-cert"
+
+	if tlsConfig.PerNode {
+		if err := r.handleTransportCertManagerPerNode(nodeSecretName); err != nil {
+			return err
+		}
+		mountFolder("transport", "certs", nodeSecretName, r.reconcilerContext)
+		r.reconcilerContext.AddConfig("plugins.security.ssl.transport.pemcert_filepath", "tls-transport/${HOSTNAME}.crt")
+		r.reconcilerContext.AddConfig("plugins.security.ssl.transport.pemkey_filepath", "tls-transport/${HOSTNAME}.key")
+		r.reconcilerContext.AddConfig("plugins.security.ssl.transport.enforce_hostname_verification", "true")
+	} else {
+		dnsNames := []string{
+			clusterName,
+			fmt.Sprintf("%s.%s", clusterName, namespace),
+			fmt.Sprintf("%s.%s.svc", clusterName, namespace),
+			fmt.Sprintf("%s.%s.svc.cluster.local", clusterName, namespace),
+		}
+		issued, err := r.issueCertificate(clusterName+"-transport", nodeSecretName, "transport", clusterName, dnsNames, tlsConfig.CertificateConfig)
+		if err != nil {
+			return err
+		}
+		r.addCertManagerRevision("transport", issued.renewals)
+		mountCertificates("transport", nodeSecretName, tlsConfig.CertificateConfig.CaSecret.Name, r.reconcilerContext)
+		r.reconcilerContext.AddConfig("plugins.security.ssl.transport.pemcert_filepath", fmt.Sprintf("tls-transport/%s", corev1.TLSCertKey))
+		r.reconcilerContext.AddConfig("plugins.security.ssl.transport.pemkey_filepath", fmt.Sprintf("tls-transport/%s", corev1.TLSPrivateKeyKey))
+		r.reconcilerContext.AddConfig("plugins.security.ssl.transport.enforce_hostname_verification", "false")
+	}
+	return nil
+}
+
+// handleTransportCertManagerPerNode creates a Certificate for every pod and combines the issued certificates into one
+// secret using the same layout as the generated per node certificates, so that all pods can mount the same secret
+func (r *TLSReconciler) handleTransportCertManagerPerNode(nodeSecretName string) error {
+	tlsConfig := r.instance.Spec.Security.Tls.Transport
+	var podNames []string
+	if !r.instance.Status.Initialized {
+		podNames = append(podNames, builders.BootstrapPodName(r.instance))
+	}
+	for _, nodePool := range r.instance.Spec.NodePools {
+		podNames = append(podNames, builders.NodePoolPodNames(r.instance, &nodePool)...)
+	}
+
+	data := map[string][]byte{}
+	certificates := map[string]bool{}
+	var renewals int64
+	var earliestCert []byte
+	pending := false
+	for _, podName := range podNames {
+		name := podName + "-transport"
+		certificates[name] = true
+		certificate := builders.NewCertificate(r.instance, name, name+"-cert", "transport", podName, podDnsNames(r.instance, podName), tlsConfig.CertificateConfig)
+		issued, err := reconcileCertManagerCertificate(r.ctx, r.Client, r.ResourceReconciler, r.instance, certificate)
+		if errors.Is(err, errCertificateNotIssued) {
+			pending = true
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		data[podName+".crt"] = issued.secret.Data[corev1.TLSCertKey]
+		data[podName+".key"] = issued.secret.Data[corev1.TLSPrivateKeyKey]
+		if _, ok := data[CaCertKey]; !ok && len(issued.secret.Data[CaCertKey]) > 0 {
+			data[CaCertKey] = issued.secret.Data[CaCertKey]
+		}
+		renewals += issued.renewals
+		earliestCert = earlierCertificate(earliestCert, issued.secret.Data[corev1.TLSCertKey])
+	}
+	if pending {
+		return errCertificateNotIssued
+	}
+	if err := r.deleteStaleCertificates("transport", certificates); err != nil {
+		return err
+	}
+
+	if caSecretName := tlsConfig.CertificateConfig.CaSecret.Name; caSecretName != "" {
+		caSecret := corev1.Secret{}
+		if err := r.Get(r.ctx, client.ObjectKey{Name: caSecretName, Namespace: r.instance.Namespace}, &caSecret); err != nil {
+			return err
+		}
+		data[CaCertKey] = caSecret.Data[CaCertKey]
+	}
+
+	nodeSecret := corev1.Secret{}
+	if err := r.Get(r.ctx, client.ObjectKey{Name: nodeSecretName, Namespace: r.instance.Namespace}, &nodeSecret); err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return err
+		}
+		nodeSecret = corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: nodeSecretName, Namespace: r.instance.Namespace}, Data: data}
+		if err := ctrl.SetControllerReference(r.instance, &nodeSecret, r.Client.Scheme()); err != nil {
+			return err
+		}
+		if err := r.Create(r.ctx, &nodeSecret); err != nil {
+			r.logger.Error(err, "Failed to store node certificates in secret", "interface", "transport")
+			return err
+		}
+	} else if !reflect.DeepEqual(nodeSecret.Data, data) {
+		nodeSecret.Data = data
+		if err := r.Update(r.ctx, &nodeSecret); err != nil {
+			r.logger.Error(err, "Failed to store node certificates in secret", "interface", "transport")
+			return err
+		}
+	}
+	r.trackCertificate("transport", &nodeSecret, earliestCert, false)
+	r.addCertManagerRevision("transport", renewals)
+	return nil
+}
+
+// deleteStaleCertificates removes the Certificates of an interface that are no longer needed, e.g. after scaling down
+func (r *TLSReconciler) deleteStaleCertificates(interfaceName string, certificates map[string]bool) error {
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(builders.CertificateListGVK)
+	if err := r.List(r.ctx, list, client.InNamespace(r.instance.Namespace), client.MatchingLabels{
+		builders.ClusterLabel:              r.instance.Name,
+		builders.CertificateInterfaceLabel: interfaceName,
+	}); err != nil {
+		return err
+	}
+	for i := range list.Items {
+		if certificates[list.Items[i].GetName()] {
+			continue
+		}
+		r.logger.Info("Deleting certificate that is no longer needed", "certificate", list.Items[i].GetName())
+		if err := r.Delete(r.ctx, &list.Items[i]); err != nil && !k8serrors.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// addCertManagerRevision restarts the nodes when cert-manager re-issued certificates mounted into them
+func (r *TLSReconciler) addCertManagerRevision(name string, renewals int64) {
+	if renewals > 0 {
+		r.reconcilerContext.AddCertificateRevision(name, strconv.FormatInt(renewals, 10))
+	}
+}
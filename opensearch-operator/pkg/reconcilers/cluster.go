@@ -2,6 +2,8 @@ package reconcilers
 
 import (
 	"context"
+	"strings"
+	"time"
 
 	"github.com/banzaicloud/operator-tools/pkg/reconciler"
 	appsv1 "k8s.io/api/apps/v1"
@@ -15,6 +17,11 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/log"
 )
 
+const (
+	securityConfigComponent = "SecurityConfig"
+	securityConfigInvalid   = "Invalid"
+)
+
 type ClusterReconciler struct {
 	client.Client
 	reconciler.ResourceReconciler
@@ -47,9 +54,17 @@ func (r *ClusterReconciler) Reconcile() (ctrl.Result, error) {
 	//lg := log.FromContext(r.ctx)
 	result := reconciler.CombinedResult{}
 	username, password, err := helpers.UsernameAndPassword(r.ctx, r.Client, r.instance)
+	if err == helpers.ErrAdminCredentialsRequired {
+		r.recorder.Event(r.instance, "Warning", "invalid security config", err.Error())
+		// Checked again periodically as the admin certificate secret can be created later
+		return ctrl.Result{Requeue: true, RequeueAfter: 30 * time.Second}, r.updateSecurityConfigStatus(err)
+	}
 	if err != nil {
 		return ctrl.Result{}, err
 	}
+	if err := r.updateSecurityConfigStatus(nil); err != nil {
+		return ctrl.Result{}, err
+	}
 
 	clusterService := builders.NewServiceForCR(r.instance)
 	result.CombineErr(ctrl.SetControllerReference(r.instance, clusterService, r.Client.Scheme()))
@@ -76,9 +91,16 @@ func (r *ClusterReconciler) Reconcile() (ctrl.Result, error) {
 		result.CombineErr(ctrl.SetControllerReference(r.instance, headlessService, r.Client.Scheme()))
 		result.Combine(r.ReconcileResource(headlessService, reconciler.StatePresent))
 
+		if unknown := helpers.UnknownRoles(nodePool.Roles); len(unknown) > 0 {
+			r.recorder.Eventf(r.instance, "Warning", "invalid roles", "node pool %s has unknown roles %s, it is not updated until they are removed", nodePool.Component, strings.Join(unknown, ","))
+			continue
+		}
 		result.Combine(r.reconcileNodeStatefulSet(nodePool, username))
 	}
 
+	result.CombineErr(r.reconcileDisruptionBudgets())
+	result.Combine(r.reconcilePodZones())
+
 	// if Version isn't set we set it now to check for upgrades later.
 	if r.instance.Status.Version == "" {
 		err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
@@ -94,6 +116,43 @@ func (r *ClusterReconciler) Reconcile() (ctrl.Result, error) {
 	return result.Result, result.Err
 }
 
+// updateSecurityConfigStatus reports a security config the operator can not authenticate with in the components status
+func (r *ClusterReconciler) updateSecurityConfigStatus(configErr error) error {
+	current := opsterv1.ComponentStatus{}
+	for _, status := range r.instance.Status.ComponentsStatus {
+		if status.Component == securityConfigComponent {
+			current = status
+		}
+	}
+	desired := opsterv1.ComponentStatus{}
+	if configErr != nil {
+		desired = opsterv1.ComponentStatus{
+			Component:   securityConfigComponent,
+			Status:      securityConfigInvalid,
+			Description: configErr.Error(),
+		}
+	}
+	if current == desired {
+		return nil
+	}
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if err := r.Get(r.ctx, client.ObjectKeyFromObject(r.instance), r.instance); err != nil {
+			return err
+		}
+		statuses := []opsterv1.ComponentStatus{}
+		for _, status := range r.instance.Status.ComponentsStatus {
+			if status.Component != securityConfigComponent {
+				statuses = append(statuses, status)
+			}
+		}
+		if configErr != nil {
+			statuses = append(statuses, desired)
+		}
+		r.instance.Status.ComponentsStatus = statuses
+		return r.Status().Update(r.ctx, r.instance)
+	})
+}
+
 func (r *ClusterReconciler) reconcileNodeStatefulSet(nodePool opsterv1.NodePool, username string) (*ctrl.Result, error) {
 	found, nodePoolConfig := r.reconcilerContext.fetchNodePoolHash(nodePool.Component)
 	// If config hasn't been set up for the node pool requeue
@@ -103,8 +162,6 @@ func (r *ClusterReconciler) reconcileNodeStatefulSet(nodePool opsterv1.NodePool,
 		}, nil
 	}
 
-	extraConfig := helpers.MergeConfigs(r.instance.Spec.General.AdditionalConfig, nodePool.AdditionalConfig)
-
 	sts := builders.NewSTSForNodePool(
 		username,
 		r.instance,
@@ -112,7 +169,6 @@ func (r *ClusterReconciler) reconcileNodeStatefulSet(nodePool opsterv1.NodePool,
 		nodePoolConfig.ConfigHash,
 		r.reconcilerContext.Volumes,
 		r.reconcilerContext.VolumeMounts,
-		extraConfig,
 	)
 	if err := ctrl.SetControllerReference(r.instance, sts, r.Client.Scheme()); err != nil {
 		return &ctrl.Result{}, err
@@ -135,11 +191,17 @@ func (r *ClusterReconciler) reconcileNodeStatefulSet(nodePool opsterv1.NodePool,
 	// This will allow the scaler reconciler to function correctly
 	sts.Spec.Replicas = existing.Spec.Replicas
 
+	// The statefulset is recreated with the new volume claim templates once it is deleted
+	recreate, err := r.reconcileVolumes(nodePool, existing, sts)
+	if err != nil || recreate {
+		return &ctrl.Result{Requeue: true, RequeueAfter: 5 * time.Second}, err
+	}
+
 	// Don't update env vars on non data nodes while an upgrade is in progress
 	// as we don't want uncontrolled restarts while we're doing an upgrade
 	if r.instance.Status.Version != "" &&
 		r.instance.Status.Version != r.instance.Spec.General.Version &&
-		!helpers.ContainsString(nodePool.Roles, "data") {
+		!helpers.HasRole(nodePool.Roles, "data") {
 		sts.Spec.Template.Spec.Containers[0].Env = existing.Spec.Template.Spec.Containers[0].Env
 	}
 
@@ -0,0 +1,224 @@
+package reconcilers
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/banzaicloud/operator-tools/pkg/reconciler"
+	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/retry"
+	opsterv1 "opensearch.opster.io/api/v1"
+	"opensearch.opster.io/opensearch-gateway/responses"
+	"opensearch.opster.io/opensearch-gateway/services"
+	"opensearch.opster.io/pkg/helpers"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+type ClusterSettingsReconciler struct {
+	client.Client
+	reconciler.ResourceReconciler
+	ctx               context.Context
+	osClient          *services.OsClusterClient
+	recorder          record.EventRecorder
+	reconcilerContext *ReconcilerContext
+	instance          *opsterv1.OpenSearchCluster
+	logger            logr.Logger
+}
+
+func NewClusterSettingsReconciler(
+	client client.Client,
+	ctx context.Context,
+	recorder record.EventRecorder,
+	reconcilerContext *ReconcilerContext,
+	instance *opsterv1.OpenSearchCluster,
+	opts ...reconciler.ResourceReconcilerOption,
+) *ClusterSettingsReconciler {
+	return &ClusterSettingsReconciler{
+		Client: client,
+		ResourceReconciler: reconciler.NewReconcilerWith(client,
+			append(opts, reconciler.WithLog(log.FromContext(ctx).WithValues("reconciler", "clustersettings")))...),
+		ctx:               ctx,
+		recorder:          recorder,
+		reconcilerContext: reconcilerContext,
+		instance:          instance,
+		logger:            log.FromContext(ctx).WithValues("reconciler", "clustersettings"),
+	}
+}
+
+// Reconcile keeps the persistent cluster settings in sync with the cluster settings and the dynamic settings of the
+// additional config. Settings changed outside of the operator are reported as drift and re-applied, settings removed
+// from the resource are reset to their defaults.
+func (r *ClusterSettingsReconciler) Reconcile() (ctrl.Result, error) {
+	desired := desiredClusterSettings(r.instance)
+	if len(desired) == 0 && r.instance.Status.ClusterSettings == nil {
+		return ctrl.Result{}, nil
+	}
+	if !r.instance.Status.Initialized {
+		return ctrl.Result{Requeue: true, RequeueAfter: 30 * time.Second}, nil
+	}
+	var err error
+	r.osClient, err = newOsClientForCluster(r.ctx, r.Client, r.instance)
+	if err != nil {
+		r.logger.Error(err, "failed to create os client")
+		return ctrl.Result{Requeue: true, RequeueAfter: 30 * time.Second}, nil
+	}
+
+	current, err := r.osClient.GetFlatClusterSettings(r.ctx)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	previousReason := ""
+	var applied map[string]string
+	if r.instance.Status.ClusterSettings != nil {
+		previousReason = r.instance.Status.ClusterSettings.Reason
+		applied = r.instance.Status.ClusterSettings.Applied
+	}
+	changed, drift := diffClusterSettings(desired, applied, current.Persistent)
+	if len(drift) > 0 {
+		names := make([]string, 0, len(drift))
+		for _, setting := range drift {
+			names = append(names, setting.Name)
+		}
+		r.recorder.Eventf(r.instance, "Warning", "cluster settings", "cluster settings %s were changed outside of the operator, re-applying them", strings.Join(names, ","))
+	}
+
+	var updateErr error
+	if len(changed) > 0 {
+		if _, updateErr = r.osClient.PutClusterSettings(r.ctx, responses.ClusterSettingsResponse{Persistent: changed}); updateErr != nil {
+			if updateErr.Error() != previousReason {
+				r.recorder.Eventf(r.instance, "Warning", "cluster settings", "failed to update cluster settings: %s", updateErr)
+			}
+		} else {
+			r.recorder.Eventf(r.instance, "Normal", "cluster settings", "updated cluster settings %s", strings.Join(settingNames(changed), ","))
+		}
+	}
+
+	newStatus := nextClusterSettingsStatus(r.instance.Status.ClusterSettings, desired, drift, updateErr, time.Now())
+	if reflect.DeepEqual(newStatus, r.instance.Status.ClusterSettings) {
+		return ctrl.Result{}, nil
+	}
+	return ctrl.Result{}, retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if err := r.Get(r.ctx, client.ObjectKeyFromObject(r.instance), r.instance); err != nil {
+			return err
+		}
+		r.instance.Status.ClusterSettings = newStatus
+		return r.Status().Update(r.ctx, r.instance)
+	})
+}
+
+// nextClusterSettingsStatus derives the status after comparing the settings. Drift is only reported while it is
+// found, so it is cleared once the re-applied settings match. Returns nil if there is nothing to report.
+func nextClusterSettingsStatus(previous *opsterv1.ClusterSettingsStatus, desired map[string]string, drift []opsterv1.ClusterSettingDrift, updateErr error, now time.Time) *opsterv1.ClusterSettingsStatus {
+	status := opsterv1.ClusterSettingsStatus{}
+	if previous != nil {
+		status = *previous.DeepCopy()
+	}
+	status.Drift = drift
+	if len(drift) > 0 {
+		status.LastDriftTime = &metav1.Time{Time: now}
+	}
+	status.Reason = ""
+	if updateErr != nil {
+		status.Reason = updateErr.Error()
+	} else {
+		status.Applied = nil
+		if len(desired) > 0 {
+			status.Applied = desired
+		}
+	}
+	if len(status.Applied) == 0 && len(status.Drift) == 0 && status.Reason == "" {
+		return nil
+	}
+	return &status
+}
+
+// desiredClusterSettings returns the allocation awareness settings and the dynamic settings of the general additional
+// config merged with the cluster settings, which take precedence
+func desiredClusterSettings(cr *opsterv1.OpenSearchCluster) map[string]string {
+	desired, _ := helpers.SplitDynamicSettings(cr.Spec.General.AdditionalConfig)
+	if cr.Spec.ZoneAwareness != nil {
+		desired["cluster.routing.allocation.awareness.attributes"] = "zone"
+		if len(cr.Spec.ZoneAwareness.ForcedZones) > 0 {
+			desired["cluster.routing.allocation.awareness.force.zone.values"] = strings.Join(cr.Spec.ZoneAwareness.ForcedZones, ",")
+		}
+	}
+	for name, value := range cr.Spec.ClusterSettings {
+		desired[name] = value
+	}
+	return desired
+}
+
+// diffClusterSettings returns the settings that have to be updated, with nil values for settings that were applied
+// before but are no longer desired, and the previously applied settings whose value was changed in the cluster.
+func diffClusterSettings(desired map[string]string, applied map[string]string, current map[string]interface{}) (map[string]interface{}, []opsterv1.ClusterSettingDrift) {
+	changed := map[string]interface{}{}
+	var drift []opsterv1.ClusterSettingDrift
+	for _, name := range sortedKeys(desired) {
+		value := desired[name]
+		// Lists can be written as JSON or YAML lists, they are sent as lists and compared in their comma separated form
+		desiredValue := settingYamlValue(value)
+		actual, ok := current[name]
+		actualValue := settingValue(actual)
+		if ok && actualValue == settingValue(desiredValue) {
+			continue
+		}
+		changed[name] = desiredValue
+		if appliedValue, wasApplied := applied[name]; wasApplied && appliedValue == value {
+			drift = append(drift, opsterv1.ClusterSettingDrift{Name: name, Expected: value, Actual: actualValue})
+		}
+	}
+	for name := range applied {
+		if _, ok := desired[name]; ok {
+			continue
+		}
+		if _, ok := current[name]; ok {
+			changed[name] = nil
+		}
+	}
+	return changed, drift
+}
+
+// settingValue converts a flat setting value to its string form, lists are joined with commas
+func settingValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case []interface{}:
+		values := make([]string, 0, len(v))
+		for _, item := range v {
+			values = append(values, settingValue(item))
+		}
+		return strings.Join(values, ",")
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+func sortedKeys(settings map[string]string) []string {
+	names := make([]string, 0, len(settings))
+	for name := range settings {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func settingNames(settings map[string]interface{}) []string {
+	names := make([]string, 0, len(settings))
+	for name := range settings {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
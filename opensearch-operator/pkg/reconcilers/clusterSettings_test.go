@@ -0,0 +1,105 @@
+package reconcilers
+
+import (
+	"errors"
+	"time"
+
+	opsterv1 "opensearch.opster.io/api/v1"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Cluster Settings Reconciler", func() {
+
+	Context("When comparing the cluster settings with the cluster", func() {
+		It("should update changed settings, report drift and reset removed settings", func() {
+			desired := map[string]string{
+				"indices.recovery.max_bytes_per_sec":             "100mb",
+				"cluster.routing.allocation.disk.watermark.low":  "80%",
+				"cluster.routing.allocation.disk.watermark.high": "90%",
+				"action.auto_create_index":                       "logs-*,metrics-*",
+			}
+			applied := map[string]string{
+				"indices.recovery.max_bytes_per_sec":             "100mb",
+				"cluster.routing.allocation.disk.watermark.low":  "85%",
+				"cluster.routing.allocation.disk.watermark.high": "90%",
+				"action.auto_create_index":                       "logs-*,metrics-*",
+				"search.max_buckets":                             "20000",
+			}
+			current := map[string]interface{}{
+				"indices.recovery.max_bytes_per_sec":            "40mb",
+				"cluster.routing.allocation.disk.watermark.low": "85%",
+				"action.auto_create_index":                      []interface{}{"logs-*", "metrics-*"},
+				"search.max_buckets":                            "20000",
+				"cluster.remote.eu.seeds":                       []interface{}{"eu:9300"},
+			}
+
+			changed, drift := diffClusterSettings(desired, applied, current)
+			Expect(changed).To(HaveLen(4))
+			Expect(changed).To(HaveKeyWithValue("indices.recovery.max_bytes_per_sec", "100mb"))
+			Expect(changed).To(HaveKeyWithValue("cluster.routing.allocation.disk.watermark.low", "80%"))
+			Expect(changed).To(HaveKeyWithValue("cluster.routing.allocation.disk.watermark.high", "90%"))
+			Expect(changed).To(HaveKeyWithValue("search.max_buckets", BeNil()))
+			Expect(drift).To(Equal([]opsterv1.ClusterSettingDrift{
+				{Name: "cluster.routing.allocation.disk.watermark.high", Expected: "90%"},
+				{Name: "indices.recovery.max_bytes_per_sec", Expected: "100mb", Actual: "40mb"},
+			}))
+		})
+
+		It("should compare list settings independent of their notation", func() {
+			desired := map[string]string{
+				"cluster.routing.allocation.awareness.force.zone.values": `["eu-1a","eu-1b"]`,
+				"action.auto_create_index":                               "[logs-*, metrics-*]",
+				"cluster.remote.eu.seeds":                                `["eu:9300","eu:9301"]`,
+			}
+			current := map[string]interface{}{
+				"cluster.routing.allocation.awareness.force.zone.values": []interface{}{"eu-1a", "eu-1b"},
+				"action.auto_create_index":                               []interface{}{"logs-*", "metrics-*"},
+				"cluster.remote.eu.seeds":                                []interface{}{"eu:9300"},
+			}
+
+			changed, drift := diffClusterSettings(desired, desired, current)
+			Expect(changed).To(Equal(map[string]interface{}{
+				"cluster.remote.eu.seeds": []interface{}{"eu:9300", "eu:9301"},
+			}))
+			Expect(drift).To(Equal([]opsterv1.ClusterSettingDrift{
+				{Name: "cluster.remote.eu.seeds", Expected: `["eu:9300","eu:9301"]`, Actual: "eu:9300"},
+			}))
+		})
+	})
+
+	Context("When updating the status", func() {
+		It("should clear the drift once the re-applied settings match", func() {
+			desired := map[string]string{"indices.recovery.max_bytes_per_sec": "100mb"}
+			status := &opsterv1.ClusterSettingsStatus{Applied: desired}
+
+			By("finding the drift")
+			changed, drift := diffClusterSettings(desired, status.Applied, map[string]interface{}{"indices.recovery.max_bytes_per_sec": "40mb"})
+			Expect(changed).To(HaveLen(1))
+			status = nextClusterSettingsStatus(status, desired, drift, nil, time.Now())
+			Expect(status.Drift).To(HaveLen(1))
+			Expect(status.LastDriftTime).ToNot(BeNil())
+
+			By("keeping the drift while the settings can not be re-applied")
+			status = nextClusterSettingsStatus(status, desired, drift, errors.New("unavailable"), time.Now())
+			Expect(status.Drift).To(HaveLen(1))
+			Expect(status.Reason).To(Equal("unavailable"))
+
+			By("clearing the drift after the settings were re-applied")
+			changed, drift = diffClusterSettings(desired, status.Applied, map[string]interface{}{"indices.recovery.max_bytes_per_sec": "100mb"})
+			Expect(changed).To(BeEmpty())
+			status = nextClusterSettingsStatus(status, desired, drift, nil, time.Now())
+			Expect(status.Drift).To(BeNil())
+			Expect(status.Reason).To(BeEmpty())
+			Expect(status.Applied).To(Equal(desired))
+		})
+
+		It("should remove the status once no settings are applied", func() {
+			status := &opsterv1.ClusterSettingsStatus{
+				Drift: []opsterv1.ClusterSettingDrift{{Name: "search.max_buckets", Expected: "20000"}},
+			}
+			Expect(nextClusterSettingsStatus(status, map[string]string{}, nil, nil, time.Now())).To(BeNil())
+		})
+	})
+})
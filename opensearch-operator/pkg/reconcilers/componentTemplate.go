@@ -0,0 +1,125 @@
+package reconcilers
+
+import (
+	"context"
+
+	"github.com/banzaicloud/operator-tools/pkg/reconciler"
+	"github.com/go-logr/logr"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/retry"
+	opsterv1 "opensearch.opster.io/api/v1"
+	"opensearch.opster.io/opensearch-gateway/requests"
+	"opensearch.opster.io/opensearch-gateway/services"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+type ComponentTemplateReconciler struct {
+	client.Client
+	reconciler.ResourceReconciler
+	ctx      context.Context
+	osClient *services.OsClusterClient
+	recorder record.EventRecorder
+	instance *opsterv1.OpenSearchComponentTemplate
+	logger   logr.Logger
+}
+
+func NewComponentTemplateReconciler(
+	client client.Client,
+	ctx context.Context,
+	recorder record.EventRecorder,
+	instance *opsterv1.OpenSearchComponentTemplate,
+	opts ...reconciler.ResourceReconcilerOption,
+) *ComponentTemplateReconciler {
+	return &ComponentTemplateReconciler{
+		Client: client,
+		ResourceReconciler: reconciler.NewReconcilerWith(client,
+			append(opts, reconciler.WithLog(log.FromContext(ctx).WithValues("reconciler", "componenttemplate")))...),
+		ctx:      ctx,
+		recorder: recorder,
+		instance: instance,
+		logger:   log.FromContext(ctx).WithValues("reconciler", "componenttemplate"),
+	}
+}
+
+func (r *ComponentTemplateReconciler) Reconcile() (ctrl.Result, error) {
+	cluster, err := fetchReadyCluster(r.ctx, r.Client, r.instance.Namespace, r.instance.Spec.OpensearchRef)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if cluster == nil {
+		return ctrl.Result{Requeue: true, RequeueAfter: templateWaitInterval}, r.updateStatus(opsterv1.TemplatePhasePending, "waiting for opensearch cluster to be initialized", r.instance.Status.ExistingName)
+	}
+	r.osClient, err = newOsClientForCluster(r.ctx, r.Client, cluster)
+	if err != nil {
+		r.logger.Error(err, "failed to create os client")
+		return ctrl.Result{Requeue: true, RequeueAfter: templateWaitInterval}, nil
+	}
+
+	template, err := buildTemplate(r.instance.Spec.Template)
+	if err != nil {
+		r.recorder.Eventf(r.instance, "Warning", "invalid template", "failed to parse template: %s", err)
+		// No requeue, a change to the template will trigger a new reconcile
+		return ctrl.Result{}, r.updateStatus(opsterv1.TemplatePhaseError, err.Error(), r.instance.Status.ExistingName)
+	}
+
+	name := r.templateName()
+	// The template was renamed, remove the old one from the cluster
+	if existing := r.instance.Status.ExistingName; existing != "" && existing != name {
+		if err := r.osClient.DeleteComponentTemplate(r.ctx, existing); err != nil {
+			return ctrl.Result{}, err
+		}
+		r.recorder.Eventf(r.instance, "Normal", "template deleted", "deleted renamed component template %s", existing)
+	}
+
+	changed, err := services.EnsureComponentTemplate(r.ctx, r.osClient, name, requests.ComponentTemplate{
+		Version:  r.instance.Spec.Version,
+		Template: template,
+	})
+	if err != nil {
+		r.recorder.Eventf(r.instance, "Warning", "template failed", "failed to apply component template %s: %s", name, err)
+		if statusErr := r.updateStatus(opsterv1.TemplatePhaseError, err.Error(), name); statusErr != nil {
+			return ctrl.Result{}, statusErr
+		}
+		return ctrl.Result{}, err
+	}
+	if changed {
+		r.recorder.Eventf(r.instance, "Normal", "template applied", "applied component template %s", name)
+	}
+	return ctrl.Result{Requeue: true, RequeueAfter: templateResyncInterval}, r.updateStatus(opsterv1.TemplatePhaseApplied, "", name)
+}
+
+// Delete removes the template from the cluster, if the cluster no longer exists there is nothing to do.
+// OpenSearch refuses to delete a component template that is still used by an index template.
+func (r *ComponentTemplateReconciler) Delete() error {
+	name := r.instance.Status.ExistingName
+	if name == "" {
+		return nil
+	}
+	return deleteFromReadyCluster(r.ctx, r.Client, r.recorder, r.instance, r.instance.Spec.OpensearchRef, func(osClient *services.OsClusterClient) error {
+		return osClient.DeleteComponentTemplate(r.ctx, name)
+	})
+}
+
+func (r *ComponentTemplateReconciler) templateName() string {
+	if r.instance.Spec.Name != "" {
+		return r.instance.Spec.Name
+	}
+	return r.instance.Name
+}
+
+func (r *ComponentTemplateReconciler) updateStatus(phase string, reason string, existingName string) error {
+	if r.instance.Status.Phase == phase && r.instance.Status.Reason == reason && r.instance.Status.ExistingName == existingName {
+		return nil
+	}
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if err := r.Get(r.ctx, client.ObjectKeyFromObject(r.instance), r.instance); err != nil {
+			return err
+		}
+		r.instance.Status.Phase = phase
+		r.instance.Status.Reason = reason
+		r.instance.Status.ExistingName = existingName
+		return r.Status().Update(r.ctx, r.instance)
+	})
+}
@@ -10,6 +10,7 @@ import (
 	"strings"
 
 	"github.com/banzaicloud/operator-tools/pkg/reconciler"
+	"gopkg.in/yaml.v2"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
@@ -54,7 +55,7 @@ func NewConfigurationReconciler(
 }
 
 func (r *ConfigurationReconciler) Reconcile() (ctrl.Result, error) {
-	if r.reconcilerContext.OpenSearchConfig == nil || len(r.reconcilerContext.OpenSearchConfig) == 0 {
+	if len(r.reconcilerContext.OpenSearchConfig) == 0 && !r.hasAdditionalConfig() {
 		return ctrl.Result{}, nil
 	}
 	systemIndices, err := json.Marshal(services.AdditionalSystemIndices)
@@ -72,17 +73,22 @@ func (r *ConfigurationReconciler) Reconcile() (ctrl.Result, error) {
 		r.reconcilerContext.AddConfig("plugins.security.system_indices.indices", string(systemIndices))
 	}
 
-	var sb strings.Builder
-	keys := make([]string, 0, len(r.reconcilerContext.OpenSearchConfig))
-	for key := range r.reconcilerContext.OpenSearchConfig {
-		keys = append(keys, key)
+	// The bootstrap pod only gets the general settings, every node pool gets its own file with its settings
+	_, generalConfig := helpers.SplitDynamicSettings(r.instance.Spec.General.AdditionalConfig)
+	bootstrapData, err := renderConfig(mergeConfigs(r.reconcilerContext.OpenSearchConfig, generalConfig))
+	if err != nil {
+		return ctrl.Result{}, err
 	}
-	sort.Strings(keys)
-
-	for _, key := range keys {
-		sb.WriteString(fmt.Sprintf("%s: %s\n", key, r.reconcilerContext.OpenSearchConfig[key]))
+	data := map[string]string{
+		builders.BootstrapConfigKey: bootstrapData,
+	}
+	for _, nodePool := range r.instance.Spec.NodePools {
+		poolData, err := renderConfig(mergeConfigs(r.reconcilerContext.OpenSearchConfig, nodePoolStaticConfig(r.instance, nodePool)))
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		data[builders.NodePoolConfigKey(&nodePool)] = poolData
 	}
-	data := sb.String()
 
 	cm := r.buildConfigMap(data)
 	if err := ctrl.SetControllerReference(r.instance, cm, r.Client.Scheme()); err != nil {
@@ -96,7 +102,7 @@ func (r *ConfigurationReconciler) Reconcile() (ctrl.Result, error) {
 	}
 
 	volume := corev1.Volume{
-		Name: "config",
+		Name: builders.ConfigVolumeName,
 		VolumeSource: corev1.VolumeSource{
 			ConfigMap: &corev1.ConfigMapVolumeSource{
 				LocalObjectReference: corev1.LocalObjectReference{
@@ -107,29 +113,35 @@ func (r *ConfigurationReconciler) Reconcile() (ctrl.Result, error) {
 	}
 	r.reconcilerContext.Volumes = append(r.reconcilerContext.Volumes, volume)
 
-	mount := corev1.VolumeMount{
-		Name:      "config",
-		MountPath: "/usr/share/opensearch/config/opensearch.yml",
-		SubPath:   "opensearch.yml",
-	}
-	r.reconcilerContext.VolumeMounts = append(r.reconcilerContext.VolumeMounts, mount)
-
+	// Re-issued certificates are only picked up by restarting the nodes. Dynamic settings are applied by the cluster
+	// settings reconciler, so only static settings of a node pool restart its nodes.
 	for _, nodePool := range r.instance.Spec.NodePools {
-		result.Combine(r.createHashForNodePool(nodePool, data))
+		poolData := data[builders.NodePoolConfigKey(&nodePool)] + r.reconcilerContext.CertificateRevision
+		result.Combine(r.createHashForNodePool(nodePool, poolData))
 	}
 
 	return result.Result, result.Err
 }
 
-func (r *ConfigurationReconciler) buildConfigMap(data string) *corev1.ConfigMap {
+func (r *ConfigurationReconciler) hasAdditionalConfig() bool {
+	if len(r.instance.Spec.General.AdditionalConfig) > 0 {
+		return true
+	}
+	for _, nodePool := range r.instance.Spec.NodePools {
+		if len(nodePool.AdditionalConfig) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *ConfigurationReconciler) buildConfigMap(data map[string]string) *corev1.ConfigMap {
 	return &corev1.ConfigMap{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      fmt.Sprintf("%s-config", r.instance.Name),
 			Namespace: r.instance.Namespace,
 		},
-		Data: map[string]string{
-			"opensearch.yml": data,
-		},
+		Data: data,
 	}
 }
 
@@ -147,7 +159,7 @@ func (r *ConfigurationReconciler) createHashForNodePool(nodePool opsterv1.NodePo
 	// If an upgrade is in process we want to wait to schedule non data nodes
 	// data nodes will be picked up by the rolling restarter, or the upgrade
 	if r.instance.Status.Version != "" && r.instance.Status.Version != r.instance.Spec.General.Version {
-		if !helpers.ContainsString(nodePool.Roles, "data") {
+		if !helpers.HasRole(nodePool.Roles, "data") {
 			sts := &appsv1.StatefulSet{}
 			err := r.Get(r.ctx, types.NamespacedName{
 				Name:      builders.StsName(r.instance, &nodePool),
@@ -174,6 +186,82 @@ func (r *ConfigurationReconciler) DeleteResources() (ctrl.Result, error) {
 	return ctrl.Result{}, nil
 }
 
+// renderConfig renders the flat settings as YAML. Settings are nested by their keys, values that are lists, e.g.
+// ["a", "b"], are rendered as lists and all other values as strings that are quoted where needed.
+func renderConfig(config map[string]string) (string, error) {
+	keys := make([]string, 0, len(config))
+	for key := range config {
+		keys = append(keys, key)
+	}
+	// Parents are sorted before their children, so a setting that has children is always a value
+	sort.Strings(keys)
+
+	root := map[string]interface{}{}
+	for _, key := range keys {
+		insertSetting(root, strings.Split(key, "."), settingYamlValue(config[key]))
+	}
+	data, err := yaml.Marshal(root)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// insertSetting adds the value below the path, if a parent on the path already has a value the remaining path is
+// used as a flat key below it
+func insertSetting(tree map[string]interface{}, path []string, value interface{}) {
+	for i, part := range path[:len(path)-1] {
+		child, ok := tree[part]
+		if !ok {
+			child = map[string]interface{}{}
+			tree[part] = child
+		}
+		subtree, isTree := child.(map[string]interface{})
+		if !isTree {
+			tree[strings.Join(path[i:], ".")] = value
+			return
+		}
+		tree = subtree
+	}
+	tree[path[len(path)-1]] = value
+}
+
+func settingYamlValue(value string) interface{} {
+	if strings.HasPrefix(strings.TrimSpace(value), "[") {
+		var list []interface{}
+		if err := yaml.Unmarshal([]byte(value), &list); err == nil {
+			return list
+		}
+	}
+	return value
+}
+
+// mergeConfigs returns a new config with the settings of both configs, settings of the right config take precedence
+func mergeConfigs(left map[string]string, right map[string]string) map[string]string {
+	merged := make(map[string]string, len(left)+len(right))
+	for key, value := range left {
+		merged[key] = value
+	}
+	for key, value := range right {
+		merged[key] = value
+	}
+	return merged
+}
+
+// nodePoolStaticConfig returns the additional settings and node attributes of a node pool that can only be applied by
+// restarting its nodes. Settings of the node pool override the general settings and are always static, as dynamic
+// settings apply to the whole cluster.
+func nodePoolStaticConfig(cr *opsterv1.OpenSearchCluster, nodePool opsterv1.NodePool) map[string]string {
+	_, static := helpers.SplitDynamicSettings(cr.Spec.General.AdditionalConfig)
+	for key, value := range nodePool.AdditionalConfig {
+		static[key] = value
+	}
+	for key, value := range nodePool.Attributes {
+		static["node.attr."+key] = value
+	}
+	return static
+}
+
 func generateHash(source []byte) string {
 	hash := sha1.New()
 	hash.Write(source)
@@ -37,7 +37,7 @@ var _ = Describe("Configuration Controller", func() {
 					NodePools: []opsterv1.NodePool{
 						{
 							Component: "test",
-							Roles: []string{
+							Roles: []opsterv1.NodeRole{
 								"master",
 								"data",
 							},
@@ -79,7 +79,7 @@ var _ = Describe("Configuration Controller", func() {
 					NodePools: []opsterv1.NodePool{
 						{
 							Component: "test",
-							Roles: []string{
+							Roles: []opsterv1.NodeRole{
 								"master",
 								"data",
 							},
@@ -115,4 +115,128 @@ var _ = Describe("Configuration Controller", func() {
 			Expect(strings.Contains(data, "bar: baz\n")).To(BeTrue())
 		})
 	})
+
+	Context("When the additional config of the cluster changes", func() {
+		It("should only change the hashes of the node pools with changed static settings", func() {
+			hashes := func(general map[string]string, data map[string]string) map[string]string {
+				spec := opsterv1.OpenSearchCluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      clusterName,
+						Namespace: clusterName,
+						UID:       "dummyuid",
+					},
+					Spec: opsterv1.ClusterSpec{
+						General: opsterv1.GeneralConfig{AdditionalConfig: general},
+						NodePools: []opsterv1.NodePool{
+							{Component: "masters", Roles: []opsterv1.NodeRole{"master"}},
+							{Component: "data", Roles: []opsterv1.NodeRole{"data"}, AdditionalConfig: data},
+						},
+					},
+				}
+				reconcilerContext := NewReconcilerContext(spec.Spec.NodePools)
+				underTest := NewConfigurationReconciler(
+					k8sClient,
+					context.Background(),
+					&helpers.MockEventRecorder{},
+					&reconcilerContext,
+					&spec,
+				)
+				reconcilerContext.AddConfig("foo", "bar")
+				_, err := underTest.Reconcile()
+				Expect(err).ToNot(HaveOccurred())
+
+				result := map[string]string{}
+				for _, hash := range reconcilerContext.NodePoolHashes {
+					result[hash.Component] = hash.ConfigHash
+				}
+				return result
+			}
+
+			initial := hashes(map[string]string{"indices.recovery.max_bytes_per_sec": "40mb"}, nil)
+			dynamicChange := hashes(map[string]string{"indices.recovery.max_bytes_per_sec": "100mb"}, nil)
+			Expect(dynamicChange).To(Equal(initial))
+
+			poolChange := hashes(map[string]string{"indices.recovery.max_bytes_per_sec": "100mb"}, map[string]string{"node.attr.temp": "hot"})
+			Expect(poolChange["masters"]).To(Equal(initial["masters"]))
+			Expect(poolChange["data"]).ToNot(Equal(initial["data"]))
+
+			staticChange := hashes(map[string]string{"path.repo": "/backups"}, map[string]string{"node.attr.temp": "hot"})
+			Expect(staticChange["masters"]).ToNot(Equal(poolChange["masters"]))
+			Expect(staticChange["data"]).ToNot(Equal(poolChange["data"]))
+		})
+	})
+
+	Context("When rendering the configuration", func() {
+		It("should render nested keys, lists and quoted values", func() {
+			data, err := renderConfig(map[string]string{
+				"node.attr":                         "x",
+				"node.attr.zone":                    "eu-1a",
+				"plugins.security.nodes_dn":         `["CN=a,OU=b","CN=c-*,OU=d"]`,
+				"plugins.security.authcz.admin_dn":  "[CN=admin",
+				"plugins.security.ssl.http.enabled": "true",
+				"foo":                               "bar: baz",
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(data).To(Equal(`foo: 'bar: baz'
+node:
+  attr: x
+  attr.zone: eu-1a
+plugins:
+  security:
+    authcz:
+      admin_dn: '[CN=admin'
+    nodes_dn:
+    - CN=a,OU=b
+    - CN=c-*,OU=d
+    ssl:
+      http:
+        enabled: "true"
+`))
+		})
+	})
+
+	Context("When a node pool has custom attributes", func() {
+		It("should add them as node attributes to the config of the node pool", func() {
+			spec := opsterv1.OpenSearchCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      clusterName,
+					Namespace: clusterName,
+					UID:       "dummyuid",
+				},
+				Spec: opsterv1.ClusterSpec{
+					General: opsterv1.GeneralConfig{},
+					NodePools: []opsterv1.NodePool{
+						{Component: "hot", Roles: []opsterv1.NodeRole{"data"}, Attributes: map[string]string{"temp": "hot"}},
+						{Component: "warm", Roles: []opsterv1.NodeRole{"data"}, Attributes: map[string]string{"temp": "warm"}},
+					},
+				},
+			}
+			reconcilerContext := NewReconcilerContext(spec.Spec.NodePools)
+			underTest := NewConfigurationReconciler(
+				k8sClient,
+				context.Background(),
+				&helpers.MockEventRecorder{},
+				&reconcilerContext,
+				&spec,
+			)
+			reconcilerContext.AddConfig("foo", "bar")
+			_, err := underTest.Reconcile()
+			Expect(err).ToNot(HaveOccurred())
+
+			configMap := corev1.ConfigMap{}
+			Expect(k8sClient.Get(context.Background(), client.ObjectKey{Name: clusterName + "-config", Namespace: clusterName}, &configMap)).Should(Succeed())
+			Expect(configMap.Data["opensearch-hot.yml"]).To(ContainSubstring("node:\n  attr:\n    temp: hot\n"))
+			Expect(configMap.Data["opensearch-warm.yml"]).To(ContainSubstring("node:\n  attr:\n    temp: warm\n"))
+			Expect(configMap.Data["opensearch.yml"]).ToNot(ContainSubstring("temp"))
+		})
+	})
+
+	Context("When mapping the node roles", func() {
+		It("should use exactly one cluster manager role for the version", func() {
+			roles := []opsterv1.NodeRole{"master", "cluster_manager", "data"}
+			Expect(helpers.MapClusterRoles(roles, "2.3.0")).To(Equal([]string{"cluster_manager", "data"}))
+			Expect(helpers.MapClusterRoles(roles, "1.3.0")).To(Equal([]string{"master", "data"}))
+			Expect(helpers.MapClusterRoles([]opsterv1.NodeRole{"master"}, "2.0.0")).To(Equal([]string{"cluster_manager"}))
+		})
+	})
 })
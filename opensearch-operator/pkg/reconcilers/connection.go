@@ -0,0 +1,179 @@
+package reconcilers
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/portforward"
+	"k8s.io/client-go/transport/spdy"
+	opsterv1 "opensearch.opster.io/api/v1"
+	"opensearch.opster.io/pkg/builders"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// The operator connects to the cluster service, requires the operator to run inside the kubernetes cluster
+	ConnectionModeService = "service"
+	// The operator connects to a pod of the cluster through a port-forward, used for running the operator locally during development
+	ConnectionModePortForward = "port-forward"
+)
+
+// ConnectionStrategy determines how the operator reaches the http interface of a cluster
+type ConnectionStrategy interface {
+	// URL returns the address of the http interface, it is called whenever a client for the cluster is requested
+	URL(ctx context.Context, k8sClient client.Client, cluster *opsterv1.OpenSearchCluster) (string, error)
+	// Close releases everything held for the cluster once it is deleted
+	Close(cluster *opsterv1.OpenSearchCluster)
+}
+
+var connectionStrategy ConnectionStrategy = &ServiceConnection{}
+
+// SetConnectionStrategy changes how all reconcilers connect to the clusters, must be called before the manager is started
+func SetConnectionStrategy(strategy ConnectionStrategy) {
+	connectionStrategy = strategy
+}
+
+// NewConnectionStrategy creates the strategy for the connection mode
+func NewConnectionStrategy(mode string, config *rest.Config) (ConnectionStrategy, error) {
+	switch mode {
+	case ConnectionModeService:
+		return &ServiceConnection{}, nil
+	case ConnectionModePortForward:
+		return NewPortForwardConnection(config)
+	default:
+		return nil, fmt.Errorf("unknown connection mode %s, must be one of %s, %s", mode, ConnectionModeService, ConnectionModePortForward)
+	}
+}
+
+// ServiceConnection uses the DNS name of the cluster service
+type ServiceConnection struct{}
+
+func (c *ServiceConnection) URL(_ context.Context, _ client.Client, cluster *opsterv1.OpenSearchCluster) (string, error) {
+	return builders.URLForCluster(cluster), nil
+}
+
+func (c *ServiceConnection) Close(_ *opsterv1.OpenSearchCluster) {}
+
+// PortForwardConnection forwards a local port to a ready pod of the cluster via the kubernetes API server.
+// The forward is kept open between reconciles and re-created once the pod goes away.
+type PortForwardConnection struct {
+	config    *rest.Config
+	clientset kubernetes.Interface
+	mu        sync.Mutex
+	forwards  map[types.NamespacedName]*podPortForward
+}
+
+type podPortForward struct {
+	url  string
+	stop chan struct{}
+	// Closed once the forward stopped, e.g. because the pod was deleted
+	done chan struct{}
+}
+
+func NewPortForwardConnection(config *rest.Config) (*PortForwardConnection, error) {
+	clientset, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	return &PortForwardConnection{
+		config:    config,
+		clientset: clientset,
+		forwards:  map[types.NamespacedName]*podPortForward{},
+	}, nil
+}
+
+func (c *PortForwardConnection) URL(ctx context.Context, k8sClient client.Client, cluster *opsterv1.OpenSearchCluster) (string, error) {
+	key := client.ObjectKeyFromObject(cluster)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if forward, ok := c.forwards[key]; ok {
+		select {
+		case <-forward.done:
+			delete(c.forwards, key)
+		default:
+			return forward.url, nil
+		}
+	}
+
+	podName, err := readyPodName(ctx, k8sClient, cluster)
+	if err != nil {
+		return "", err
+	}
+	forward, err := c.forward(cluster.Namespace, podName, builders.PortForCluster(cluster))
+	if err != nil {
+		return "", err
+	}
+	c.forwards[key] = forward
+	return forward.url, nil
+}
+
+func (c *PortForwardConnection) Close(cluster *opsterv1.OpenSearchCluster) {
+	key := client.ObjectKeyFromObject(cluster)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if forward, ok := c.forwards[key]; ok {
+		close(forward.stop)
+		delete(c.forwards, key)
+	}
+}
+
+func (c *PortForwardConnection) forward(namespace string, podName string, port int32) (*podPortForward, error) {
+	roundTripper, upgrader, err := spdy.RoundTripperFor(c.config)
+	if err != nil {
+		return nil, err
+	}
+	url := c.clientset.CoreV1().RESTClient().Post().Resource("pods").Namespace(namespace).Name(podName).SubResource("portforward").URL()
+	dialer := spdy.NewDialer(upgrader, &http.Client{Transport: roundTripper}, http.MethodPost, url)
+
+	forward := &podPortForward{stop: make(chan struct{}), done: make(chan struct{})}
+	ready := make(chan struct{})
+	forwarder, err := portforward.NewOnAddresses(dialer, []string{"127.0.0.1"}, []string{fmt.Sprintf("0:%d", port)}, forward.stop, ready, io.Discard, io.Discard)
+	if err != nil {
+		return nil, err
+	}
+	errs := make(chan error, 1)
+	go func() {
+		defer close(forward.done)
+		errs <- forwarder.ForwardPorts()
+	}()
+
+	select {
+	case <-ready:
+	case err := <-errs:
+		return nil, fmt.Errorf("failed to forward port of pod %s: %w", podName, err)
+	}
+	ports, err := forwarder.GetPorts()
+	if err != nil {
+		close(forward.stop)
+		return nil, err
+	}
+	forward.url = fmt.Sprintf("https://127.0.0.1:%d", ports[0].Local)
+	return forward, nil
+}
+
+// readyPodName returns the name of a running pod of the cluster that is ready to receive requests
+func readyPodName(ctx context.Context, k8sClient client.Client, cluster *opsterv1.OpenSearchCluster) (string, error) {
+	pods := corev1.PodList{}
+	if err := k8sClient.List(ctx, &pods, client.InNamespace(cluster.Namespace), client.MatchingLabels{builders.ClusterLabel: cluster.Name}); err != nil {
+		return "", err
+	}
+	for _, pod := range pods.Items {
+		if pod.DeletionTimestamp != nil || pod.Status.Phase != corev1.PodRunning {
+			continue
+		}
+		for _, condition := range pod.Status.Conditions {
+			if condition.Type == corev1.PodReady && condition.Status == corev1.ConditionTrue {
+				return pod.Name, nil
+			}
+		}
+	}
+	return "", fmt.Errorf("no ready pod found for cluster %s", cluster.Name)
+}
@@ -0,0 +1,53 @@
+package reconcilers
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	opsterv1 "opensearch.opster.io/api/v1"
+	"opensearch.opster.io/pkg/builders"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Connection strategies", func() {
+	const clusterName = "connection"
+
+	cluster := &opsterv1.OpenSearchCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: clusterName, Namespace: clusterName},
+		Spec:       opsterv1.ClusterSpec{General: opsterv1.GeneralConfig{ServiceName: clusterName, HttpPort: 9200}},
+	}
+
+	It("should connect to the cluster service by default", func() {
+		url, err := connectionStrategy.URL(context.Background(), k8sClient, cluster)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(url).To(Equal("https://connection.connection.svc.cluster.local:9200"))
+	})
+
+	It("should reject unknown connection modes", func() {
+		_, err := NewConnectionStrategy("nodeport", nil)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should only forward to ready pods", func() {
+		Expect(CreateNamespace(k8sClient, clusterName)).Should(Succeed())
+		_, err := readyPodName(context.Background(), k8sClient, cluster)
+		Expect(err).To(HaveOccurred())
+
+		pod := corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "connection-masters-0", Namespace: clusterName, Labels: map[string]string{builders.ClusterLabel: clusterName}},
+			Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "opensearch", Image: "opensearch"}}},
+		}
+		Expect(k8sClient.Create(context.Background(), &pod)).To(Succeed())
+		pod.Status.Phase = corev1.PodRunning
+		pod.Status.Conditions = []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}}
+		Expect(k8sClient.Status().Update(context.Background(), &pod)).To(Succeed())
+
+		Eventually(func() string {
+			name, _ := readyPodName(context.Background(), k8sClient, cluster)
+			return name
+		}).Should(Equal("connection-masters-0"))
+	})
+})
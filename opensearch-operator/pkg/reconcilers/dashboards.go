@@ -3,6 +3,7 @@ package reconcilers
 import (
 	"context"
 	"fmt"
+	"strconv"
 
 	"github.com/banzaicloud/operator-tools/pkg/reconciler"
 	"github.com/go-logr/logr"
@@ -56,10 +57,13 @@ func (r *DashboardsReconciler) Reconcile() (ctrl.Result, error) {
 	}
 	result := reconciler.CombinedResult{}
 
-	volumes, volumeMounts, err := r.handleTls()
+	volumes, volumeMounts, annotations, err := r.handleTls()
 	if err != nil {
-		return ctrl.Result{}, err
+		return certificateResult(err, r.logger)
 	}
+	caVolumes, caVolumeMounts := r.handleOpensearchCa()
+	volumes = append(volumes, caVolumes...)
+	volumeMounts = append(volumeMounts, caVolumeMounts...)
 
 	// add any aditional dashboard config to the reconciler context
 	for key, value := range r.instance.Spec.Dashboards.AdditionalConfig {
@@ -70,7 +74,7 @@ func (r *DashboardsReconciler) Reconcile() (ctrl.Result, error) {
 	result.CombineErr(ctrl.SetControllerReference(r.instance, cm, r.Client.Scheme()))
 	result.Combine(r.ReconcileResource(cm, reconciler.StatePresent))
 
-	deployment := builders.NewDashboardsDeploymentForCR(r.instance, volumes, volumeMounts)
+	deployment := builders.NewDashboardsDeploymentForCR(r.instance, volumes, volumeMounts, annotations)
 	result.CombineErr(ctrl.SetControllerReference(r.instance, deployment, r.Client.Scheme()))
 	result.Combine(r.ReconcileResource(deployment, reconciler.StatePresent))
 
@@ -81,9 +85,9 @@ func (r *DashboardsReconciler) Reconcile() (ctrl.Result, error) {
 	return result.Result, result.Err
 }
 
-func (r *DashboardsReconciler) handleTls() ([]corev1.Volume, []corev1.VolumeMount, error) {
+func (r *DashboardsReconciler) handleTls() ([]corev1.Volume, []corev1.VolumeMount, map[string]string, error) {
 	if r.instance.Spec.Dashboards.Tls == nil || !r.instance.Spec.Dashboards.Tls.Enable {
-		return nil, nil, nil
+		return nil, nil, nil, nil
 	}
 	clusterName := r.instance.Name
 	namespace := r.instance.Namespace
@@ -91,9 +95,26 @@ func (r *DashboardsReconciler) handleTls() ([]corev1.Volume, []corev1.VolumeMoun
 	tlsConfig := r.instance.Spec.Dashboards.Tls
 	var volumes []corev1.Volume
 	var volumeMounts []corev1.VolumeMount
+	var annotations map[string]string
 
-	if tlsConfig.Generate {
+	if tlsConfig.CertificateConfig.CertManager != nil {
+		r.logger.Info("Issuing certificates with cert-manager")
+		certificate := builders.NewCertificate(r.instance, clusterName+"-dashboards", tlsSecretName, "dashboards", clusterName+"-dashboards", r.dnsNames(), tlsConfig.CertificateConfig)
+		issued, err := reconcileCertManagerCertificate(r.ctx, r.Client, r.ResourceReconciler, r.instance, certificate)
+		if err != nil {
+			return volumes, volumeMounts, annotations, err
+		}
+		// Dashboards only reads the certificate on startup, restart the pods when it was re-issued
+		if issued.renewals > 0 {
+			annotations = map[string]string{CertificateRenewalAnnotation: strconv.FormatInt(issued.renewals, 10)}
+		}
+		volume := corev1.Volume{Name: "tls-cert", VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{SecretName: tlsSecretName}}}
+		volumes = append(volumes, volume)
+		mount := corev1.VolumeMount{Name: "tls-cert", MountPath: "/usr/share/opensearch-dashboards/certs"}
+		volumeMounts = append(volumeMounts, mount)
+	} else if tlsConfig.Generate {
 		r.logger.Info("Generating certificates")
+		checkRenewalWindow(r.recorder, r.instance, "dashboards", tlsConfig.CertificateConfig)
 		// Take CA from TLS reconciler or generate new one
 		var ca tls.Cert
 		var err error
@@ -103,33 +124,42 @@ func (r *DashboardsReconciler) handleTls() ([]corev1.Volume, []corev1.VolumeMoun
 			ca, err = helpers.ReadOrGenerateCaCert(r.pki, r.Client, r.ctx, r.instance)
 		}
 		if err != nil {
-			return volumes, volumeMounts, err
+			return volumes, volumeMounts, annotations, err
 		}
 
 		// Generate cert and create secret
 		tlsSecret := corev1.Secret{}
-		if err := r.Get(r.ctx, client.ObjectKey{Name: tlsSecretName, Namespace: namespace}, &tlsSecret); err != nil {
+		exists := r.Get(r.ctx, client.ObjectKey{Name: tlsSecretName, Namespace: namespace}, &tlsSecret) == nil
+		if !exists || certificateNeedsRenewal(tlsSecret.Data[corev1.TLSCertKey], ca, tlsConfig.CertificateConfig) {
 			// Generate tls cert and put it into secret
-			dnsNames := []string{
-				fmt.Sprintf("%s-dashboards", clusterName),
-				fmt.Sprintf("%s-dashboards.%s", clusterName, namespace),
-				fmt.Sprintf("%s-dashboards.%s.svc", clusterName, namespace),
-				fmt.Sprintf("%s-dashboards.%s.svc.cluster.local", clusterName, namespace),
-			}
-			nodeCert, err := ca.CreateAndSignCertificate(clusterName+"-dashboards", clusterName, dnsNames)
+			nodeCert, err := createAndSignCertificate(ca, tlsConfig.CertificateConfig, clusterName+"-dashboards", clusterName, r.dnsNames())
 			if err != nil {
 				r.logger.Error(err, "Failed to create tls certificate")
-				return volumes, volumeMounts, err
-			}
-			tlsSecret = corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: tlsSecretName, Namespace: namespace}, Data: nodeCert.SecretData(ca)}
-			if err := ctrl.SetControllerReference(r.instance, &tlsSecret, r.Client.Scheme()); err != nil {
-				return nil, nil, err
+				return volumes, volumeMounts, annotations, err
 			}
-			if err := r.Create(r.ctx, &tlsSecret); err != nil {
-				r.logger.Error(err, "Failed to store tls certificate in secret")
-				return volumes, volumeMounts, err
+			if exists {
+				r.logger.Info("Renewing tls certificate")
+				tlsSecret.Data = nodeCert.SecretData(ca)
+				setRenewalAnnotation(&tlsSecret)
+				if err := r.Update(r.ctx, &tlsSecret); err != nil {
+					r.logger.Error(err, "Failed to store tls certificate in secret")
+					return volumes, volumeMounts, annotations, err
+				}
+			} else {
+				tlsSecret = corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: tlsSecretName, Namespace: namespace}, Data: nodeCert.SecretData(ca)}
+				if err := ctrl.SetControllerReference(r.instance, &tlsSecret, r.Client.Scheme()); err != nil {
+					return nil, nil, nil, err
+				}
+				if err := r.Create(r.ctx, &tlsSecret); err != nil {
+					r.logger.Error(err, "Failed to store tls certificate in secret")
+					return volumes, volumeMounts, annotations, err
+				}
 			}
 		}
+		// Dashboards only reads the certificate on startup, restart the pods when it was re-issued
+		if renewal, ok := tlsSecret.Annotations[CertificateRenewalAnnotation]; ok {
+			annotations = map[string]string{CertificateRenewalAnnotation: renewal}
+		}
 		// Mount secret
 		volume := corev1.Volume{Name: "tls-cert", VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{SecretName: tlsSecretName}}}
 		volumes = append(volumes, volume)
@@ -146,7 +176,36 @@ func (r *DashboardsReconciler) handleTls() ([]corev1.Volume, []corev1.VolumeMoun
 	r.reconcilerContext.AddDashboardsConfig("server.ssl.enabled", "true")
 	r.reconcilerContext.AddDashboardsConfig("server.ssl.key", "/usr/share/opensearch-dashboards/certs/tls.key")
 	r.reconcilerContext.AddDashboardsConfig("server.ssl.certificate", "/usr/share/opensearch-dashboards/certs/tls.crt")
-	return volumes, volumeMounts, nil
+	return volumes, volumeMounts, annotations, nil
+}
+
+// handleOpensearchCa configures dashboards to verify the http certificates of the opensearch nodes.
+// Verification is only disabled if the nodes use the demo certificates.
+func (r *DashboardsReconciler) handleOpensearchCa() ([]corev1.Volume, []corev1.VolumeMount) {
+	caSecretName := helpers.HttpCaSecretName(r.instance)
+	if caSecretName == "" {
+		r.reconcilerContext.AddDashboardsConfig("opensearch.ssl.verificationMode", "none")
+		return nil, nil
+	}
+	volume := corev1.Volume{Name: "opensearch-ca", VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{
+		SecretName: caSecretName,
+		Items:      []corev1.KeyToPath{{Key: CaCertKey, Path: CaCertKey}},
+	}}}
+	mount := corev1.VolumeMount{Name: "opensearch-ca", MountPath: "/usr/share/opensearch-dashboards/opensearch-ca"}
+	r.reconcilerContext.AddDashboardsConfig("opensearch.ssl.verificationMode", "full")
+	r.reconcilerContext.AddDashboardsConfig("opensearch.ssl.certificateAuthorities", fmt.Sprintf("[\"/usr/share/opensearch-dashboards/opensearch-ca/%s\"]", CaCertKey))
+	return []corev1.Volume{volume}, []corev1.VolumeMount{mount}
+}
+
+func (r *DashboardsReconciler) dnsNames() []string {
+	clusterName := r.instance.Name
+	namespace := r.instance.Namespace
+	return []string{
+		fmt.Sprintf("%s-dashboards", clusterName),
+		fmt.Sprintf("%s-dashboards.%s", clusterName, namespace),
+		fmt.Sprintf("%s-dashboards.%s.svc", clusterName, namespace),
+		fmt.Sprintf("%s-dashboards.%s.svc.cluster.local", clusterName, namespace),
+	}
 }
 
 func (r *DashboardsReconciler) providedCaCert(secretName string, namespace string) (tls.Cert, error) {
@@ -204,6 +204,44 @@ var _ = Describe("Dashboards Reconciler", func() {
 		})
 	})
 
+	Context("When running the dashboards reconciler for a cluster with http certificates", func() {
+		It("should verify the certificates of the cluster", func() {
+			clusterName := "dashboards-verify"
+			spec := opsterv1.OpenSearchCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: clusterName, Namespace: clusterName, UID: "dummyuid"},
+				Spec: opsterv1.ClusterSpec{
+					General: opsterv1.GeneralConfig{ServiceName: clusterName},
+					Security: &opsterv1.Security{Tls: &opsterv1.TlsConfig{
+						Http: &opsterv1.TlsConfigHttp{CertificateConfig: opsterv1.TlsCertificateConfig{
+							Secret:   corev1.LocalObjectReference{Name: "http-cert"},
+							CaSecret: corev1.LocalObjectReference{Name: "http-ca"},
+						}},
+					}},
+					Dashboards: opsterv1.DashboardsConfig{
+						Enable: true,
+					},
+				}}
+			Expect(CreateNamespace(k8sClient, clusterName)).Should(Succeed())
+
+			_, underTest := newDashboardsReconciler(&spec)
+			_, err := underTest.Reconcile()
+			Expect(err).ToNot(HaveOccurred())
+
+			deployment := appsv1.Deployment{}
+			Eventually(func() bool {
+				err := k8sClient.Get(context.Background(), client.ObjectKey{Name: clusterName + "-dashboards", Namespace: clusterName}, &deployment)
+				return err == nil
+			}, timeout, interval).Should(BeTrue())
+			Expect(helpers.CheckVolumeExists(deployment.Spec.Template.Spec.Volumes, deployment.Spec.Template.Spec.Containers[0].VolumeMounts, "http-ca", "opensearch-ca")).Should((BeTrue()))
+
+			configMap := corev1.ConfigMap{}
+			Expect(k8sClient.Get(context.Background(), client.ObjectKey{Name: clusterName + "-dashboards-config", Namespace: clusterName}, &configMap)).To(Succeed())
+			data := configMap.Data["opensearch_dashboards.yml"]
+			Expect(data).To(ContainSubstring("opensearch.ssl.verificationMode: full\n"))
+			Expect(data).To(ContainSubstring("opensearch.ssl.certificateAuthorities: [\"/usr/share/opensearch-dashboards/opensearch-ca/ca.crt\"]\n"))
+		})
+	})
+
 })
 
 func hasEnvWithSecretSource(env []corev1.EnvVar, name string, secretName string, secretKey string) bool {
@@ -0,0 +1,101 @@
+package reconcilers
+
+import (
+	"context"
+
+	"github.com/banzaicloud/operator-tools/pkg/reconciler"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	opsterv1 "opensearch.opster.io/api/v1"
+	"opensearch.opster.io/pkg/builders"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// reconcileDisruptionBudgets keeps a pod disruption budget for every node pool and removes the budgets of deleted node
+// pools. Data node pools only allow a disruption while the cluster is green. Rolling restarts and upgrades of the
+// operator check the budget with podDisruptionAllowed before they delete a pod.
+func (r *ClusterReconciler) reconcileDisruptionBudgets() error {
+	result := reconciler.CombinedResult{}
+	green := r.clusterGreen()
+	components := map[string]bool{}
+	for _, nodePool := range r.instance.Spec.NodePools {
+		components[nodePool.Component] = true
+		pdb := builders.NewPDBForNodePool(r.instance, &nodePool, green)
+		result.CombineErr(ctrl.SetControllerReference(r.instance, pdb, r.Client.Scheme()))
+		result.Combine(r.ReconcileResource(pdb, reconciler.StatePresent))
+	}
+
+	pdbs := policyv1.PodDisruptionBudgetList{}
+	if err := r.List(r.ctx, &pdbs, client.InNamespace(r.instance.Namespace), client.MatchingLabels{builders.ClusterLabel: r.instance.Name}); err != nil {
+		return err
+	}
+	for i, pdb := range pdbs.Items {
+		if components[pdb.Labels[builders.NodePoolLabel]] {
+			continue
+		}
+		result.Combine(r.ReconcileResource(&pdbs.Items[i], reconciler.StateAbsent))
+	}
+	return result.Err
+}
+
+// clusterGreen checks the health of the cluster, a cluster that is not initialized or cannot be reached is not green
+func (r *ClusterReconciler) clusterGreen() bool {
+	if !r.instance.Status.Initialized {
+		return false
+	}
+	lg := log.FromContext(r.ctx)
+	osClient, err := newOsClientForCluster(r.ctx, r.Client, r.instance)
+	if err != nil {
+		lg.Error(err, "failed to create os client")
+		return false
+	}
+	health, err := osClient.GetClusterHealth(r.ctx)
+	if err != nil {
+		lg.Error(err, "failed to get cluster health")
+		return false
+	}
+	return health.Status == "green"
+}
+
+// podDisruptionAllowed checks if the pod disruption budget of the node pool of the statefulset allows deleting the pod.
+// Pods that are not ready do not count as available for the budget, so they can always be deleted.
+func podDisruptionAllowed(ctx context.Context, k8sClient client.Client, cr *opsterv1.OpenSearchCluster, sts *appsv1.StatefulSet, podName string) (bool, error) {
+	pod := corev1.Pod{}
+	if err := k8sClient.Get(ctx, types.NamespacedName{Name: podName, Namespace: sts.Namespace}, &pod); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return true, nil
+		}
+		return false, err
+	}
+	if !isPodReady(pod) {
+		return true, nil
+	}
+
+	pdb := policyv1.PodDisruptionBudget{}
+	nodePool := opsterv1.NodePool{Component: sts.Labels[builders.NodePoolLabel]}
+	if err := k8sClient.Get(ctx, types.NamespacedName{Name: builders.PDBName(cr, &nodePool), Namespace: sts.Namespace}, &pdb); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return true, nil
+		}
+		return false, err
+	}
+	// The status is outdated until the disruption controller observed the latest spec
+	if pdb.Status.ObservedGeneration < pdb.Generation {
+		return false, nil
+	}
+	return pdb.Status.DisruptionsAllowed > 0, nil
+}
+
+func isPodReady(pod corev1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
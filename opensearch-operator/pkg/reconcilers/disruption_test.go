@@ -0,0 +1,113 @@
+package reconcilers
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	opsterv1 "opensearch.opster.io/api/v1"
+	"opensearch.opster.io/pkg/builders"
+	"opensearch.opster.io/pkg/helpers"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Pod disruption budgets", func() {
+
+	const (
+		clusterName = "disruption-budgets"
+	)
+
+	Context("When reconciling the node pools", func() {
+		It("should derive the budgets from the roles", func() {
+			spec := opsterv1.OpenSearchCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: clusterName, Namespace: clusterName},
+				Spec: opsterv1.ClusterSpec{
+					General: opsterv1.GeneralConfig{ServiceName: clusterName},
+					NodePools: []opsterv1.NodePool{
+						{Component: "masters", Replicas: 3, Roles: []opsterv1.NodeRole{"cluster_manager"}},
+						{Component: "data", Replicas: 3, Roles: []opsterv1.NodeRole{"data"}},
+						{Component: "ingest", Replicas: 2, Roles: []opsterv1.NodeRole{"ingest"}},
+						{Component: "single", Replicas: 1, Roles: []opsterv1.NodeRole{"master"}},
+						{Component: "managers", Replicas: 5, Roles: []opsterv1.NodeRole{"cluster_manager"}},
+						{Component: "pair", Replicas: 2, Roles: []opsterv1.NodeRole{"cluster_manager"}},
+						{Component: "data-single", Replicas: 1, Roles: []opsterv1.NodeRole{"data"}},
+					},
+				},
+			}
+
+			Expect(builders.NewPDBForNodePool(&spec, &spec.Spec.NodePools[0], false).Spec.MaxUnavailable.IntValue()).To(Equal(1))
+			Expect(builders.NewPDBForNodePool(&spec, &spec.Spec.NodePools[1], false).Spec.MaxUnavailable.IntValue()).To(Equal(0))
+			Expect(builders.NewPDBForNodePool(&spec, &spec.Spec.NodePools[1], true).Spec.MaxUnavailable.IntValue()).To(Equal(1))
+			Expect(builders.NewPDBForNodePool(&spec, &spec.Spec.NodePools[2], false).Spec.MaxUnavailable.IntValue()).To(Equal(1))
+			// Budgets that could never be satisfied would block every drain
+			Expect(builders.NewPDBForNodePool(&spec, &spec.Spec.NodePools[3], false).Spec.MaxUnavailable.IntValue()).To(Equal(1))
+			Expect(builders.NewPDBForNodePool(&spec, &spec.Spec.NodePools[4], false).Spec.MaxUnavailable.IntValue()).To(Equal(2))
+			Expect(builders.NewPDBForNodePool(&spec, &spec.Spec.NodePools[5], false).Spec.MaxUnavailable.IntValue()).To(Equal(1))
+			Expect(builders.NewPDBForNodePool(&spec, &spec.Spec.NodePools[6], false).Spec.MaxUnavailable.IntValue()).To(Equal(1))
+		})
+
+		It("should create a budget per node pool and remove the budgets of removed node pools", func() {
+			Expect(CreateNamespace(k8sClient, clusterName)).Should(Succeed())
+			spec := opsterv1.OpenSearchCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: clusterName, Namespace: clusterName, UID: "dummyuid"},
+				Spec: opsterv1.ClusterSpec{
+					General: opsterv1.GeneralConfig{ServiceName: clusterName},
+					NodePools: []opsterv1.NodePool{
+						{Component: "masters", Replicas: 3, Roles: []opsterv1.NodeRole{"cluster_manager", "data"}},
+					},
+				},
+			}
+			stale := builders.NewPDBForNodePool(&spec, &opsterv1.NodePool{Component: "removed", Replicas: 3}, false)
+			Expect(k8sClient.Create(context.Background(), stale)).Should(Succeed())
+
+			reconcilerContext := NewReconcilerContext(spec.Spec.NodePools)
+			underTest := NewClusterReconciler(
+				k8sClient,
+				context.Background(),
+				&helpers.MockEventRecorder{},
+				&reconcilerContext,
+				&spec,
+			)
+			Expect(underTest.reconcileDisruptionBudgets()).To(Succeed())
+
+			pdb := policyv1.PodDisruptionBudget{}
+			Expect(k8sClient.Get(context.Background(), types.NamespacedName{Name: clusterName + "-masters-pdb", Namespace: clusterName}, &pdb)).Should(Succeed())
+			// The cluster is not initialized, so the data role does not allow disruptions
+			Expect(pdb.Spec.MaxUnavailable.IntValue()).To(Equal(0))
+			Expect(pdb.Spec.Selector.MatchLabels).To(HaveKeyWithValue(builders.NodePoolLabel, "masters"))
+
+			err := k8sClient.Get(context.Background(), types.NamespacedName{Name: stale.Name, Namespace: clusterName}, &policyv1.PodDisruptionBudget{})
+			Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+
+			By("checking the budget before restarting a pod")
+			sts := builders.NewSTSForNodePool("admin", &spec, spec.Spec.NodePools[0], "", nil, nil)
+			pod := corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: clusterName + "-masters-0", Namespace: clusterName},
+				Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "opensearch", Image: "opensearch"}}},
+			}
+			Expect(k8sClient.Create(context.Background(), &pod)).Should(Succeed())
+			// Pods that are not ready do not reduce the availability
+			allowed, err := podDisruptionAllowed(context.Background(), k8sClient, &spec, sts, pod.Name)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(allowed).To(BeTrue())
+
+			pod.Status.Conditions = []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}}
+			Expect(k8sClient.Status().Update(context.Background(), &pod)).Should(Succeed())
+			// The budget does not allow disruptions until the disruption controller observed it
+			allowed, err = podDisruptionAllowed(context.Background(), k8sClient, &spec, sts, pod.Name)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(allowed).To(BeFalse())
+
+			pdb.Status = policyv1.PodDisruptionBudgetStatus{ObservedGeneration: pdb.Generation, DisruptionsAllowed: 1}
+			Expect(k8sClient.Status().Update(context.Background(), &pdb)).Should(Succeed())
+			allowed, err = podDisruptionAllowed(context.Background(), k8sClient, &spec, sts, pod.Name)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(allowed).To(BeTrue())
+		})
+	})
+})
@@ -0,0 +1,170 @@
+package reconcilers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/banzaicloud/operator-tools/pkg/reconciler"
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/retry"
+	opsterv1 "opensearch.opster.io/api/v1"
+	"opensearch.opster.io/opensearch-gateway/requests"
+	"opensearch.opster.io/opensearch-gateway/services"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	// Templates are checked for drift in this interval
+	templateResyncInterval = time.Minute
+	templateWaitInterval   = 30 * time.Second
+)
+
+type IndexTemplateReconciler struct {
+	client.Client
+	reconciler.ResourceReconciler
+	ctx      context.Context
+	osClient *services.OsClusterClient
+	recorder record.EventRecorder
+	instance *opsterv1.OpenSearchIndexTemplate
+	logger   logr.Logger
+}
+
+func NewIndexTemplateReconciler(
+	client client.Client,
+	ctx context.Context,
+	recorder record.EventRecorder,
+	instance *opsterv1.OpenSearchIndexTemplate,
+	opts ...reconciler.ResourceReconcilerOption,
+) *IndexTemplateReconciler {
+	return &IndexTemplateReconciler{
+		Client: client,
+		ResourceReconciler: reconciler.NewReconcilerWith(client,
+			append(opts, reconciler.WithLog(log.FromContext(ctx).WithValues("reconciler", "indextemplate")))...),
+		ctx:      ctx,
+		recorder: recorder,
+		instance: instance,
+		logger:   log.FromContext(ctx).WithValues("reconciler", "indextemplate"),
+	}
+}
+
+func (r *IndexTemplateReconciler) Reconcile() (ctrl.Result, error) {
+	cluster, err := fetchReadyCluster(r.ctx, r.Client, r.instance.Namespace, r.instance.Spec.OpensearchRef)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if cluster == nil {
+		return ctrl.Result{Requeue: true, RequeueAfter: templateWaitInterval}, r.updateStatus(opsterv1.TemplatePhasePending, "waiting for opensearch cluster to be initialized", r.instance.Status.ExistingName)
+	}
+	r.osClient, err = newOsClientForCluster(r.ctx, r.Client, cluster)
+	if err != nil {
+		r.logger.Error(err, "failed to create os client")
+		return ctrl.Result{Requeue: true, RequeueAfter: templateWaitInterval}, nil
+	}
+
+	template, err := r.buildIndexTemplate()
+	if err != nil {
+		r.recorder.Eventf(r.instance, "Warning", "invalid template", "failed to parse template: %s", err)
+		// No requeue, a change to the template will trigger a new reconcile
+		return ctrl.Result{}, r.updateStatus(opsterv1.TemplatePhaseError, err.Error(), r.instance.Status.ExistingName)
+	}
+
+	name := r.templateName()
+	// The template was renamed, remove the old one from the cluster
+	if existing := r.instance.Status.ExistingName; existing != "" && existing != name {
+		if err := r.osClient.DeleteIndexTemplate(r.ctx, existing); err != nil {
+			return ctrl.Result{}, err
+		}
+		r.recorder.Eventf(r.instance, "Normal", "template deleted", "deleted renamed index template %s", existing)
+	}
+
+	changed, err := services.EnsureIndexTemplate(r.ctx, r.osClient, name, template)
+	if err != nil {
+		r.recorder.Eventf(r.instance, "Warning", "template failed", "failed to apply index template %s: %s", name, err)
+		if statusErr := r.updateStatus(opsterv1.TemplatePhaseError, err.Error(), name); statusErr != nil {
+			return ctrl.Result{}, statusErr
+		}
+		return ctrl.Result{}, err
+	}
+	if changed {
+		r.recorder.Eventf(r.instance, "Normal", "template applied", "applied index template %s", name)
+	}
+	return ctrl.Result{Requeue: true, RequeueAfter: templateResyncInterval}, r.updateStatus(opsterv1.TemplatePhaseApplied, "", name)
+}
+
+// Delete removes the template from the cluster, if the cluster no longer exists there is nothing to do
+func (r *IndexTemplateReconciler) Delete() error {
+	name := r.instance.Status.ExistingName
+	if name == "" {
+		return nil
+	}
+	return deleteFromReadyCluster(r.ctx, r.Client, r.recorder, r.instance, r.instance.Spec.OpensearchRef, func(osClient *services.OsClusterClient) error {
+		return osClient.DeleteIndexTemplate(r.ctx, name)
+	})
+}
+
+func (r *IndexTemplateReconciler) templateName() string {
+	if r.instance.Spec.Name != "" {
+		return r.instance.Spec.Name
+	}
+	return r.instance.Name
+}
+
+func (r *IndexTemplateReconciler) buildIndexTemplate() (requests.IndexTemplate, error) {
+	template, err := buildTemplate(r.instance.Spec.Template)
+	if err != nil {
+		return requests.IndexTemplate{}, err
+	}
+	return requests.IndexTemplate{
+		IndexPatterns: r.instance.Spec.IndexPatterns,
+		ComposedOf:    r.instance.Spec.ComposedOf,
+		Priority:      r.instance.Spec.Priority,
+		Version:       r.instance.Spec.Version,
+		Template:      template,
+	}, nil
+}
+
+func (r *IndexTemplateReconciler) updateStatus(phase string, reason string, existingName string) error {
+	if r.instance.Status.Phase == phase && r.instance.Status.Reason == reason && r.instance.Status.ExistingName == existingName {
+		return nil
+	}
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if err := r.Get(r.ctx, client.ObjectKeyFromObject(r.instance), r.instance); err != nil {
+			return err
+		}
+		r.instance.Status.Phase = phase
+		r.instance.Status.Reason = reason
+		r.instance.Status.ExistingName = existingName
+		return r.Status().Update(r.ctx, r.instance)
+	})
+}
+
+// buildTemplate converts the free form parts of a template into the request format
+func buildTemplate(template opsterv1.OpensearchTemplate) (*requests.Template, error) {
+	result := &requests.Template{}
+	var err error
+	if result.Settings, err = rawToMap(template.Settings); err != nil {
+		return nil, fmt.Errorf("settings: %w", err)
+	}
+	if result.Mappings, err = rawToMap(template.Mappings); err != nil {
+		return nil, fmt.Errorf("mappings: %w", err)
+	}
+	if result.Aliases, err = rawToMap(template.Aliases); err != nil {
+		return nil, fmt.Errorf("aliases: %w", err)
+	}
+	return result, nil
+}
+
+func rawToMap(raw *runtime.RawExtension) (map[string]interface{}, error) {
+	if raw == nil || len(raw.Raw) == 0 {
+		return nil, nil
+	}
+	result := map[string]interface{}{}
+	err := json.Unmarshal(raw.Raw, &result)
+	return result, err
+}
@@ -0,0 +1,105 @@
+package reconcilers
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	opsterv1 "opensearch.opster.io/api/v1"
+	"opensearch.opster.io/pkg/helpers"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("IndexTemplate Reconciler", func() {
+
+	const (
+		templateName = "indextemplate-test"
+	)
+
+	Context("When reconciling a template for a missing cluster", func() {
+		It("should set the template to pending", func() {
+			Expect(CreateNamespace(k8sClient, templateName)).Should(Succeed())
+
+			template := opsterv1.OpenSearchIndexTemplate{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      templateName,
+					Namespace: templateName,
+				},
+				Spec: opsterv1.OpenSearchIndexTemplateSpec{
+					OpensearchRef: corev1.LocalObjectReference{Name: "missing"},
+					IndexPatterns: []string{"logs-*"},
+				},
+			}
+			Expect(k8sClient.Create(context.Background(), &template)).Should(Succeed())
+
+			underTest := NewIndexTemplateReconciler(
+				k8sClient,
+				context.Background(),
+				&helpers.MockEventRecorder{},
+				&template,
+			)
+			result, err := underTest.Reconcile()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.Requeue).To(BeTrue())
+
+			updated := opsterv1.OpenSearchIndexTemplate{}
+			Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(&template), &updated)).Should(Succeed())
+			Expect(updated.Status.Phase).To(Equal(opsterv1.TemplatePhasePending))
+		})
+	})
+
+	Context("When deleting a template from a cluster that can not be reached", func() {
+		It("should only remove it with the skip annotation", func() {
+			Expect(CreateNamespace(k8sClient, templateName+"-delete")).Should(Succeed())
+			Expect(CreateUnreachableCluster(k8sClient, "unreachable", templateName+"-delete")).Should(Succeed())
+
+			template := opsterv1.OpenSearchIndexTemplate{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      templateName,
+					Namespace: templateName + "-delete",
+				},
+				Spec: opsterv1.OpenSearchIndexTemplateSpec{
+					OpensearchRef: corev1.LocalObjectReference{Name: "unreachable"},
+					IndexPatterns: []string{"logs-*"},
+				},
+				Status: opsterv1.OpenSearchTemplateStatus{
+					ExistingName: templateName,
+				},
+			}
+			underTest := NewIndexTemplateReconciler(
+				k8sClient,
+				context.Background(),
+				&helpers.MockEventRecorder{},
+				&template,
+			)
+			Expect(underTest.Delete()).To(HaveOccurred())
+
+			template.Annotations = map[string]string{SkipClusterDeletionAnnotation: "true"}
+			Expect(underTest.Delete()).To(Succeed())
+		})
+	})
+
+	Context("When building a template", func() {
+		It("should convert the raw parts", func() {
+			template, err := buildTemplate(opsterv1.OpensearchTemplate{
+				Settings: &runtime.RawExtension{Raw: []byte(`{"number_of_shards": 2}`)},
+				Mappings: &runtime.RawExtension{Raw: []byte(`{"properties": {"message": {"type": "text"}}}`)},
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(template.Settings).To(HaveKeyWithValue("number_of_shards", BeNumerically("==", 2)))
+			Expect(template.Mappings).To(HaveKey("properties"))
+			Expect(template.Aliases).To(BeNil())
+		})
+
+		It("should fail for invalid json", func() {
+			_, err := buildTemplate(opsterv1.OpensearchTemplate{
+				Settings: &runtime.RawExtension{Raw: []byte(`[1, 2]`)},
+			})
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
@@ -0,0 +1,254 @@
+package reconcilers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/banzaicloud/operator-tools/pkg/reconciler"
+	"github.com/go-logr/logr"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/retry"
+	opsterv1 "opensearch.opster.io/api/v1"
+	"opensearch.opster.io/opensearch-gateway/requests"
+	"opensearch.opster.io/opensearch-gateway/responses"
+	"opensearch.opster.io/opensearch-gateway/services"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	// Policies are checked for drift and new indices in this interval
+	ismPolicyResyncInterval = time.Minute
+	ismPolicyWaitInterval   = 30 * time.Second
+)
+
+type ISMPolicyReconciler struct {
+	client.Client
+	reconciler.ResourceReconciler
+	ctx      context.Context
+	osClient *services.OsClusterClient
+	recorder record.EventRecorder
+	instance *opsterv1.OpenSearchISMPolicy
+	logger   logr.Logger
+}
+
+func NewISMPolicyReconciler(
+	client client.Client,
+	ctx context.Context,
+	recorder record.EventRecorder,
+	instance *opsterv1.OpenSearchISMPolicy,
+	opts ...reconciler.ResourceReconcilerOption,
+) *ISMPolicyReconciler {
+	return &ISMPolicyReconciler{
+		Client: client,
+		ResourceReconciler: reconciler.NewReconcilerWith(client,
+			append(opts, reconciler.WithLog(log.FromContext(ctx).WithValues("reconciler", "ismpolicy")))...),
+		ctx:      ctx,
+		recorder: recorder,
+		instance: instance,
+		logger:   log.FromContext(ctx).WithValues("reconciler", "ismpolicy"),
+	}
+}
+
+func (r *ISMPolicyReconciler) Reconcile() (ctrl.Result, error) {
+	cluster, err := fetchReadyCluster(r.ctx, r.Client, r.instance.Namespace, r.instance.Spec.OpensearchRef)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if cluster == nil {
+		return ctrl.Result{Requeue: true, RequeueAfter: ismPolicyWaitInterval}, r.updateStatus(func(status *opsterv1.OpenSearchISMPolicyStatus) {
+			status.Phase = opsterv1.ISMPolicyPhasePending
+			status.Reason = "waiting for opensearch cluster to be initialized"
+		})
+	}
+	r.osClient, err = newOsClientForCluster(r.ctx, r.Client, cluster)
+	if err != nil {
+		r.logger.Error(err, "failed to create os client")
+		return ctrl.Result{Requeue: true, RequeueAfter: ismPolicyWaitInterval}, nil
+	}
+
+	policy, err := buildISMPolicy(r.instance.Spec)
+	if err != nil {
+		r.recorder.Eventf(r.instance, "Warning", "invalid policy", "failed to parse policy: %s", err)
+		// No requeue, a change to the policy will trigger a new reconcile
+		return ctrl.Result{}, r.updateStatus(func(status *opsterv1.OpenSearchISMPolicyStatus) {
+			status.Phase = opsterv1.ISMPolicyPhaseError
+			status.Reason = err.Error()
+		})
+	}
+
+	policyID := r.policyID()
+	// The policy was renamed, remove the old one from the cluster
+	if existing := r.instance.Status.PolicyID; existing != "" && existing != policyID {
+		if err := r.deletePolicy(existing); err != nil {
+			return ctrl.Result{}, err
+		}
+		r.recorder.Eventf(r.instance, "Normal", "policy deleted", "deleted renamed ism policy %s", existing)
+	}
+
+	status := r.instance.Status.DeepCopy()
+	applied, err := r.applyPolicy(policyID, policy, status)
+	if err != nil {
+		r.recorder.Eventf(r.instance, "Warning", "policy failed", "failed to apply ism policy %s: %s", policyID, err)
+		if statusErr := r.updateStatus(func(s *opsterv1.OpenSearchISMPolicyStatus) {
+			s.Phase = opsterv1.ISMPolicyPhaseError
+			s.Reason = err.Error()
+		}); statusErr != nil {
+			return ctrl.Result{}, statusErr
+		}
+		return ctrl.Result{}, err
+	}
+	status.Phase = opsterv1.ISMPolicyPhaseApplied
+	status.Reason = ""
+	status.PolicyID = policyID
+	status.SeqNo = applied.SeqNo
+	status.PrimaryTerm = applied.PrimaryTerm
+	status.ObservedGeneration = r.instance.Generation
+
+	if len(r.instance.Spec.ApplyToIndices) > 0 {
+		attached, err := services.AttachISMPolicy(r.ctx, r.osClient, r.instance.Spec.ApplyToIndices, policyID)
+		if len(attached) > 0 {
+			r.recorder.Eventf(r.instance, "Normal", "policy attached", "attached ism policy %s to indices %s", policyID, strings.Join(attached, ","))
+		}
+		if err != nil {
+			r.recorder.Eventf(r.instance, "Warning", "attach failed", "failed to attach ism policy %s: %s", policyID, err)
+			status.Reason = fmt.Sprintf("failed to attach policy to indices: %s", err)
+		}
+	}
+
+	return ctrl.Result{Requeue: true, RequeueAfter: ismPolicyResyncInterval}, r.updateStatus(func(s *opsterv1.OpenSearchISMPolicyStatus) {
+		*s = *status
+	})
+}
+
+// applyPolicy creates or updates the policy in the cluster if the resource changed or the policy was
+// changed outside of the operator, which is detected using the sequence number and primary term of the policy
+func (r *ISMPolicyReconciler) applyPolicy(policyID string, policy requests.ISMPolicy, status *opsterv1.OpenSearchISMPolicyStatus) (responses.ISMPolicyResponse, error) {
+	existing, found, err := r.osClient.GetISMPolicy(r.ctx, policyID)
+	if err != nil {
+		return existing, err
+	}
+	if !found {
+		applied, err := r.osClient.PutISMPolicy(r.ctx, policyID, policy, nil)
+		if err == nil {
+			r.recorder.Eventf(r.instance, "Normal", "policy created", "created ism policy %s", policyID)
+		}
+		return applied, err
+	}
+
+	managed := status.PolicyID == policyID
+	unchanged := managed && status.ObservedGeneration == r.instance.Generation
+	modified := managed && (status.SeqNo != existing.SeqNo || status.PrimaryTerm != existing.PrimaryTerm)
+	if unchanged && !modified {
+		return existing, nil
+	}
+	applied, err := r.osClient.PutISMPolicy(r.ctx, policyID, policy, &existing)
+	if err != nil {
+		return applied, err
+	}
+	if unchanged {
+		r.recorder.Eventf(r.instance, "Normal", "policy drift", "ism policy %s was changed outside of the operator, re-applied it", policyID)
+	} else {
+		r.recorder.Eventf(r.instance, "Normal", "policy updated", "updated ism policy %s", policyID)
+	}
+	return applied, nil
+}
+
+// Delete removes the policy from all indices and deletes it from the cluster.
+// If the cluster no longer exists there is nothing to do.
+func (r *ISMPolicyReconciler) Delete() error {
+	policyID := r.instance.Status.PolicyID
+	if policyID == "" {
+		return nil
+	}
+	return deleteFromReadyCluster(r.ctx, r.Client, r.recorder, r.instance, r.instance.Spec.OpensearchRef, func(osClient *services.OsClusterClient) error {
+		r.osClient = osClient
+		return r.deletePolicy(policyID)
+	})
+}
+
+func (r *ISMPolicyReconciler) deletePolicy(policyID string) error {
+	if err := services.DetachISMPolicy(r.ctx, r.osClient, policyID); err != nil {
+		return err
+	}
+	return r.osClient.DeleteISMPolicy(r.ctx, policyID)
+}
+
+func (r *ISMPolicyReconciler) policyID() string {
+	if r.instance.Spec.PolicyID != "" {
+		return r.instance.Spec.PolicyID
+	}
+	return r.instance.Name
+}
+
+func (r *ISMPolicyReconciler) updateStatus(update func(*opsterv1.OpenSearchISMPolicyStatus)) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if err := r.Get(r.ctx, client.ObjectKeyFromObject(r.instance), r.instance); err != nil {
+			return err
+		}
+		update(&r.instance.Status)
+		return r.Status().Update(r.ctx, r.instance)
+	})
+}
+
+// buildISMPolicy converts the policy of the resource into the request format
+func buildISMPolicy(spec opsterv1.OpenSearchISMPolicySpec) (requests.ISMPolicy, error) {
+	body := requests.ISMPolicyBody{
+		Description:  spec.Description,
+		DefaultState: spec.DefaultState,
+		States:       make([]requests.ISMState, 0, len(spec.States)),
+	}
+	stateNames := map[string]bool{}
+	for _, state := range spec.States {
+		stateNames[state.Name] = true
+	}
+	if !stateNames[spec.DefaultState] {
+		return requests.ISMPolicy{}, fmt.Errorf("default state %s is not defined", spec.DefaultState)
+	}
+
+	for _, state := range spec.States {
+		converted := requests.ISMState{
+			Name:        state.Name,
+			Actions:     make([]map[string]interface{}, 0, len(state.Actions)),
+			Transitions: make([]requests.ISMTransition, 0, len(state.Transitions)),
+		}
+		for i, action := range state.Actions {
+			parsed := map[string]interface{}{}
+			if err := json.Unmarshal(action.Raw, &parsed); err != nil {
+				return requests.ISMPolicy{}, fmt.Errorf("state %s action %d: %w", state.Name, i, err)
+			}
+			converted.Actions = append(converted.Actions, parsed)
+		}
+		for _, transition := range state.Transitions {
+			if !stateNames[transition.StateName] {
+				return requests.ISMPolicy{}, fmt.Errorf("state %s transitions to undefined state %s", state.Name, transition.StateName)
+			}
+			conditions, err := rawToMap(transition.Conditions)
+			if err != nil {
+				return requests.ISMPolicy{}, fmt.Errorf("state %s transition conditions: %w", state.Name, err)
+			}
+			converted.Transitions = append(converted.Transitions, requests.ISMTransition{
+				StateName:  transition.StateName,
+				Conditions: conditions,
+			})
+		}
+		body.States = append(body.States, converted)
+	}
+
+	for _, template := range spec.ISMTemplate {
+		body.ISMTemplate = append(body.ISMTemplate, requests.ISMTemplate{
+			IndexPatterns: template.IndexPatterns,
+			Priority:      template.Priority,
+		})
+	}
+
+	var err error
+	if body.ErrorNotification, err = rawToMap(spec.ErrorNotification); err != nil {
+		return requests.ISMPolicy{}, fmt.Errorf("error notification: %w", err)
+	}
+	return requests.ISMPolicy{Policy: body}, nil
+}
@@ -0,0 +1,135 @@
+package reconcilers
+
+import (
+	"context"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	opsterv1 "opensearch.opster.io/api/v1"
+	"opensearch.opster.io/pkg/helpers"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("ISMPolicy Reconciler", func() {
+
+	const (
+		policyName = "ismpolicy-test"
+	)
+
+	newSpec := func() opsterv1.OpenSearchISMPolicySpec {
+		return opsterv1.OpenSearchISMPolicySpec{
+			OpensearchRef: corev1.LocalObjectReference{Name: "missing"},
+			DefaultState:  "hot",
+			States: []opsterv1.ISMState{
+				{
+					Name: "hot",
+					Actions: []apiextensionsv1.JSON{
+						{Raw: []byte(`{"rollover": {"min_size": "50gb"}}`)},
+					},
+					Transitions: []opsterv1.ISMTransition{
+						{
+							StateName:  "delete",
+							Conditions: &runtime.RawExtension{Raw: []byte(`{"min_index_age": "30d"}`)},
+						},
+					},
+				},
+				{
+					Name: "delete",
+					Actions: []apiextensionsv1.JSON{
+						{Raw: []byte(`{"delete": {}}`)},
+					},
+				},
+			},
+		}
+	}
+
+	Context("When reconciling a policy for a missing cluster", func() {
+		It("should set the policy to pending", func() {
+			Expect(CreateNamespace(k8sClient, policyName)).Should(Succeed())
+
+			policy := opsterv1.OpenSearchISMPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      policyName,
+					Namespace: policyName,
+				},
+				Spec: newSpec(),
+			}
+			Expect(k8sClient.Create(context.Background(), &policy)).Should(Succeed())
+
+			underTest := NewISMPolicyReconciler(
+				k8sClient,
+				context.Background(),
+				&helpers.MockEventRecorder{},
+				&policy,
+			)
+			result, err := underTest.Reconcile()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.Requeue).To(BeTrue())
+
+			updated := opsterv1.OpenSearchISMPolicy{}
+			Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(&policy), &updated)).Should(Succeed())
+			Expect(updated.Status.Phase).To(Equal(opsterv1.ISMPolicyPhasePending))
+		})
+	})
+
+	Context("When deleting a policy from a cluster that can not be reached", func() {
+		It("should only remove it with the skip annotation", func() {
+			Expect(CreateNamespace(k8sClient, policyName+"-delete")).Should(Succeed())
+			Expect(CreateUnreachableCluster(k8sClient, "unreachable", policyName+"-delete")).Should(Succeed())
+
+			spec := newSpec()
+			spec.OpensearchRef = corev1.LocalObjectReference{Name: "unreachable"}
+			policy := opsterv1.OpenSearchISMPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      policyName,
+					Namespace: policyName + "-delete",
+				},
+				Spec: spec,
+				Status: opsterv1.OpenSearchISMPolicyStatus{
+					PolicyID: policyName,
+				},
+			}
+			underTest := NewISMPolicyReconciler(
+				k8sClient,
+				context.Background(),
+				&helpers.MockEventRecorder{},
+				&policy,
+			)
+			Expect(underTest.Delete()).To(HaveOccurred())
+
+			policy.Annotations = map[string]string{SkipClusterDeletionAnnotation: "true"}
+			Expect(underTest.Delete()).To(Succeed())
+		})
+	})
+
+	Context("When building a policy", func() {
+		It("should convert the states", func() {
+			policy, err := buildISMPolicy(newSpec())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(policy.Policy.DefaultState).To(Equal("hot"))
+			Expect(policy.Policy.States).To(HaveLen(2))
+			Expect(policy.Policy.States[0].Actions[0]).To(HaveKey("rollover"))
+			Expect(policy.Policy.States[0].Transitions[0].Conditions).To(HaveKeyWithValue("min_index_age", "30d"))
+			Expect(policy.Policy.States[1].Transitions).To(BeEmpty())
+		})
+
+		It("should reject an undefined default state", func() {
+			spec := newSpec()
+			spec.DefaultState = "warm"
+			_, err := buildISMPolicy(spec)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should reject transitions to undefined states", func() {
+			spec := newSpec()
+			spec.States[0].Transitions[0].StateName = "cold"
+			_, err := buildISMPolicy(spec)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
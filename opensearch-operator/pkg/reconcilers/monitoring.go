@@ -0,0 +1,112 @@
+package reconcilers
+
+import (
+	"context"
+
+	"github.com/banzaicloud/operator-tools/pkg/reconciler"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/client-go/tools/record"
+	opsterv1 "opensearch.opster.io/api/v1"
+	"opensearch.opster.io/pkg/builders"
+	"opensearch.opster.io/pkg/metrics"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+type MonitoringReconciler struct {
+	client.Client
+	reconciler.ResourceReconciler
+	ctx               context.Context
+	recorder          record.EventRecorder
+	reconcilerContext *ReconcilerContext
+	instance          *opsterv1.OpenSearchCluster
+}
+
+func NewMonitoringReconciler(
+	client client.Client,
+	ctx context.Context,
+	recorder record.EventRecorder,
+	reconcilerContext *ReconcilerContext,
+	instance *opsterv1.OpenSearchCluster,
+	opts ...reconciler.ResourceReconcilerOption,
+) *MonitoringReconciler {
+	return &MonitoringReconciler{
+		Client: client,
+		ResourceReconciler: reconciler.NewReconcilerWith(client,
+			append(opts, reconciler.WithLog(log.FromContext(ctx).WithValues("reconciler", "monitoring")))...),
+		ctx:               ctx,
+		recorder:          recorder,
+		reconcilerContext: reconcilerContext,
+		instance:          instance,
+	}
+}
+
+func (r *MonitoringReconciler) Reconcile() (ctrl.Result, error) {
+	result := reconciler.CombinedResult{}
+	result.Combine(r.reconcileServiceMonitor())
+	if result.Err != nil || result.Result.Requeue {
+		return result.Result, result.Err
+	}
+
+	if !r.instance.Spec.ConfMgmt.Monitoring {
+		metrics.DeleteClusterStats(r.instance)
+		return ctrl.Result{}, nil
+	}
+	if !r.instance.Status.Initialized {
+		return ctrl.Result{}, nil
+	}
+	r.collectClusterStats()
+	return ctrl.Result{}, nil
+}
+
+// reconcileServiceMonitor creates a ServiceMonitor for the exporter sidecars if the prometheus-operator CRDs are installed
+func (r *MonitoringReconciler) reconcileServiceMonitor() (*ctrl.Result, error) {
+	lg := log.FromContext(r.ctx)
+	_, err := r.RESTMapper().RESTMapping(builders.ServiceMonitorGVK.GroupKind(), builders.ServiceMonitorGVK.Version)
+	if meta.IsNoMatchError(err) {
+		if r.instance.Spec.ConfMgmt.Monitoring {
+			lg.V(1).Info("ServiceMonitor CRD not installed, skipping ServiceMonitor")
+		}
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	serviceMonitor := builders.NewServiceMonitor(r.instance)
+	if err := ctrl.SetControllerReference(r.instance, serviceMonitor, r.Scheme()); err != nil {
+		return nil, err
+	}
+	if r.instance.Spec.ConfMgmt.Monitoring {
+		return r.ReconcileResource(serviceMonitor, reconciler.StatePresent)
+	}
+	return r.ReconcileResource(serviceMonitor, reconciler.StateAbsent)
+}
+
+// collectClusterStats updates the cluster metrics exposed by the operator.
+// Failing to collect stats must not block the other reconcilers, so errors are only logged.
+func (r *MonitoringReconciler) collectClusterStats() {
+	lg := log.FromContext(r.ctx)
+	osClient, err := newOsClientForCluster(r.ctx, r.Client, r.instance)
+	if err != nil {
+		lg.Error(err, "failed to create os client for cluster stats")
+		return
+	}
+	health, err := osClient.GetClusterHealth(r.ctx)
+	if err != nil {
+		lg.Error(err, "failed to fetch cluster health")
+		return
+	}
+	nodes, err := osClient.NodesStats(r.ctx)
+	if err != nil {
+		lg.Error(err, "failed to fetch nodes stats")
+		return
+	}
+	metrics.UpdateClusterStats(r.instance, health, nodes)
+}
+
+func (r *MonitoringReconciler) DeleteResources() (ctrl.Result, error) {
+	metrics.DeleteClusterMetrics(r.instance)
+	return ctrl.Result{}, nil
+}
@@ -0,0 +1,172 @@
+package reconcilers
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	opsterv1 "opensearch.opster.io/api/v1"
+	"opensearch.opster.io/opensearch-gateway/services"
+	"opensearch.opster.io/pkg/builders"
+	"opensearch.opster.io/pkg/helpers"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// osClients caches the opensearch clients of all clusters so that connections are reused between reconciles
+var osClients = &osClientCache{clients: map[types.NamespacedName]cachedOsClient{}}
+
+type osClientCache struct {
+	mu      sync.Mutex
+	clients map[types.NamespacedName]cachedOsClient
+}
+
+type cachedOsClient struct {
+	client *services.OsClusterClient
+	// Changes when the url, credentials or certificates of the cluster change
+	fingerprint string
+}
+
+// osClientConfig contains everything needed to connect to a cluster
+type osClientConfig struct {
+	url        string
+	username   string
+	password   string
+	caCert     []byte
+	clientCert []byte
+	clientKey  []byte
+}
+
+// newOsClientForCluster returns the cached opensearch client of the cluster, the address of the cluster is determined by the connection strategy.
+// A new client is created if there is none yet or the address, credentials or CA of the cluster changed.
+func newOsClientForCluster(ctx context.Context, k8sClient client.Client, cluster *opsterv1.OpenSearchCluster) (*services.OsClusterClient, error) {
+	url, err := connectionStrategy.URL(ctx, k8sClient, cluster)
+	if err != nil {
+		return nil, err
+	}
+	config, err := loadOsClientConfig(ctx, k8sClient, cluster, url)
+	if err != nil {
+		return nil, err
+	}
+	key := client.ObjectKeyFromObject(cluster)
+	fingerprint := config.fingerprint()
+
+	osClients.mu.Lock()
+	cached, ok := osClients.clients[key]
+	osClients.mu.Unlock()
+	if ok && cached.fingerprint == fingerprint {
+		return cached.client, nil
+	}
+
+	osClient, err := config.newClient(ctx, cluster)
+	if err != nil {
+		return nil, err
+	}
+	osClients.mu.Lock()
+	previous, replaced := osClients.clients[key]
+	osClients.clients[key] = cachedOsClient{client: osClient, fingerprint: fingerprint}
+	osClients.mu.Unlock()
+	// The previous client is no longer used once the credentials or certificates changed, close its connections
+	if replaced && previous.client != osClient {
+		previous.client.Close()
+	}
+	return osClient, nil
+}
+
+// RemoveOsClient removes the cached client and the connection of a cluster once it is deleted
+func RemoveOsClient(cluster *opsterv1.OpenSearchCluster) {
+	osClients.mu.Lock()
+	cached, ok := osClients.clients[client.ObjectKeyFromObject(cluster)]
+	delete(osClients.clients, client.ObjectKeyFromObject(cluster))
+	osClients.mu.Unlock()
+	if ok {
+		cached.client.Close()
+	}
+	connectionStrategy.Close(cluster)
+}
+
+// loadOsClientConfig reads the credentials and certificates of the cluster.
+// The operator authenticates with the admin credentials or, if none are configured, with the admin client certificate.
+func loadOsClientConfig(ctx context.Context, k8sClient client.Client, cluster *opsterv1.OpenSearchCluster, url string) (osClientConfig, error) {
+	config := osClientConfig{url: url}
+	if !helpers.UsesAdminCertificate(cluster) {
+		var err error
+		config.username, config.password, err = helpers.UsernameAndPassword(ctx, k8sClient, cluster)
+		if err != nil {
+			return config, err
+		}
+	}
+
+	if caSecretName := helpers.HttpCaSecretName(cluster); caSecretName != "" {
+		caSecret := corev1.Secret{}
+		if err := k8sClient.Get(ctx, client.ObjectKey{Name: caSecretName, Namespace: cluster.Namespace}, &caSecret); err != nil {
+			return config, err
+		}
+		config.caCert = caSecret.Data[CaCertKey]
+		if len(config.caCert) == 0 {
+			return config, fmt.Errorf("no CA certificate found in secret %s", caSecretName)
+		}
+	}
+
+	if helpers.UsesAdminCertificate(cluster) {
+		adminSecretName := helpers.AdminCertificateSecretName(cluster)
+		adminSecret := corev1.Secret{}
+		if err := k8sClient.Get(ctx, client.ObjectKey{Name: adminSecretName, Namespace: cluster.Namespace}, &adminSecret); err != nil {
+			return config, err
+		}
+		config.clientCert = adminSecret.Data[corev1.TLSCertKey]
+		config.clientKey = adminSecret.Data[corev1.TLSPrivateKeyKey]
+	}
+	return config, nil
+}
+
+func (c osClientConfig) fingerprint() string {
+	hash := sha256.New()
+	for _, value := range [][]byte{[]byte(c.url), []byte(c.username), []byte(c.password), c.caCert, c.clientCert, c.clientKey} {
+		hash.Write(value)
+		// Separate the values so that different splits of the same bytes do not collide
+		hash.Write([]byte{0})
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+func (c osClientConfig) newClient(ctx context.Context, cluster *opsterv1.OpenSearchCluster) (*services.OsClusterClient, error) {
+	tlsConfig, err := c.tlsConfig(cluster)
+	if err != nil {
+		return nil, err
+	}
+	return services.NewOsClusterClient(ctx, c.url, c.username, c.password, tlsConfig)
+}
+
+// tlsConfig verifies the http certificates of the cluster with its CA and adds the admin client certificate if required
+func (c osClientConfig) tlsConfig(cluster *opsterv1.OpenSearchCluster) (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		// The certificates are verified for the cluster service independent of the address used to reach the cluster
+		ServerName: fmt.Sprintf("%s.svc.cluster.local", builders.DnsOfService(cluster)),
+	}
+	if c.caCert != nil {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(c.caCert) {
+			return nil, errors.New("invalid CA certificate for the http interface")
+		}
+		tlsConfig.RootCAs = pool
+	} else {
+		// The demo certificates are not signed by a CA known to the operator
+		tlsConfig.InsecureSkipVerify = true
+	}
+
+	if c.username == "" {
+		certificate, err := tls.X509KeyPair(c.clientCert, c.clientKey)
+		if err != nil {
+			return nil, fmt.Errorf("invalid admin client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{certificate}
+	}
+	return tlsConfig, nil
+}
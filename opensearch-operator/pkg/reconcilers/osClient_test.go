@@ -0,0 +1,164 @@
+package reconcilers
+
+import (
+	"context"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	opsterv1 "opensearch.opster.io/api/v1"
+	"opensearch.opster.io/pkg/builders"
+	"opensearch.opster.io/pkg/helpers"
+	"opensearch.opster.io/pkg/tls"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("OpenSearch client", func() {
+	const (
+		clusterName = "os-client"
+		timeout     = time.Second * 10
+		interval    = time.Second * 1
+	)
+
+	newCluster := func() *opsterv1.OpenSearchCluster {
+		return &opsterv1.OpenSearchCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: clusterName, Namespace: clusterName, UID: "dummyuid"},
+			Spec: opsterv1.ClusterSpec{
+				General: opsterv1.GeneralConfig{ServiceName: "os-client-svc"},
+				Security: &opsterv1.Security{Tls: &opsterv1.TlsConfig{
+					Transport: &opsterv1.TlsConfigTransport{Generate: true},
+					Http:      &opsterv1.TlsConfigHttp{Generate: true},
+				}},
+			},
+		}
+	}
+
+	Context("When creating the TLS configuration", func() {
+		It("should trust the http CA and use the admin certificate", func() {
+			Expect(CreateNamespace(k8sClient, clusterName)).Should(Succeed())
+			ca, err := tls.NewPKI().GenerateCA(tls.CertificateOptions{CommonName: clusterName})
+			Expect(err).ToNot(HaveOccurred())
+			admin, err := createAndSignCertificate(ca, opsterv1.TlsCertificateConfig{}, "admin", clusterName, nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(k8sClient.Create(context.Background(), &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: clusterName + "-http-cert", Namespace: clusterName},
+				Data:       map[string][]byte{CaCertKey: ca.CertData()},
+			})).To(Succeed())
+			Expect(k8sClient.Create(context.Background(), &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: clusterName + "-admin-cert", Namespace: clusterName},
+				Data:       admin.SecretData(ca),
+			})).To(Succeed())
+
+			config, err := loadOsClientConfig(context.Background(), k8sClient, newCluster(), "https://localhost:9200")
+			Expect(err).ToNot(HaveOccurred())
+			tlsConfig, err := config.tlsConfig(newCluster())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(tlsConfig.InsecureSkipVerify).To(BeFalse())
+			Expect(tlsConfig.RootCAs).ToNot(BeNil())
+			Expect(tlsConfig.ServerName).To(Equal("os-client-svc.os-client.svc.cluster.local"))
+			Expect(tlsConfig.Certificates).To(HaveLen(1))
+
+			By("changing the fingerprint when the CA changes")
+			newCa, err := tls.NewPKI().GenerateCA(tls.CertificateOptions{CommonName: clusterName})
+			Expect(err).ToNot(HaveOccurred())
+			caSecret := corev1.Secret{}
+			Expect(k8sClient.Get(context.Background(), client.ObjectKey{Name: clusterName + "-http-cert", Namespace: clusterName}, &caSecret)).To(Succeed())
+			caSecret.Data[CaCertKey] = newCa.CertData()
+			Expect(k8sClient.Update(context.Background(), &caSecret)).To(Succeed())
+			Eventually(func() string {
+				updated, err := loadOsClientConfig(context.Background(), k8sClient, newCluster(), "https://localhost:9200")
+				if err != nil {
+					return ""
+				}
+				return updated.fingerprint()
+			}, timeout, interval).ShouldNot(Or(BeEmpty(), Equal(config.fingerprint())))
+		})
+
+		It("should fall back to the demo credentials without admin credentials or certificate", func() {
+			cluster := newCluster()
+			cluster.Spec.Security.Tls.Transport = nil
+			config, err := loadOsClientConfig(context.Background(), k8sClient, cluster, "https://localhost:9200")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(config.username).To(Equal("admin"))
+			Expect(config.clientCert).To(BeNil())
+		})
+
+		It("should use the admin certificate with a custom securityconfig", func() {
+			cluster := newCluster()
+			cluster.Spec.Security.Config = &opsterv1.SecurityConfig{
+				SecurityconfigSecret: corev1.LocalObjectReference{Name: "securityconfig"},
+			}
+			config, err := loadOsClientConfig(context.Background(), k8sClient, cluster, "https://localhost:9200")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(config.username).To(BeEmpty())
+			Expect(config.clientCert).ToNot(BeNil())
+		})
+
+		It("should require admin credentials with a custom securityconfig and no admin certificate", func() {
+			cluster := newCluster()
+			cluster.Spec.Security.Tls.Transport = nil
+			cluster.Spec.Security.Config = &opsterv1.SecurityConfig{
+				SecurityconfigSecret: corev1.LocalObjectReference{Name: "securityconfig"},
+			}
+			_, err := loadOsClientConfig(context.Background(), k8sClient, cluster, "https://localhost:9200")
+			Expect(err).To(Equal(helpers.ErrAdminCredentialsRequired))
+		})
+
+		It("should not verify the demo certificates", func() {
+			cluster := newCluster()
+			cluster.Spec.Security = nil
+			tlsConfig, err := osClientConfig{username: "admin", password: "admin"}.tlsConfig(cluster)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(tlsConfig.InsecureSkipVerify).To(BeTrue())
+		})
+	})
+
+	Context("When the admin password is not known", func() {
+		It("should authenticate the readiness probe with the admin certificate", func() {
+			cluster := newCluster()
+			cluster.Spec.NodePools = []opsterv1.NodePool{{Component: "nodes", Replicas: 1, Roles: []opsterv1.NodeRole{"master", "data"}}}
+			cluster.Spec.Security.Config = &opsterv1.SecurityConfig{
+				SecurityconfigSecret: corev1.LocalObjectReference{Name: "securityconfig"},
+			}
+			sts := builders.NewSTSForNodePool("", cluster, cluster.Spec.NodePools[0], "", nil, nil)
+			container := sts.Spec.Template.Spec.Containers[0]
+			Expect(container.ReadinessProbe.Exec.Command[2]).To(ContainSubstring("--cert"))
+			Expect(container.ReadinessProbe.Exec.Command[2]).ToNot(ContainSubstring("OPENSEARCH_PASSWORD"))
+			Expect(container.VolumeMounts).To(ContainElement(HaveField("Name", "admin-cert")))
+		})
+
+		It("should report missing admin credentials in the status and requeue", func() {
+			cluster := newCluster()
+			cluster.Name = clusterName + "-credentials"
+			cluster.Spec.NodePools = []opsterv1.NodePool{{Component: "nodes", Replicas: 1, Roles: []opsterv1.NodeRole{"master", "data"}}}
+			cluster.Spec.Security.Tls.Transport = nil
+			cluster.Spec.Security.Config = &opsterv1.SecurityConfig{
+				SecurityconfigSecret: corev1.LocalObjectReference{Name: "securityconfig"},
+			}
+			Expect(k8sClient.Create(context.Background(), cluster)).To(Succeed())
+
+			reconcilerContext := NewReconcilerContext(cluster.Spec.NodePools)
+			underTest := NewClusterReconciler(
+				k8sClient,
+				context.Background(),
+				&helpers.MockEventRecorder{},
+				&reconcilerContext,
+				cluster,
+			)
+			result, err := underTest.Reconcile()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.Requeue).To(BeTrue())
+
+			updated := opsterv1.OpenSearchCluster{}
+			Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(cluster), &updated)).To(Succeed())
+			Expect(updated.Status.ComponentsStatus).To(ContainElement(opsterv1.ComponentStatus{
+				Component:   securityConfigComponent,
+				Status:      securityConfigInvalid,
+				Description: helpers.ErrAdminCredentialsRequired.Error(),
+			}))
+		})
+	})
+})
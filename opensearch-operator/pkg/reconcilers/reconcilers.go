@@ -5,12 +5,19 @@ import (
 	"fmt"
 
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/retry"
 	opsterv1 "opensearch.opster.io/api/v1"
+	"opensearch.opster.io/opensearch-gateway/services"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
+// SkipClusterDeletionAnnotation removes a resource that is managed inside a cluster without deleting it from the
+// cluster, e.g. when the operator can no longer connect to the cluster
+const SkipClusterDeletionAnnotation = "opster.io/skip-cluster-deletion"
+
 type ComponentReconciler func() (reconcile.Result, error)
 
 type ReconcilerContext struct {
@@ -19,6 +26,8 @@ type ReconcilerContext struct {
 	NodePoolHashes   []NodePoolHash
 	DashboardsConfig map[string]string
 	OpenSearchConfig map[string]string
+	// Changes when certificates mounted into the nodes are re-issued, used to trigger a restart
+	CertificateRevision string
 }
 
 type NodePoolHash struct {
@@ -56,6 +65,11 @@ func (c *ReconcilerContext) AddDashboardsConfig(key string, value string) {
 	c.DashboardsConfig[key] = value
 }
 
+// AddCertificateRevision records that the certificate with the given name was re-issued at the given revision
+func (c *ReconcilerContext) AddCertificateRevision(name string, revision string) {
+	c.CertificateRevision = fmt.Sprintf("%s%s=%s\n", c.CertificateRevision, name, revision)
+}
+
 // fetchNodePoolHash gets the hash of the config for a specific node pool
 func (c *ReconcilerContext) fetchNodePoolHash(name string) (bool, NodePoolHash) {
 	for _, config := range c.NodePoolHashes {
@@ -106,3 +120,52 @@ func UpdateOpensearchStatus(
 	}
 	return nil
 }
+
+// fetchReadyCluster returns the cluster referenced by a resource in the same namespace.
+// Returns nil if the cluster does not exist, is being deleted or is not yet initialized.
+func fetchReadyCluster(
+	ctx context.Context,
+	k8sClient client.Client,
+	namespace string,
+	ref corev1.LocalObjectReference,
+) (*opsterv1.OpenSearchCluster, error) {
+	cluster := &opsterv1.OpenSearchCluster{}
+	if err := k8sClient.Get(ctx, client.ObjectKey{Name: ref.Name, Namespace: namespace}, cluster); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if !cluster.ObjectMeta.DeletionTimestamp.IsZero() || !cluster.Status.Initialized {
+		return nil, nil
+	}
+	return cluster, nil
+}
+
+// deleteFromReadyCluster deletes a resource from the referenced cluster before its finalizer is removed. There is
+// nothing to delete if the cluster does not exist, is being deleted or the resource has the skip annotation.
+// Failures are reported as warning event, the deletion is retried until it succeeds or is skipped.
+func deleteFromReadyCluster(
+	ctx context.Context,
+	k8sClient client.Client,
+	recorder record.EventRecorder,
+	object client.Object,
+	ref corev1.LocalObjectReference,
+	deleteFunc func(osClient *services.OsClusterClient) error,
+) error {
+	if object.GetAnnotations()[SkipClusterDeletionAnnotation] == "true" {
+		return nil
+	}
+	cluster, err := fetchReadyCluster(ctx, k8sClient, object.GetNamespace(), ref)
+	if err != nil || cluster == nil {
+		return err
+	}
+	osClient, err := newOsClientForCluster(ctx, k8sClient, cluster)
+	if err == nil {
+		err = deleteFunc(osClient)
+	}
+	if err != nil {
+		recorder.Eventf(object, "Warning", "delete failed", "failed to delete from cluster %s, annotate with %s=true to skip: %s", cluster.Name, SkipClusterDeletionAnnotation, err)
+	}
+	return err
+}
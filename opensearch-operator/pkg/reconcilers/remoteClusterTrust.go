@@ -0,0 +1,162 @@
+package reconcilers
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	opsterv1 "opensearch.opster.io/api/v1"
+	"opensearch.opster.io/pkg/helpers"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// handleRemoteClusterTrust makes the nodes trust the transport CAs and node certificates of all clusters that are linked
+// to the cluster by an OpenSearchReplication or as remote clusters, in both directions. Returns the path of the CAs trusted by the transport
+// interface and the DNs of the remote nodes.
+func (r *TLSReconciler) handleRemoteClusterTrust() (string, []string, error) {
+	ownCas := fmt.Sprintf("tls-transport/%s", CaCertKey)
+	remotes, err := linkedClusters(r.ctx, r.Client, r.instance)
+	if err != nil {
+		return "", nil, err
+	}
+
+	var remoteCas [][]byte
+	var remoteNodesDn []string
+	for _, remote := range remotes {
+		caSecretName := helpers.TransportCaSecretName(&remote)
+		if caSecretName == "" {
+			r.logger.Info("Remote cluster uses the demo certificates, can not trust it", "cluster", remote.Name, "namespace", remote.Namespace)
+			continue
+		}
+		caSecret := corev1.Secret{}
+		if err := r.Get(r.ctx, client.ObjectKey{Name: caSecretName, Namespace: remote.Namespace}, &caSecret); err != nil {
+			if k8serrors.IsNotFound(err) {
+				r.logger.Info("Transport CA of remote cluster does not exist yet", "cluster", remote.Name, "namespace", remote.Namespace)
+				continue
+			}
+			return "", nil, err
+		}
+		if ca := bytes.TrimSpace(caSecret.Data[CaCertKey]); len(ca) > 0 {
+			remoteCas = append(remoteCas, ca)
+			remoteNodesDn = append(remoteNodesDn, transportNodesDn(&remote)...)
+		}
+	}
+	if len(remoteCas) == 0 {
+		return ownCas, nil, nil
+	}
+
+	ownSecret := corev1.Secret{}
+	if err := r.Get(r.ctx, client.ObjectKey{Name: helpers.TransportCaSecretName(r.instance), Namespace: r.instance.Namespace}, &ownSecret); err != nil {
+		return "", nil, err
+	}
+	bundle := append([][]byte{bytes.TrimSpace(ownSecret.Data[CaCertKey])}, remoteCas...)
+	if err := r.storeTrustSecret(append(bytes.Join(bundle, []byte("\n")), '\n')); err != nil {
+		return "", nil, err
+	}
+
+	volume := corev1.Volume{Name: "transport-trust", VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{SecretName: r.trustSecretName()}}}
+	r.reconcilerContext.Volumes = append(r.reconcilerContext.Volumes, volume)
+	mount := corev1.VolumeMount{Name: "transport-trust", MountPath: "/usr/share/opensearch/config/tls-transport-trust"}
+	r.reconcilerContext.VolumeMounts = append(r.reconcilerContext.VolumeMounts, mount)
+	// The nodes only read the trusted CAs on startup, restart them when a remote CA changes
+	hash := sha256.Sum256(bytes.Join(remoteCas, nil))
+	r.reconcilerContext.AddCertificateRevision("remote-trust", hex.EncodeToString(hash[:8]))
+	return fmt.Sprintf("tls-transport-trust/%s", CaCertKey), remoteNodesDn, nil
+}
+
+func (r *TLSReconciler) trustSecretName() string {
+	return r.instance.Name + "-transport-trust"
+}
+
+func (r *TLSReconciler) storeTrustSecret(bundle []byte) error {
+	secret := corev1.Secret{}
+	err := r.Get(r.ctx, client.ObjectKey{Name: r.trustSecretName(), Namespace: r.instance.Namespace}, &secret)
+	if err == nil {
+		if bytes.Equal(secret.Data[CaCertKey], bundle) {
+			return nil
+		}
+		secret.Data = map[string][]byte{CaCertKey: bundle}
+		return r.Update(r.ctx, &secret)
+	}
+	if !k8serrors.IsNotFound(err) {
+		return err
+	}
+	secret = corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: r.trustSecretName(), Namespace: r.instance.Namespace},
+		Data:       map[string][]byte{CaCertKey: bundle},
+	}
+	if err := ctrl.SetControllerReference(r.instance, &secret, r.Client.Scheme()); err != nil {
+		return err
+	}
+	return r.Create(r.ctx, &secret)
+}
+
+// linkedClusters returns the clusters that replicate from or to the cluster or that are remote clusters of each other
+func linkedClusters(ctx context.Context, k8sClient client.Client, cluster *opsterv1.OpenSearchCluster) ([]opsterv1.OpenSearchCluster, error) {
+	self := client.ObjectKeyFromObject(cluster)
+	var remotes []types.NamespacedName
+	link := func(a types.NamespacedName, b types.NamespacedName) {
+		switch self {
+		case a:
+			remotes = append(remotes, b)
+		case b:
+			remotes = append(remotes, a)
+		}
+	}
+
+	replications := opsterv1.OpenSearchReplicationList{}
+	if err := k8sClient.List(ctx, &replications); err != nil {
+		return nil, err
+	}
+	for _, replication := range replications.Items {
+		link(types.NamespacedName{Name: replication.Spec.OpensearchRef.Name, Namespace: replication.Namespace}, leaderClusterKey(&replication))
+	}
+	clusters := opsterv1.OpenSearchClusterList{}
+	if err := k8sClient.List(ctx, &clusters); err != nil {
+		return nil, err
+	}
+	for _, other := range clusters.Items {
+		for _, remote := range other.Spec.RemoteClusters {
+			if remote.Cluster != nil {
+				link(client.ObjectKeyFromObject(&other), clusterReferenceKey(other.Namespace, *remote.Cluster))
+			}
+		}
+	}
+
+	seen := map[types.NamespacedName]bool{self: true}
+	var linked []opsterv1.OpenSearchCluster
+	for _, remote := range remotes {
+		if seen[remote] {
+			continue
+		}
+		seen[remote] = true
+		remoteCluster := opsterv1.OpenSearchCluster{}
+		if err := k8sClient.Get(ctx, remote, &remoteCluster); err != nil {
+			if k8serrors.IsNotFound(err) {
+				continue
+			}
+			return nil, err
+		}
+		linked = append(linked, remoteCluster)
+	}
+	return linked, nil
+}
+
+// clusterReferenceKey resolves a reference of a resource in the namespace to another cluster
+func clusterReferenceKey(namespace string, ref opsterv1.ClusterReference) types.NamespacedName {
+	if ref.Namespace != "" {
+		namespace = ref.Namespace
+	}
+	return types.NamespacedName{Name: ref.Name, Namespace: namespace}
+}
+
+func leaderClusterKey(replication *opsterv1.OpenSearchReplication) types.NamespacedName {
+	return clusterReferenceKey(replication.Namespace, replication.Spec.LeaderCluster)
+}
@@ -0,0 +1,160 @@
+package reconcilers
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/banzaicloud/operator-tools/pkg/reconciler"
+	"github.com/go-logr/logr"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/retry"
+	opsterv1 "opensearch.opster.io/api/v1"
+	"opensearch.opster.io/opensearch-gateway/services"
+	"opensearch.opster.io/pkg/builders"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+type RemoteClusterReconciler struct {
+	client.Client
+	reconciler.ResourceReconciler
+	ctx               context.Context
+	osClient          *services.OsClusterClient
+	recorder          record.EventRecorder
+	reconcilerContext *ReconcilerContext
+	instance          *opsterv1.OpenSearchCluster
+	logger            logr.Logger
+}
+
+func NewRemoteClusterReconciler(
+	client client.Client,
+	ctx context.Context,
+	recorder record.EventRecorder,
+	reconcilerContext *ReconcilerContext,
+	instance *opsterv1.OpenSearchCluster,
+	opts ...reconciler.ResourceReconcilerOption,
+) *RemoteClusterReconciler {
+	return &RemoteClusterReconciler{
+		Client: client,
+		ResourceReconciler: reconciler.NewReconcilerWith(client,
+			append(opts, reconciler.WithLog(log.FromContext(ctx).WithValues("reconciler", "remoteclusters")))...),
+		ctx:               ctx,
+		recorder:          recorder,
+		reconcilerContext: reconcilerContext,
+		instance:          instance,
+		logger:            log.FromContext(ctx).WithValues("reconciler", "remoteclusters"),
+	}
+}
+
+// Reconcile keeps the seeds of the remote clusters in the persistent cluster settings in sync with the resource.
+// Remote clusters removed from the resource are removed from the cluster, remote clusters configured outside of the
+// operator are not changed.
+func (r *RemoteClusterReconciler) Reconcile() (ctrl.Result, error) {
+	if len(r.instance.Spec.RemoteClusters) == 0 && len(r.instance.Status.RemoteClusters) == 0 {
+		return ctrl.Result{}, nil
+	}
+	if !r.instance.Status.Initialized {
+		return ctrl.Result{Requeue: true, RequeueAfter: 30 * time.Second}, nil
+	}
+	var err error
+	r.osClient, err = newOsClientForCluster(r.ctx, r.Client, r.instance)
+	if err != nil {
+		r.logger.Error(err, "failed to create os client")
+		return ctrl.Result{Requeue: true, RequeueAfter: 30 * time.Second}, nil
+	}
+
+	desired, err := r.desiredSeeds()
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	current, err := r.osClient.GetRemoteClusters(r.ctx)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	changed := map[string][]string{}
+	for alias, seeds := range desired {
+		if !sameSeeds(current[alias].Seeds, seeds) {
+			changed[alias] = seeds
+		}
+	}
+	for _, remote := range r.instance.Status.RemoteClusters {
+		if _, ok := desired[remote.Alias]; !ok {
+			changed[remote.Alias] = nil
+		}
+	}
+	if len(changed) > 0 {
+		if err := services.SetRemoteClusterSeeds(r.ctx, r.osClient, changed); err != nil {
+			r.recorder.Eventf(r.instance, "Warning", "remote clusters", "failed to update remote clusters: %s", err)
+			return ctrl.Result{}, err
+		}
+		aliases := make([]string, 0, len(changed))
+		for alias := range changed {
+			aliases = append(aliases, alias)
+		}
+		sort.Strings(aliases)
+		r.recorder.Eventf(r.instance, "Normal", "remote clusters", "updated remote clusters %s", strings.Join(aliases, ","))
+	}
+
+	var status []opsterv1.RemoteClusterStatus
+	for _, remote := range r.instance.Spec.RemoteClusters {
+		seeds, ok := desired[remote.Alias]
+		if !ok {
+			continue
+		}
+		status = append(status, opsterv1.RemoteClusterStatus{
+			Alias:     remote.Alias,
+			Seeds:     seeds,
+			Connected: len(changed[remote.Alias]) == 0 && current[remote.Alias].Connected,
+		})
+	}
+	if reflect.DeepEqual(status, r.instance.Status.RemoteClusters) {
+		return ctrl.Result{}, nil
+	}
+	return ctrl.Result{}, retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if err := r.Get(r.ctx, client.ObjectKeyFromObject(r.instance), r.instance); err != nil {
+			return err
+		}
+		r.instance.Status.RemoteClusters = status
+		return r.Status().Update(r.ctx, r.instance)
+	})
+}
+
+// desiredSeeds returns the seeds of all valid remote clusters by their alias.
+// Remote clusters referencing a cluster that does not exist are skipped.
+func (r *RemoteClusterReconciler) desiredSeeds() (map[string][]string, error) {
+	desired := map[string][]string{}
+	for _, remote := range r.instance.Spec.RemoteClusters {
+		if (remote.Cluster == nil) == (len(remote.Seeds) == 0) {
+			r.recorder.Eventf(r.instance, "Warning", "remote clusters", "remote cluster %s must either reference a cluster or list seeds", remote.Alias)
+			continue
+		}
+		if remote.Cluster == nil {
+			desired[remote.Alias] = remote.Seeds
+			continue
+		}
+		cluster := opsterv1.OpenSearchCluster{}
+		if err := r.Get(r.ctx, clusterReferenceKey(r.instance.Namespace, *remote.Cluster), &cluster); err != nil {
+			if k8serrors.IsNotFound(err) {
+				r.recorder.Eventf(r.instance, "Warning", "remote clusters", "cluster %s of remote cluster %s does not exist", remote.Cluster.Name, remote.Alias)
+				continue
+			}
+			return nil, err
+		}
+		desired[remote.Alias] = builders.TransportSeeds(&cluster)
+	}
+	return desired, nil
+}
+
+func sameSeeds(a []string, b []string) bool {
+	a = append([]string{}, a...)
+	b = append([]string{}, b...)
+	sort.Strings(a)
+	sort.Strings(b)
+	return reflect.DeepEqual(a, b)
+}
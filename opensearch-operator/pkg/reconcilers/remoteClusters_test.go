@@ -0,0 +1,60 @@
+package reconcilers
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	opsterv1 "opensearch.opster.io/api/v1"
+	"opensearch.opster.io/pkg/helpers"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Remote Cluster Reconciler", func() {
+
+	Context("When resolving the seeds of the remote clusters", func() {
+		It("should use the seeds or the discovery service of the referenced cluster", func() {
+			clusterName := "remote-clusters"
+			remoteName := "remote-clusters-eu"
+			Expect(CreateNamespace(k8sClient, clusterName)).Should(Succeed())
+			Expect(CreateNamespace(k8sClient, remoteName)).Should(Succeed())
+
+			remote := opsterv1.OpenSearchCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: remoteName, Namespace: remoteName},
+				Spec: opsterv1.ClusterSpec{
+					General:   opsterv1.GeneralConfig{ServiceName: remoteName},
+					NodePools: []opsterv1.NodePool{{Component: "masters", Replicas: 3, Roles: []opsterv1.NodeRole{"master", "data"}}},
+				},
+			}
+			Expect(k8sClient.Create(context.Background(), &remote)).Should(Succeed())
+
+			spec := opsterv1.OpenSearchCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: clusterName, Namespace: clusterName, UID: "dummyuid"},
+				Spec: opsterv1.ClusterSpec{
+					General: opsterv1.GeneralConfig{ServiceName: clusterName},
+					RemoteClusters: []opsterv1.RemoteCluster{
+						{Alias: "eu", Cluster: &opsterv1.ClusterReference{Name: remoteName, Namespace: remoteName}},
+						{Alias: "us", Seeds: []string{"opensearch.us.example.com:9300"}},
+						{Alias: "missing", Cluster: &opsterv1.ClusterReference{Name: "missing"}},
+						{Alias: "invalid"},
+					},
+				},
+			}
+			reconcilerContext := NewReconcilerContext(spec.Spec.NodePools)
+			underTest := NewRemoteClusterReconciler(
+				k8sClient,
+				context.Background(),
+				&helpers.MockEventRecorder{},
+				&reconcilerContext,
+				&spec,
+			)
+
+			seeds, err := underTest.desiredSeeds()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(seeds).To(HaveLen(2))
+			Expect(seeds).To(HaveKeyWithValue("eu", []string{"remote-clusters-eu-discovery.remote-clusters-eu.svc.cluster.local:9300"}))
+			Expect(seeds).To(HaveKeyWithValue("us", []string{"opensearch.us.example.com:9300"}))
+		})
+	})
+})
@@ -0,0 +1,325 @@
+package reconcilers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/banzaicloud/operator-tools/pkg/reconciler"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/retry"
+	opsterv1 "opensearch.opster.io/api/v1"
+	"opensearch.opster.io/opensearch-gateway/requests"
+	"opensearch.opster.io/opensearch-gateway/responses"
+	"opensearch.opster.io/opensearch-gateway/services"
+	"opensearch.opster.io/pkg/builders"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	// The status of the replication is refreshed in this interval
+	replicationResyncInterval = time.Minute
+	replicationWaitInterval   = 30 * time.Second
+	defaultReplicationRole    = "all_access"
+)
+
+type ReplicationReconciler struct {
+	client.Client
+	reconciler.ResourceReconciler
+	ctx      context.Context
+	osClient *services.OsClusterClient
+	recorder record.EventRecorder
+	instance *opsterv1.OpenSearchReplication
+	logger   logr.Logger
+}
+
+func NewReplicationReconciler(
+	client client.Client,
+	ctx context.Context,
+	recorder record.EventRecorder,
+	instance *opsterv1.OpenSearchReplication,
+	opts ...reconciler.ResourceReconcilerOption,
+) *ReplicationReconciler {
+	return &ReplicationReconciler{
+		Client: client,
+		ResourceReconciler: reconciler.NewReconcilerWith(client,
+			append(opts, reconciler.WithLog(log.FromContext(ctx).WithValues("reconciler", "replication")))...),
+		ctx:      ctx,
+		recorder: recorder,
+		instance: instance,
+		logger:   log.FromContext(ctx).WithValues("reconciler", "replication"),
+	}
+}
+
+// Reconcile connects the follower cluster to the leader cluster and manages the auto-follow rules in the follower cluster.
+// The trust between the clusters is configured by the TLS reconcilers of both clusters.
+func (r *ReplicationReconciler) Reconcile() (ctrl.Result, error) {
+	cluster, err := fetchReadyCluster(r.ctx, r.Client, r.instance.Namespace, r.instance.Spec.OpensearchRef)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	leaderKey := leaderClusterKey(r.instance)
+	leader, err := fetchReadyCluster(r.ctx, r.Client, leaderKey.Namespace, corev1.LocalObjectReference{Name: leaderKey.Name})
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if cluster == nil || leader == nil {
+		return ctrl.Result{Requeue: true, RequeueAfter: replicationWaitInterval}, r.updateStatus(func(status *opsterv1.OpenSearchReplicationStatus) {
+			status.Phase = opsterv1.ReplicationPhasePending
+			status.Reason = "waiting for leader and follower clusters to be initialized"
+		})
+	}
+	r.osClient, err = newOsClientForCluster(r.ctx, r.Client, cluster)
+	if err != nil {
+		r.logger.Error(err, "failed to create os client")
+		return ctrl.Result{Requeue: true, RequeueAfter: replicationWaitInterval}, nil
+	}
+
+	alias := r.connectionAlias()
+	// The alias was changed, remove the replication using the old one
+	if existing := r.instance.Status.ConnectionAlias; existing != "" && existing != alias {
+		if err := r.removeReplication(existing, r.instance.Status.Rules); err != nil {
+			return ctrl.Result{}, err
+		}
+		if err := r.updateStatus(func(status *opsterv1.OpenSearchReplicationStatus) {
+			status.ConnectionAlias = ""
+			status.Rules = nil
+			status.Indices = nil
+		}); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	if err := services.SetRemoteClusterSeeds(r.ctx, r.osClient, map[string][]string{alias: builders.TransportSeeds(leader)}); err != nil {
+		return r.failed(fmt.Errorf("failed to configure leader cluster: %w", err))
+	}
+	status := r.instance.Status.DeepCopy()
+	status.ConnectionAlias = alias
+	remotes, err := r.osClient.GetRemoteClusters(r.ctx)
+	if err != nil {
+		return r.failed(err)
+	}
+	status.Connected = remotes[alias].Connected
+	if !status.Connected {
+		// The nodes of both clusters are restarted to trust each other before they can connect
+		status.Phase = opsterv1.ReplicationPhasePending
+		status.Reason = "waiting for the follower cluster to connect to the leader cluster"
+		return ctrl.Result{Requeue: true, RequeueAfter: replicationWaitInterval}, r.updateStatus(func(s *opsterv1.OpenSearchReplicationStatus) {
+			*s = *status
+		})
+	}
+
+	if err := r.reconcileRules(alias, status); err != nil {
+		return r.failed(err)
+	}
+	if err := r.updateIndicesStatus(alias, status); err != nil {
+		return r.failed(err)
+	}
+	status.Phase = opsterv1.ReplicationPhaseRunning
+	status.Reason = replicationProblems(status)
+	return ctrl.Result{Requeue: true, RequeueAfter: replicationResyncInterval}, r.updateStatus(func(s *opsterv1.OpenSearchReplicationStatus) {
+		*s = *status
+	})
+}
+
+// reconcileRules creates missing auto-follow rules and stops the rules that were removed from the resource
+func (r *ReplicationReconciler) reconcileRules(alias string, status *opsterv1.OpenSearchReplicationStatus) error {
+	stats, err := r.osClient.GetAutoFollowStats(r.ctx)
+	if err != nil {
+		return err
+	}
+	existing := make(map[string]responses.AutoFollowRuleStats, len(stats.AutoFollowStats))
+	for _, rule := range stats.AutoFollowStats {
+		existing[rule.Name] = rule
+	}
+
+	wanted := map[string]bool{}
+	rules := make([]opsterv1.ReplicationRuleStatus, 0, len(r.instance.Spec.Rules))
+	for _, rule := range r.instance.Spec.Rules {
+		wanted[rule.Name] = true
+		current, found := existing[rule.Name]
+		if found && current.Pattern != rule.Pattern {
+			// The pattern of a rule can not be updated, indices already replicated by the rule keep being replicated
+			if err := r.osClient.DeleteAutoFollowRule(r.ctx, alias, rule.Name); err != nil {
+				return err
+			}
+			found = false
+		}
+		if !found {
+			if err := r.osClient.CreateAutoFollowRule(r.ctx, requests.AutoFollowRule{
+				LeaderAlias: alias,
+				Name:        rule.Name,
+				Pattern:     rule.Pattern,
+				UseRoles:    r.roles(),
+			}); err != nil {
+				r.recorder.Eventf(r.instance, "Warning", "rule failed", "failed to create replication rule %s: %s", rule.Name, err)
+				return err
+			}
+			r.recorder.Eventf(r.instance, "Normal", "rule created", "started replicating indices matching %s", rule.Pattern)
+			current = responses.AutoFollowRuleStats{Name: rule.Name, Pattern: rule.Pattern}
+		}
+		rules = append(rules, opsterv1.ReplicationRuleStatus{
+			Name:              rule.Name,
+			Pattern:           rule.Pattern,
+			ReplicatedIndices: current.NumSuccessStartReplication,
+			FailedIndices:     current.FailedIndices,
+		})
+	}
+
+	var removed []opsterv1.ReplicationRuleStatus
+	for _, rule := range status.Rules {
+		if !wanted[rule.Name] {
+			removed = append(removed, rule)
+		}
+	}
+	if err := r.stopRules(alias, removed); err != nil {
+		return err
+	}
+	status.Rules = rules
+	return nil
+}
+
+func (r *ReplicationReconciler) updateIndicesStatus(alias string, status *opsterv1.OpenSearchReplicationStatus) error {
+	replicated, err := services.ReplicatedIndices(r.ctx, r.osClient, rulePatterns(status.Rules))
+	if err != nil {
+		return err
+	}
+	status.Indices = nil
+	for _, index := range replicated {
+		if index.LeaderAlias != alias {
+			continue
+		}
+		status.Indices = append(status.Indices, opsterv1.ReplicationIndexStatus{
+			Index:       index.FollowerIndex,
+			LeaderIndex: index.LeaderIndex,
+			Status:      index.Status,
+			Reason:      index.Reason,
+		})
+	}
+	return nil
+}
+
+// stopRules deletes the auto-follow rules and stops the replication of the indices replicated by them.
+// The follower indices are kept as regular indices.
+func (r *ReplicationReconciler) stopRules(alias string, rules []opsterv1.ReplicationRuleStatus) error {
+	if len(rules) == 0 {
+		return nil
+	}
+	for _, rule := range rules {
+		if err := r.osClient.DeleteAutoFollowRule(r.ctx, alias, rule.Name); err != nil {
+			return err
+		}
+		r.recorder.Eventf(r.instance, "Normal", "rule deleted", "stopped replicating indices matching %s", rule.Pattern)
+	}
+	replicated, err := services.ReplicatedIndices(r.ctx, r.osClient, rulePatterns(rules))
+	if err != nil {
+		return err
+	}
+	for _, index := range replicated {
+		if index.LeaderAlias != alias {
+			continue
+		}
+		if err := r.osClient.StopReplication(r.ctx, index.FollowerIndex); err != nil {
+			return err
+		}
+		r.recorder.Eventf(r.instance, "Normal", "replication stopped", "stopped replication of index %s", index.FollowerIndex)
+	}
+	return nil
+}
+
+// removeReplication stops all rules and removes the leader cluster from the remote clusters of the follower cluster
+func (r *ReplicationReconciler) removeReplication(alias string, rules []opsterv1.ReplicationRuleStatus) error {
+	if err := r.stopRules(alias, rules); err != nil {
+		return err
+	}
+	return services.SetRemoteClusterSeeds(r.ctx, r.osClient, map[string][]string{alias: nil})
+}
+
+// Delete stops the replication, the follower indices are kept as regular indices.
+// If the follower cluster no longer exists there is nothing to do.
+func (r *ReplicationReconciler) Delete() error {
+	alias := r.instance.Status.ConnectionAlias
+	if alias == "" {
+		return nil
+	}
+	cluster, err := fetchReadyCluster(r.ctx, r.Client, r.instance.Namespace, r.instance.Spec.OpensearchRef)
+	if err != nil || cluster == nil {
+		return err
+	}
+	r.osClient, err = newOsClientForCluster(r.ctx, r.Client, cluster)
+	if err != nil {
+		return err
+	}
+	return r.removeReplication(alias, r.instance.Status.Rules)
+}
+
+func (r *ReplicationReconciler) failed(err error) (ctrl.Result, error) {
+	r.recorder.Eventf(r.instance, "Warning", "replication failed", "failed to reconcile replication: %s", err)
+	if statusErr := r.updateStatus(func(status *opsterv1.OpenSearchReplicationStatus) {
+		status.Phase = opsterv1.ReplicationPhaseError
+		status.Reason = err.Error()
+	}); statusErr != nil {
+		return ctrl.Result{}, statusErr
+	}
+	return ctrl.Result{}, err
+}
+
+func (r *ReplicationReconciler) connectionAlias() string {
+	if r.instance.Spec.ConnectionAlias != "" {
+		return r.instance.Spec.ConnectionAlias
+	}
+	return r.instance.Spec.LeaderCluster.Name
+}
+
+func (r *ReplicationReconciler) roles() *requests.ReplicationRoles {
+	roles := &requests.ReplicationRoles{LeaderClusterRole: defaultReplicationRole, FollowerClusterRole: defaultReplicationRole}
+	if useRoles := r.instance.Spec.UseRoles; useRoles != nil {
+		roles.LeaderClusterRole = useRoles.LeaderClusterRole
+		roles.FollowerClusterRole = useRoles.FollowerClusterRole
+	}
+	return roles
+}
+
+func (r *ReplicationReconciler) updateStatus(update func(*opsterv1.OpenSearchReplicationStatus)) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if err := r.Get(r.ctx, client.ObjectKeyFromObject(r.instance), r.instance); err != nil {
+			return err
+		}
+		update(&r.instance.Status)
+		return r.Status().Update(r.ctx, r.instance)
+	})
+}
+
+func rulePatterns(rules []opsterv1.ReplicationRuleStatus) []string {
+	patterns := make([]string, 0, len(rules))
+	for _, rule := range rules {
+		patterns = append(patterns, rule.Pattern)
+	}
+	return patterns
+}
+
+// replicationProblems describes failed and paused replications, empty if there are none
+func replicationProblems(status *opsterv1.OpenSearchReplicationStatus) string {
+	var failed, paused []string
+	for _, rule := range status.Rules {
+		failed = append(failed, rule.FailedIndices...)
+	}
+	for _, index := range status.Indices {
+		if index.Status == "PAUSED" {
+			paused = append(paused, index.Index)
+		}
+	}
+	var problems []string
+	if len(failed) > 0 {
+		problems = append(problems, fmt.Sprintf("failed to start replication of indices %s", strings.Join(failed, ",")))
+	}
+	if len(paused) > 0 {
+		problems = append(problems, fmt.Sprintf("replication of indices %s is paused", strings.Join(paused, ",")))
+	}
+	return strings.Join(problems, ", ")
+}
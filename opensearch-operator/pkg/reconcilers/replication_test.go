@@ -0,0 +1,111 @@
+package reconcilers
+
+import (
+	"context"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	opsterv1 "opensearch.opster.io/api/v1"
+	"opensearch.opster.io/pkg/helpers"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("Replication Reconciler", func() {
+
+	const (
+		replicationName = "replication-test"
+	)
+
+	Context("When reconciling a replication for a missing cluster", func() {
+		It("should set the replication to pending", func() {
+			Expect(CreateNamespace(k8sClient, replicationName)).Should(Succeed())
+
+			replication := opsterv1.OpenSearchReplication{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      replicationName,
+					Namespace: replicationName,
+				},
+				Spec: opsterv1.OpenSearchReplicationSpec{
+					OpensearchRef: corev1.LocalObjectReference{Name: "missing"},
+					LeaderCluster: opsterv1.ClusterReference{Name: "missing-leader"},
+					Rules:         []opsterv1.ReplicationRule{{Name: "logs", Pattern: "logs-*"}},
+				},
+			}
+			Expect(k8sClient.Create(context.Background(), &replication)).Should(Succeed())
+
+			underTest := NewReplicationReconciler(
+				k8sClient,
+				context.Background(),
+				&helpers.MockEventRecorder{},
+				&replication,
+			)
+			result, err := underTest.Reconcile()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.Requeue).To(BeTrue())
+
+			updated := opsterv1.OpenSearchReplication{}
+			Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(&replication), &updated)).Should(Succeed())
+			Expect(updated.Status.Phase).To(Equal(opsterv1.ReplicationPhasePending))
+		})
+	})
+
+	Context("When reconciling the TLS configuration of a follower cluster", func() {
+		It("should trust the transport CA and nodes of the leader cluster", func() {
+			followerName := "replication-follower"
+			leaderName := "replication-leader"
+			Expect(CreateNamespace(k8sClient, followerName)).Should(Succeed())
+			Expect(CreateNamespace(k8sClient, leaderName)).Should(Succeed())
+
+			leader := opsterv1.OpenSearchCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: leaderName, Namespace: leaderName},
+				Spec: opsterv1.ClusterSpec{
+					General: opsterv1.GeneralConfig{ServiceName: leaderName},
+					Security: &opsterv1.Security{Tls: &opsterv1.TlsConfig{
+						Transport: &opsterv1.TlsConfigTransport{Generate: true, PerNode: true},
+					}},
+					NodePools: []opsterv1.NodePool{{Component: "masters", Replicas: 3, Roles: []opsterv1.NodeRole{"master", "data"}}},
+				},
+			}
+			Expect(k8sClient.Create(context.Background(), &leader)).Should(Succeed())
+			Expect(k8sClient.Create(context.Background(), &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: leaderName + "-transport-cert", Namespace: leaderName},
+				Data:       map[string][]byte{CaCertKey: []byte("leader-ca")},
+			})).Should(Succeed())
+
+			replication := opsterv1.OpenSearchReplication{
+				ObjectMeta: metav1.ObjectMeta{Name: followerName, Namespace: followerName},
+				Spec: opsterv1.OpenSearchReplicationSpec{
+					OpensearchRef: corev1.LocalObjectReference{Name: followerName},
+					LeaderCluster: opsterv1.ClusterReference{Name: leaderName, Namespace: leaderName},
+				},
+			}
+			Expect(k8sClient.Create(context.Background(), &replication)).Should(Succeed())
+
+			follower := opsterv1.OpenSearchCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: followerName, Namespace: followerName, UID: "dummyuid"},
+				Spec: opsterv1.ClusterSpec{
+					General: opsterv1.GeneralConfig{ServiceName: followerName},
+					Security: &opsterv1.Security{Tls: &opsterv1.TlsConfig{
+						Transport: &opsterv1.TlsConfigTransport{Generate: true},
+					}},
+				},
+			}
+			reconcilerContext, underTest := newTLSReconciler(&follower)
+			_, err := underTest.Reconcile()
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(reconcilerContext.OpenSearchConfig).To(HaveKeyWithValue("plugins.security.nodes_dn", "[\"CN=replication-follower,OU=replication-follower\",\"CN=replication-leader-*,OU=replication-leader\"]"))
+			Expect(reconcilerContext.OpenSearchConfig).To(HaveKeyWithValue("plugins.security.ssl.transport.pemtrustedcas_filepath", "tls-transport-trust/ca.crt"))
+			Expect(helpers.CheckVolumeExists(reconcilerContext.Volumes, reconcilerContext.VolumeMounts, followerName+"-transport-trust", "transport-trust")).To(BeTrue())
+			Expect(reconcilerContext.CertificateRevision).To(ContainSubstring("remote-trust="))
+
+			trustSecret := corev1.Secret{}
+			Expect(k8sClient.Get(context.Background(), client.ObjectKey{Name: followerName + "-transport-trust", Namespace: followerName}, &trustSecret)).To(Succeed())
+			Expect(strings.HasSuffix(string(trustSecret.Data[CaCertKey]), "\nleader-ca\n")).To(BeTrue())
+		})
+	})
+})
@@ -0,0 +1,210 @@
+package reconcilers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/banzaicloud/operator-tools/pkg/reconciler"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/retry"
+	opsterv1 "opensearch.opster.io/api/v1"
+	"opensearch.opster.io/opensearch-gateway/requests"
+	"opensearch.opster.io/opensearch-gateway/services"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	// The security index is created by the operator and can not be restored while it is open
+	defaultRestoreIndices = "*,-.opendistro_security"
+	defaultRestoreTimeout = 24 * time.Hour
+)
+
+type RestoreReconciler struct {
+	client.Client
+	reconciler.ResourceReconciler
+	ctx               context.Context
+	osClient          *services.OsClusterClient
+	recorder          record.EventRecorder
+	reconcilerContext *ReconcilerContext
+	instance          *opsterv1.OpenSearchCluster
+}
+
+func NewRestoreReconciler(
+	client client.Client,
+	ctx context.Context,
+	recorder record.EventRecorder,
+	reconcilerContext *ReconcilerContext,
+	instance *opsterv1.OpenSearchCluster,
+	opts ...reconciler.ResourceReconcilerOption,
+) *RestoreReconciler {
+	return &RestoreReconciler{
+		Client: client,
+		ResourceReconciler: reconciler.NewReconcilerWith(client,
+			append(opts, reconciler.WithLog(log.FromContext(ctx).WithValues("reconciler", "restore")))...),
+		ctx:               ctx,
+		recorder:          recorder,
+		reconcilerContext: reconcilerContext,
+		instance:          instance,
+	}
+}
+
+func (r *RestoreReconciler) Reconcile() (ctrl.Result, error) {
+	restore := r.instance.Spec.Restore
+	if restore == nil {
+		return ctrl.Result{}, nil
+	}
+	status := r.instance.Status.Restore
+	if status != nil && status.Snapshot == restore.Snapshot && status.State != opsterv1.RestoreStateRestoring {
+		return ctrl.Result{}, nil
+	}
+	// Wait for the bootstrap to complete before restoring anything
+	if !r.instance.Status.Initialized {
+		return ctrl.Result{Requeue: true, RequeueAfter: 30 * time.Second}, nil
+	}
+
+	lg := log.FromContext(r.ctx)
+	var err error
+	r.osClient, err = newOsClientForCluster(r.ctx, r.Client, r.instance)
+	if err != nil {
+		lg.Error(err, "failed to create os client")
+		return ctrl.Result{Requeue: true, RequeueAfter: 30 * time.Second}, nil
+	}
+
+	if status == nil || status.Snapshot != restore.Snapshot {
+		return r.startRestore(restore)
+	}
+	return r.trackRestore(restore)
+}
+
+func (r *RestoreReconciler) startRestore(restore *opsterv1.RestoreConfig) (ctrl.Result, error) {
+	changed, err := services.EnsureSnapshotRepository(r.ctx, r.osClient, restore.Repository.Name, requests.SnapshotRepository{
+		Type:     restore.Repository.Type,
+		Settings: restore.Repository.Settings,
+	})
+	if err != nil {
+		r.recorder.Eventf(r.instance, "Warning", "restore", "failed to register snapshot repository %s: %s", restore.Repository.Name, err)
+		return ctrl.Result{}, err
+	}
+	if changed {
+		r.recorder.Eventf(r.instance, "Normal", "restore", "registered snapshot repository %s", restore.Repository.Name)
+	}
+
+	indices := defaultRestoreIndices
+	if len(restore.Indices) > 0 {
+		indices = strings.Join(restore.Indices, ",")
+	}
+	err = r.osClient.RestoreSnapshot(r.ctx, restore.Repository.Name, restore.Snapshot, requests.RestoreSnapshot{
+		Indices:            indices,
+		IgnoreUnavailable:  true,
+		IncludeGlobalState: restore.IncludeGlobalState,
+		RenamePattern:      restore.RenamePattern,
+		RenameReplacement:  restore.RenameReplacement,
+	})
+	if err != nil {
+		r.recorder.Eventf(r.instance, "Warning", "restore", "failed to restore snapshot %s: %s", restore.Snapshot, err)
+		// The restore is not retried until the snapshot is changed
+		return ctrl.Result{}, r.updateStatus(&opsterv1.RestoreStatus{
+			Snapshot: restore.Snapshot,
+			State:    opsterv1.RestoreStateFailed,
+			Reason:   err.Error(),
+		})
+	}
+
+	r.recorder.Eventf(r.instance, "Normal", "restore", "started restore of snapshot %s", restore.Snapshot)
+	return ctrl.Result{Requeue: true, RequeueAfter: 10 * time.Second}, r.updateStatus(&opsterv1.RestoreStatus{
+		Snapshot:  restore.Snapshot,
+		State:     opsterv1.RestoreStateRestoring,
+		StartTime: &metav1.Time{Time: time.Now()},
+	})
+}
+
+func (r *RestoreReconciler) trackRestore(restore *opsterv1.RestoreConfig) (ctrl.Result, error) {
+	inProgress, err := services.SnapshotRestoreInProgress(r.ctx, r.osClient, restore.Repository.Name, restore.Snapshot)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	progress, err := services.GetSnapshotRestoreProgress(r.ctx, r.osClient, restore.Repository.Name, restore.Snapshot)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	previous := map[string]string{}
+	for _, index := range r.instance.Status.Restore.Indices {
+		previous[index.Index] = index.Stage
+	}
+	status := nextRestoreStatus(restore, r.instance.Status.Restore, progress, inProgress, time.Now())
+	for _, index := range status.Indices {
+		if index.Stage == services.RecoveryStageDone && previous[index.Index] != services.RecoveryStageDone {
+			r.recorder.Eventf(r.instance, "Normal", "restore", "restored index %s from snapshot %s", index.Index, restore.Snapshot)
+		}
+	}
+
+	switch status.State {
+	case opsterv1.RestoreStateFinished:
+		r.recorder.Eventf(r.instance, "Normal", "restore", "finished restore of snapshot %s", restore.Snapshot)
+	case opsterv1.RestoreStateFailed:
+		r.recorder.Eventf(r.instance, "Warning", "restore", "failed to restore snapshot %s: %s", restore.Snapshot, status.Reason)
+	default:
+		return ctrl.Result{Requeue: true, RequeueAfter: 10 * time.Second}, r.updateStatus(status)
+	}
+	return ctrl.Result{}, r.updateStatus(status)
+}
+
+// nextRestoreStatus derives the status of a running restore. OpenSearch removes a restore from the cluster state once
+// the primaries of all restored indices started, so that decides if the restore finished. The shard recoveries only
+// report the progress, they are replaced by replica recoveries or disappear when nodes restart.
+func nextRestoreStatus(restore *opsterv1.RestoreConfig, current *opsterv1.RestoreStatus, progress []services.IndexRestoreProgress, inProgress bool, now time.Time) *opsterv1.RestoreStatus {
+	status := &opsterv1.RestoreStatus{
+		Snapshot:  restore.Snapshot,
+		State:     opsterv1.RestoreStateRestoring,
+		StartTime: current.StartTime,
+	}
+	// Restores started by older versions of the operator have no start time
+	if status.StartTime == nil {
+		status.StartTime = &metav1.Time{Time: now}
+	}
+	for _, index := range progress {
+		status.Indices = append(status.Indices, opsterv1.IndexRestoreStatus{
+			Index:   index.Index,
+			Stage:   index.Stage,
+			Percent: index.Percent,
+		})
+	}
+
+	timeout := restoreTimeout(restore)
+	switch {
+	case !inProgress:
+		status.State = opsterv1.RestoreStateFinished
+	case now.Sub(status.StartTime.Time) > timeout:
+		status.State = opsterv1.RestoreStateFailed
+		status.Reason = fmt.Sprintf("restore did not finish within %s", timeout)
+	}
+	return status
+}
+
+func restoreTimeout(restore *opsterv1.RestoreConfig) time.Duration {
+	if restore.Timeout != nil {
+		return restore.Timeout.Duration
+	}
+	return defaultRestoreTimeout
+}
+
+func (r *RestoreReconciler) updateStatus(status *opsterv1.RestoreStatus) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if err := r.Get(r.ctx, client.ObjectKeyFromObject(r.instance), r.instance); err != nil {
+			return err
+		}
+		r.instance.Status.Restore = status
+		return r.Status().Update(r.ctx, r.instance)
+	})
+}
+
+func (r *RestoreReconciler) DeleteResources() (ctrl.Result, error) {
+	result := reconciler.CombinedResult{}
+	return result.Result, result.Err
+}
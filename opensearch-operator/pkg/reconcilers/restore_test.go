@@ -0,0 +1,196 @@
+package reconcilers
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	opsterv1 "opensearch.opster.io/api/v1"
+	"opensearch.opster.io/opensearch-gateway/responses"
+	"opensearch.opster.io/opensearch-gateway/services"
+	"opensearch.opster.io/pkg/helpers"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Restore Reconciler", func() {
+
+	const (
+		clusterName = "restore-test"
+	)
+
+	newSpec := func(restore *opsterv1.RestoreConfig) opsterv1.OpenSearchCluster {
+		return opsterv1.OpenSearchCluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      clusterName,
+				Namespace: clusterName,
+				UID:       "dummyuid",
+			},
+			Spec: opsterv1.ClusterSpec{
+				General: opsterv1.GeneralConfig{},
+				NodePools: []opsterv1.NodePool{
+					{
+						Component: "test",
+						Roles: []opsterv1.NodeRole{
+							"master",
+							"data",
+						},
+					},
+				},
+				Restore: restore,
+			},
+		}
+	}
+
+	Context("When no restore is configured", func() {
+		It("should do nothing", func() {
+			spec := newSpec(nil)
+			reconcilerContext := NewReconcilerContext(spec.Spec.NodePools)
+			underTest := NewRestoreReconciler(
+				k8sClient,
+				context.Background(),
+				&helpers.MockEventRecorder{},
+				&reconcilerContext,
+				&spec,
+			)
+			result, err := underTest.Reconcile()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.Requeue).To(BeFalse())
+		})
+	})
+
+	Context("When the cluster is not yet initialized", func() {
+		It("should wait for the bootstrap to complete", func() {
+			spec := newSpec(&opsterv1.RestoreConfig{
+				Repository: opsterv1.SnapshotRepository{
+					Name: "backups",
+					Type: "fs",
+				},
+				Snapshot: "snapshot-1",
+			})
+			reconcilerContext := NewReconcilerContext(spec.Spec.NodePools)
+			underTest := NewRestoreReconciler(
+				k8sClient,
+				context.Background(),
+				&helpers.MockEventRecorder{},
+				&reconcilerContext,
+				&spec,
+			)
+			result, err := underTest.Reconcile()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.Requeue).To(BeTrue())
+			Expect(spec.Status.Restore).To(BeNil())
+		})
+	})
+
+	Context("When the snapshot was already restored", func() {
+		It("should not restore it again", func() {
+			spec := newSpec(&opsterv1.RestoreConfig{
+				Repository: opsterv1.SnapshotRepository{
+					Name: "backups",
+					Type: "fs",
+				},
+				Snapshot: "snapshot-1",
+			})
+			spec.Status.Initialized = true
+			spec.Status.Restore = &opsterv1.RestoreStatus{
+				Snapshot: "snapshot-1",
+				State:    opsterv1.RestoreStateFinished,
+			}
+			reconcilerContext := NewReconcilerContext(spec.Spec.NodePools)
+			underTest := NewRestoreReconciler(
+				k8sClient,
+				context.Background(),
+				&helpers.MockEventRecorder{},
+				&reconcilerContext,
+				&spec,
+			)
+			result, err := underTest.Reconcile()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.Requeue).To(BeFalse())
+		})
+	})
+
+	Context("When parsing the recovery of restored indices", func() {
+		It("should only count shards recovered from the snapshot", func() {
+			response := responses.IndicesRecoveryResponse{}
+			Expect(json.Unmarshal([]byte(`{
+				"logs-1": {"shards": [
+					{"id": 0, "type": "SNAPSHOT", "stage": "DONE", "primary": true, "source": {"repository": "backups", "snapshot": "snapshot-1", "index": "logs-1"}, "index": {"size": {"percent": "100.0%"}}},
+					{"id": 1, "type": "SNAPSHOT", "stage": "INDEX", "primary": true, "source": {"repository": "backups", "snapshot": "snapshot-1", "index": "logs-1"}, "index": {"size": {"percent": "50.0%"}}},
+					{"id": 0, "type": "PEER", "stage": "INDEX", "primary": false, "source": {}, "index": {"size": {"percent": "10.0%"}}}
+				]},
+				"logs-2": {"shards": [
+					{"id": 0, "type": "SNAPSHOT", "stage": "DONE", "primary": true, "source": {"repository": "backups", "snapshot": "snapshot-1", "index": "logs-2"}, "index": {"size": {"percent": "100.0%"}}}
+				]},
+				"other": {"shards": [
+					{"id": 0, "type": "SNAPSHOT", "stage": "INDEX", "primary": true, "source": {"repository": "backups", "snapshot": "snapshot-0", "index": "other"}, "index": {"size": {"percent": "20.0%"}}},
+					{"id": 0, "type": "EXISTING_STORE", "stage": "DONE", "primary": true, "source": {}, "index": {"size": {"percent": "100.0%"}}}
+				]}
+			}`), &response)).To(Succeed())
+
+			progress := services.SnapshotRestoreProgress(response, "backups", "snapshot-1")
+			Expect(progress).To(HaveLen(2))
+			Expect(progress[0].Index).To(Equal("logs-1"))
+			Expect(progress[0].Stage).To(Equal("INDEX"))
+			Expect(progress[0].Percent).To(Equal("75.0%"))
+			Expect(progress[0].Done()).To(BeFalse())
+			Expect(progress[1].Index).To(Equal("logs-2"))
+			Expect(progress[1].Stage).To(Equal(services.RecoveryStageDone))
+			Expect(progress[1].Done()).To(BeTrue())
+		})
+	})
+
+	Context("When tracking a running restore", func() {
+		restore := &opsterv1.RestoreConfig{
+			Repository: opsterv1.SnapshotRepository{
+				Name: "backups",
+				Type: "fs",
+			},
+			Snapshot: "snapshot-1",
+		}
+		startTime := metav1.NewTime(time.Now().Add(-time.Hour))
+		current := &opsterv1.RestoreStatus{
+			Snapshot:  "snapshot-1",
+			State:     opsterv1.RestoreStateRestoring,
+			StartTime: &startTime,
+		}
+		progress := []services.IndexRestoreProgress{
+			{Index: "logs-1", Stage: "INDEX", Percent: "50.0%", Shards: 2, DoneShards: 1},
+		}
+
+		It("should keep restoring while the restore is in the cluster state", func() {
+			status := nextRestoreStatus(restore, current, progress, true, time.Now())
+			Expect(status.State).To(Equal(opsterv1.RestoreStateRestoring))
+			Expect(status.StartTime).To(Equal(&startTime))
+			Expect(status.Indices).To(HaveLen(1))
+			Expect(status.Indices[0].Stage).To(Equal("INDEX"))
+		})
+
+		It("should finish once the restore left the cluster state", func() {
+			status := nextRestoreStatus(restore, current, progress, false, time.Now())
+			Expect(status.State).To(Equal(opsterv1.RestoreStateFinished))
+		})
+
+		It("should finish without any recovery of the snapshot", func() {
+			status := nextRestoreStatus(restore, current, nil, false, time.Now())
+			Expect(status.State).To(Equal(opsterv1.RestoreStateFinished))
+			Expect(status.Indices).To(BeEmpty())
+		})
+
+		It("should fail once the timeout is exceeded", func() {
+			status := nextRestoreStatus(restore, current, progress, true, time.Now().Add(defaultRestoreTimeout))
+			Expect(status.State).To(Equal(opsterv1.RestoreStateFailed))
+			Expect(status.Reason).ToNot(BeEmpty())
+		})
+
+		It("should start the timeout for restores without a start time", func() {
+			now := time.Now()
+			status := nextRestoreStatus(restore, &opsterv1.RestoreStatus{Snapshot: "snapshot-1", State: opsterv1.RestoreStateRestoring}, progress, true, now)
+			Expect(status.State).To(Equal(opsterv1.RestoreStateRestoring))
+			Expect(status.StartTime.Time).To(Equal(now))
+		})
+	})
+})
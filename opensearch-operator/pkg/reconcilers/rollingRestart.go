@@ -2,7 +2,6 @@ package reconcilers
 
 import (
 	"context"
-	"fmt"
 	"time"
 
 	"github.com/banzaicloud/operator-tools/pkg/reconciler"
@@ -63,7 +62,7 @@ func (r *RollingRestartReconciler) Reconcile() (ctrl.Result, error) {
 	// Check that all data nodes are ready before doing work
 	// Also check if there are pending updates
 	for _, nodePool := range r.instance.Spec.NodePools {
-		if helpers.ContainsString(nodePool.Roles, "data") {
+		if helpers.HasRole(nodePool.Roles, "data") {
 			sts := &appsv1.StatefulSet{}
 			if err := r.Get(r.ctx, types.NamespacedName{
 				Name:      builders.StsName(r.instance, &nodePool),
@@ -91,12 +90,7 @@ func (r *RollingRestartReconciler) Reconcile() (ctrl.Result, error) {
 	}
 
 	// If there is work to do create an Opensearch Client
-	username, password, err := helpers.UsernameAndPassword(r.ctx, r.Client, r.instance)
-	if err != nil {
-		return ctrl.Result{}, err
-	}
-
-	clusterClient, err := services.NewOsClusterClient(fmt.Sprintf("https://%s.%s:9200", r.instance.Spec.General.ServiceName, r.instance.Namespace), username, password)
+	clusterClient, err := newOsClientForCluster(r.ctx, r.Client, r.instance)
 	if err != nil {
 		return ctrl.Result{}, err
 	}
@@ -104,7 +98,7 @@ func (r *RollingRestartReconciler) Reconcile() (ctrl.Result, error) {
 
 	// Restart statefulset pod.  Order is not important so we just pick the first we find
 	for _, nodePool := range r.instance.Spec.NodePools {
-		if helpers.ContainsString(nodePool.Roles, "data") {
+		if helpers.HasRole(nodePool.Roles, "data") {
 			sts := &appsv1.StatefulSet{}
 			if err := r.Get(r.ctx, types.NamespacedName{
 				Name:      builders.StsName(r.instance, &nodePool),
@@ -129,7 +123,7 @@ func (r *RollingRestartReconciler) restartStatefulSetPod(sts *appsv1.StatefulSet
 		lg.Info("only 2 data nodes and drain is set, some shards may not drain")
 	}
 
-	ready, err := services.CheckClusterStatusForRestart(r.osClient, r.instance.Spec.General.DrainDataNodes)
+	ready, err := services.CheckClusterStatusForRestart(r.ctx, r.osClient, r.instance.Spec.General.DrainDataNodes)
 	if err != nil {
 		return ctrl.Result{}, err
 	}
@@ -142,7 +136,19 @@ func (r *RollingRestartReconciler) restartStatefulSetPod(sts *appsv1.StatefulSet
 
 	workingPod := builders.WorkingPodForRollingRestart(sts)
 
-	ready, err = services.PreparePodForDelete(r.osClient, workingPod, r.instance.Spec.General.DrainDataNodes, dataCount)
+	allowed, err := podDisruptionAllowed(r.ctx, r.Client, r.instance, sts, workingPod)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if !allowed {
+		lg.Info("pod disruption budget does not allow restarting the pod", "pod", workingPod)
+		return ctrl.Result{
+			Requeue:      true,
+			RequeueAfter: 10 * time.Second,
+		}, nil
+	}
+
+	ready, err = services.PreparePodForDelete(r.ctx, r.osClient, workingPod, r.instance.Spec.General.DrainDataNodes, dataCount)
 	if err != nil {
 		return ctrl.Result{}, err
 	}
@@ -165,7 +171,7 @@ func (r *RollingRestartReconciler) restartStatefulSetPod(sts *appsv1.StatefulSet
 
 	// If we are draining nodes remove the exclusion after the pod is deleted
 	if r.instance.Spec.General.DrainDataNodes {
-		_, err = services.RemoveExcludeNodeHost(r.osClient, workingPod)
+		_, err = services.RemoveExcludeNodeHost(r.ctx, r.osClient, workingPod)
 		return ctrl.Result{}, err
 	}
 
@@ -3,17 +3,20 @@ package reconcilers
 import (
 	"context"
 	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
-	corev1 "k8s.io/api/core/v1"
-	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/utils/pointer"
 	"opensearch.opster.io/opensearch-gateway/services"
 	"opensearch.opster.io/pkg/builders"
 
 	"github.com/banzaicloud/operator-tools/pkg/reconciler"
 	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/retry"
 	opsterv1 "opensearch.opster.io/api/v1"
 	"opensearch.opster.io/pkg/helpers"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -145,53 +148,23 @@ func (r *ScalerReconciler) decreaseOneNode(currentStatus opsterv1.ComponentStatu
 	if !smartDecrease {
 		return false, err
 	}
-	username, password, err := helpers.UsernameAndPassword(r.ctx, r.Client, r.instance)
-	if err != nil {
-		return true, err
-	}
-	service, created, err := r.CreateNodePortServiceIfNotExists()
-	if err != nil {
-		return true, err
-	}
-	clusterClient, err := services.NewOsClusterClient(builders.URLForCluster(r.instance), username, password)
+	clusterClient, err := newOsClientForCluster(r.ctx, r.Client, r.instance)
 	if err != nil {
 		lg.Error(err, "failed to create os client")
 		r.recorder.Event(r.instance, "WARN", "failed to remove node exclude", fmt.Sprintf("Group-%s . failed to remove node exclude %s", nodePoolGroupName, lastReplicaNodeName))
-		if created {
-			r.DeleteNodePortService(service)
-		}
 		return true, err
 	}
-	success, err := services.RemoveExcludeNodeHost(clusterClient, lastReplicaNodeName)
+	success, err := services.RemoveExcludeNodeHost(r.ctx, clusterClient, lastReplicaNodeName)
 	if !success || err != nil {
 		lg.Error(err, fmt.Sprintf("failed to remove exclude node %s", lastReplicaNodeName))
 		r.recorder.Event(r.instance, "WARN", "failed to remove node exclude", fmt.Sprintf("Group-%s . failed to remove node exclude %s", nodePoolGroupName, lastReplicaNodeName))
 	}
-	if created {
-		r.DeleteNodePortService(service)
-	}
 	return false, err
 }
 
 func (r *ScalerReconciler) excludeNode(currentStatus opsterv1.ComponentStatus, currentSts appsv1.StatefulSet, nodePoolGroupName string) error {
 	lg := log.FromContext(r.ctx)
-	username, password, err := helpers.UsernameAndPassword(r.ctx, r.Client, r.instance)
-	if err != nil {
-		return err
-	}
-	service, created, err := r.CreateNodePortServiceIfNotExists()
-	if err != nil {
-		return err
-	}
-
-	// Clean up created service at the end of the function
-	defer func() {
-		if created {
-			r.DeleteNodePortService(service)
-		}
-	}()
-
-	clusterClient, err := services.NewOsClusterClient(fmt.Sprintf("https://localhost:%d", service.Spec.Ports[0].NodePort), username, password)
+	clusterClient, err := newOsClientForCluster(r.ctx, r.Client, r.instance)
 	if err != nil {
 		lg.Error(err, "failed to create os client")
 		return err
@@ -199,7 +172,7 @@ func (r *ScalerReconciler) excludeNode(currentStatus opsterv1.ComponentStatus, c
 	// -----  Now start remove node ------
 	lastReplicaNodeName := builders.ReplicaHostName(currentSts, *currentSts.Spec.Replicas-1)
 
-	excluded, err := services.AppendExcludeNodeHost(clusterClient, lastReplicaNodeName)
+	excluded, err := services.AppendExcludeNodeHost(r.ctx, clusterClient, lastReplicaNodeName)
 	if err != nil {
 		lg.Error(err, fmt.Sprintf("failed to exclude node %s", lastReplicaNodeName))
 		return err
@@ -240,32 +213,16 @@ func (r *ScalerReconciler) excludeNode(currentStatus opsterv1.ComponentStatus, c
 func (r *ScalerReconciler) drainNode(currentStatus opsterv1.ComponentStatus, currentSts appsv1.StatefulSet, nodePoolGroupName string) error {
 	lg := log.FromContext(r.ctx)
 	lastReplicaNodeName := builders.ReplicaHostName(currentSts, *currentSts.Spec.Replicas-1)
-	username, password, err := helpers.UsernameAndPassword(r.ctx, r.Client, r.instance)
+	clusterClient, err := newOsClientForCluster(r.ctx, r.Client, r.instance)
 	if err != nil {
 		return err
 	}
-	service, created, err := r.CreateNodePortServiceIfNotExists()
-	if err != nil {
-		return err
-	}
-
-	// Clean up created service at the end of the function
-	defer func() {
-		if created {
-			r.DeleteNodePortService(service)
-		}
-	}()
-
-	clusterClient, err := services.NewOsClusterClient(fmt.Sprintf("https://localhost:%d", service.Spec.Ports[0].NodePort), username, password)
-	if err != nil {
-		return err
-	}
-	nodeNotEmpty, err := services.HasShardsOnNode(clusterClient, lastReplicaNodeName)
+	nodeNotEmpty, err := services.HasShardsOnNode(r.ctx, clusterClient, lastReplicaNodeName)
 	if nodeNotEmpty {
 		lg.Info(fmt.Sprintf("Group-%s . draining node %s", nodePoolGroupName, lastReplicaNodeName))
 		return err
 	}
-	success, err := services.RemoveExcludeNodeHost(clusterClient, lastReplicaNodeName)
+	success, err := services.RemoveExcludeNodeHost(r.ctx, clusterClient, lastReplicaNodeName)
 	if !success {
 		r.recorder.Event(r.instance, "Normal", "node is empty but node is still excluded from allocation", fmt.Sprintf("Group-%s . node %s node is empty but node is still excluded from allocation", nodePoolGroupName, lastReplicaNodeName))
 		return err
@@ -286,44 +243,12 @@ func (r *ScalerReconciler) drainNode(currentStatus opsterv1.ComponentStatus, cur
 	return err
 }
 
-func (r *ScalerReconciler) CreateNodePortServiceIfNotExists() (corev1.Service, bool, error) {
-	lg := log.FromContext(r.ctx)
-	namespace := r.instance.Namespace
-	targetService := builders.NewNodePortService(r.instance)
-	existingService := corev1.Service{}
-	if err := r.Get(r.ctx, client.ObjectKey{Name: targetService.Name, Namespace: namespace}, &existingService); err != nil {
-		if err := ctrl.SetControllerReference(r.instance, targetService, r.Client.Scheme()); err != nil {
-			return *targetService, false, err
-		}
-		err = r.Create(r.ctx, targetService)
-		if err != nil {
-			if !errors.IsAlreadyExists(err) {
-				lg.Error(err, "Cannot create service")
-				r.recorder.Event(r.instance, "Warning", "Cannot create service", "Requeue - Fix the problem you have on main Opensearch Headless Service ")
-				return *targetService, false, err
-			}
-		}
-		lg.Info("service created successfully")
-		return *targetService, true, nil
-	}
-	return existingService, false, nil
-}
-
-func (r *ScalerReconciler) DeleteNodePortService(service corev1.Service) {
-	lg := log.FromContext(r.ctx)
-	err := r.Delete(r.ctx, &service)
-	if err != nil {
-		lg.Error(err, "Cannot delete service")
-		r.recorder.Event(r.instance, "Warning", "Cannot delete service", "Requeue - Fix the problem you have on main Opensearch Headless Service ")
-	}
-}
-
 func (r *ScalerReconciler) cleanupStatefulSets(result *reconciler.CombinedResult) {
 	stsList := &appsv1.StatefulSetList{}
 	if err := r.Client.List(
 		r.ctx,
 		stsList,
-		client.InNamespace(r.instance.Name),
+		client.InNamespace(r.instance.Namespace),
 		client.MatchingLabels{builders.ClusterLabel: r.instance.Name},
 	); err != nil {
 		result.Combine(&ctrl.Result{}, err)
@@ -331,26 +256,157 @@ func (r *ScalerReconciler) cleanupStatefulSets(result *reconciler.CombinedResult
 	}
 
 	for _, sts := range stsList.Items {
-		if !builders.STSInNodePools(sts, r.instance.Spec.NodePools) {
-			result.Combine(r.removeStatefulSet(sts))
+		if builders.STSInNodePools(r.instance, sts, r.instance.Spec.NodePools) {
+			continue
 		}
+		if migration, ok := r.storageMigrationSource(sts); ok {
+			result.Combine(r.retireMigratedStatefulSet(sts, migration))
+			continue
+		}
+		result.Combine(r.removeStatefulSet(sts, r.instance.Spec.ConfMgmt.SmartScaler))
 	}
 
+	// Finish migrations whose source statefulset is already gone, e.g. if removing its volumes failed before
+	for _, migration := range r.instance.Status.StorageMigrations {
+		if migration.Source == "" || stsInList(stsList.Items, migration.Source) {
+			continue
+		}
+		result.Combine(r.finishStorageMigration(migration))
+	}
 }
 
-func (r *ScalerReconciler) removeStatefulSet(sts appsv1.StatefulSet) (*ctrl.Result, error) {
-	if !r.instance.Spec.ConfMgmt.SmartScaler {
-		return r.ReconcileResource(&sts, reconciler.StateAbsent)
+func stsInList(stsList []appsv1.StatefulSet, name string) bool {
+	for _, sts := range stsList {
+		if sts.Name == name {
+			return true
+		}
 	}
+	return false
+}
 
-	// Gracefully remove nodes
+// isDataClaimOf checks if the PVC is the data volume of a pod of the statefulset
+func isDataClaimOf(pvcName string, stsName string) bool {
+	ordinal := strings.TrimPrefix(pvcName, fmt.Sprintf("data-%s-", stsName))
+	if ordinal == pvcName {
+		return false
+	}
+	_, err := strconv.Atoi(ordinal)
+	return err == nil
+}
+
+func (r *ScalerReconciler) storageMigrationSource(sts appsv1.StatefulSet) (opsterv1.StorageMigrationStatus, bool) {
+	for _, migration := range r.instance.Status.StorageMigrations {
+		if migration.Source == sts.Name {
+			return migration, true
+		}
+	}
+	return opsterv1.StorageMigrationStatus{}, false
+}
+
+// retireMigratedStatefulSet drains the nodes of a statefulset whose node pool was migrated to new storage once the
+// new statefulset is ready, and gracefully removes it
+func (r *ScalerReconciler) retireMigratedStatefulSet(sts appsv1.StatefulSet, migration opsterv1.StorageMigrationStatus) (*ctrl.Result, error) {
 	lg := log.FromContext(r.ctx)
-	username, password, err := helpers.UsernameAndPassword(r.ctx, r.Client, r.instance)
+	target := appsv1.StatefulSet{}
+	if err := r.Get(r.ctx, client.ObjectKey{Name: migration.StatefulSet, Namespace: sts.Namespace}, &target); err != nil {
+		return nil, err
+	}
+	if target.Status.ReadyReplicas != pointer.Int32Deref(target.Spec.Replicas, 1) {
+		lg.Info(fmt.Sprintf("waiting for statefulset %s to be ready before draining %s", target.Name, sts.Name))
+		return &ctrl.Result{
+			Requeue:      true,
+			RequeueAfter: 15 * time.Second,
+		}, nil
+	}
+
+	clusterClient, err := newOsClientForCluster(r.ctx, r.Client, r.instance)
 	if err != nil {
+		lg.Error(err, "failed to create os client")
+		return nil, err
+	}
+	for ordinal := int32(0); ordinal < pointer.Int32Deref(sts.Spec.Replicas, 1); ordinal++ {
+		nodeName := builders.ReplicaHostName(sts, ordinal)
+		if _, err := services.AppendExcludeNodeHost(r.ctx, clusterClient, nodeName); err != nil {
+			lg.Error(err, fmt.Sprintf("failed to exclude node %s", nodeName))
+			return nil, err
+		}
+	}
+
+	result, err := r.removeStatefulSet(sts, true)
+	if err != nil {
+		return result, err
+	}
+	removed := appsv1.StatefulSet{}
+	if err := r.Get(r.ctx, client.ObjectKeyFromObject(&sts), &removed); err == nil && removed.DeletionTimestamp == nil {
+		return result, nil
+	} else if err != nil && !k8serrors.IsNotFound(err) {
+		return result, err
+	}
+
+	finishResult, err := r.finishStorageMigration(migration)
+	if finishResult != nil {
+		result = finishResult
+	}
+	return result, err
+}
+
+// finishStorageMigration deletes the volumes of the removed statefulset and clears the source of the migration once
+// they are gone. The volumes have to be removed as a later migration of the node pool reuses the name of the statefulset.
+func (r *ScalerReconciler) finishStorageMigration(migration opsterv1.StorageMigrationStatus) (*ctrl.Result, error) {
+	pvcs := corev1.PersistentVolumeClaimList{}
+	if err := r.List(r.ctx, &pvcs, client.InNamespace(r.instance.Namespace), client.MatchingLabels{builders.ClusterLabel: r.instance.Name}); err != nil {
 		return nil, err
 	}
+	remaining := false
+	for i, pvc := range pvcs.Items {
+		if !isDataClaimOf(pvc.Name, migration.Source) {
+			continue
+		}
+		remaining = true
+		if pvc.DeletionTimestamp != nil {
+			continue
+		}
+		if err := r.Delete(r.ctx, &pvcs.Items[i]); err != nil && !k8serrors.IsNotFound(err) {
+			return nil, err
+		}
+	}
+	if remaining {
+		return &ctrl.Result{
+			Requeue:      true,
+			RequeueAfter: 15 * time.Second,
+		}, nil
+	}
 
-	clusterClient, err := services.NewOsClusterClient(fmt.Sprintf("https://%s.%s:9200", r.instance.Spec.General.ServiceName, r.instance.Name), username, password)
+	r.recorder.Eventf(r.instance, "Normal", "disk", "migrated the storage of node pool %s to statefulset %s", migration.Component, migration.StatefulSet)
+	return nil, retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if err := r.Get(r.ctx, client.ObjectKeyFromObject(r.instance), r.instance); err != nil {
+			return err
+		}
+		var migrations []opsterv1.StorageMigrationStatus
+		for _, current := range r.instance.Status.StorageMigrations {
+			if current.Component != migration.Component {
+				migrations = append(migrations, current)
+				continue
+			}
+			// Node pools using the default name again need no status
+			if current.StatefulSet != r.instance.Name+"-"+current.Component {
+				current.Source = ""
+				migrations = append(migrations, current)
+			}
+		}
+		r.instance.Status.StorageMigrations = migrations
+		return r.Status().Update(r.ctx, r.instance)
+	})
+}
+
+func (r *ScalerReconciler) removeStatefulSet(sts appsv1.StatefulSet, graceful bool) (*ctrl.Result, error) {
+	if !graceful {
+		return r.ReconcileResource(&sts, reconciler.StateAbsent)
+	}
+
+	// Gracefully remove nodes
+	lg := log.FromContext(r.ctx)
+	clusterClient, err := newOsClientForCluster(r.ctx, r.Client, r.instance)
 	if err != nil {
 		lg.Error(err, "failed to create os client")
 		return nil, err
@@ -358,13 +414,13 @@ func (r *ScalerReconciler) removeStatefulSet(sts appsv1.StatefulSet) (*ctrl.Resu
 
 	workingOrdinal := pointer.Int32Deref(sts.Spec.Replicas, 1) - 1
 	lastReplicaNodeName := builders.ReplicaHostName(sts, workingOrdinal)
-	_, err = services.AppendExcludeNodeHost(clusterClient, lastReplicaNodeName)
+	_, err = services.AppendExcludeNodeHost(r.ctx, clusterClient, lastReplicaNodeName)
 	if err != nil {
 		lg.Error(err, fmt.Sprintf("failed to exclude node %s", lastReplicaNodeName))
 		return nil, err
 	}
 
-	nodeNotEmpty, err := services.HasShardsOnNode(clusterClient, lastReplicaNodeName)
+	nodeNotEmpty, err := services.HasShardsOnNode(r.ctx, clusterClient, lastReplicaNodeName)
 	if err != nil {
 		lg.Error(err, "failed to check shards on node")
 		return nil, err
@@ -382,7 +438,7 @@ func (r *ScalerReconciler) removeStatefulSet(sts appsv1.StatefulSet) (*ctrl.Resu
 		if err != nil {
 			return result, err
 		}
-		_, err = services.RemoveExcludeNodeHost(clusterClient, lastReplicaNodeName)
+		_, err = services.RemoveExcludeNodeHost(r.ctx, clusterClient, lastReplicaNodeName)
 		if err != nil {
 			lg.Error(err, fmt.Sprintf("failed to remove node exclusion for %s", lastReplicaNodeName))
 		}
@@ -395,7 +451,7 @@ func (r *ScalerReconciler) removeStatefulSet(sts appsv1.StatefulSet) (*ctrl.Resu
 		return result, err
 	}
 
-	_, err = services.RemoveExcludeNodeHost(clusterClient, lastReplicaNodeName)
+	_, err = services.RemoveExcludeNodeHost(r.ctx, clusterClient, lastReplicaNodeName)
 	if err != nil {
 		lg.Error(err, fmt.Sprintf("failed to remove node exclusion for %s", lastReplicaNodeName))
 	}
@@ -0,0 +1,323 @@
+package reconcilers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/banzaicloud/operator-tools/pkg/reconciler"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/retry"
+	opsterv1 "opensearch.opster.io/api/v1"
+	"opensearch.opster.io/opensearch-gateway/requests"
+	"opensearch.opster.io/opensearch-gateway/services"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	// Users, roles and role mappings are checked for drift in this interval
+	securityResyncInterval = time.Minute
+	securityWaitInterval   = 30 * time.Second
+)
+
+// securityResource is the desired state of a user, role or role mapping
+type securityResource struct {
+	name     string
+	resource interface{}
+	// Forces an update, used for password changes which can not be detected in the cluster
+	force            bool
+	passwordChecksum string
+}
+
+// securityResourceReconciler contains the logic shared by the user, role and role mapping reconcilers
+type securityResourceReconciler struct {
+	client.Client
+	reconciler.ResourceReconciler
+	ctx          context.Context
+	osClient     *services.OsClusterClient
+	recorder     record.EventRecorder
+	logger       logr.Logger
+	object       client.Object
+	status       *opsterv1.OpenSearchSecurityStatus
+	clusterRef   corev1.LocalObjectReference
+	resourceType string
+	kind         string
+}
+
+func newSecurityResourceReconciler(
+	client client.Client,
+	ctx context.Context,
+	recorder record.EventRecorder,
+	object client.Object,
+	status *opsterv1.OpenSearchSecurityStatus,
+	clusterRef corev1.LocalObjectReference,
+	resourceType string,
+	kind string,
+	opts ...reconciler.ResourceReconcilerOption,
+) securityResourceReconciler {
+	logger := log.FromContext(ctx).WithValues("reconciler", resourceType)
+	return securityResourceReconciler{
+		Client:             client,
+		ResourceReconciler: reconciler.NewReconcilerWith(client, append(opts, reconciler.WithLog(logger))...),
+		ctx:                ctx,
+		recorder:           recorder,
+		logger:             logger,
+		object:             object,
+		status:             status,
+		clusterRef:         clusterRef,
+		resourceType:       resourceType,
+		kind:               kind,
+	}
+}
+
+// reconcile applies the resource returned by build to the cluster.
+// build is only called once the cluster is ready, errors returned by it are reported in the status and retried later.
+func (r *securityResourceReconciler) reconcile(build func() (securityResource, error)) (ctrl.Result, error) {
+	cluster, err := fetchReadyCluster(r.ctx, r.Client, r.object.GetNamespace(), r.clusterRef)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if cluster == nil {
+		return ctrl.Result{Requeue: true, RequeueAfter: securityWaitInterval}, r.updateStatus(func(status *opsterv1.OpenSearchSecurityStatus) {
+			status.Phase = opsterv1.SecurityPhasePending
+			status.Reason = "waiting for opensearch cluster to be initialized"
+		})
+	}
+	r.osClient, err = newOsClientForCluster(r.ctx, r.Client, cluster)
+	if err != nil {
+		r.logger.Error(err, "failed to create os client")
+		return ctrl.Result{Requeue: true, RequeueAfter: securityWaitInterval}, nil
+	}
+
+	desired, err := build()
+	if err != nil {
+		r.recorder.Eventf(r.object, "Warning", "invalid "+r.kind, "failed to build %s: %s", r.kind, err)
+		return ctrl.Result{Requeue: true, RequeueAfter: securityWaitInterval}, r.updateStatus(func(status *opsterv1.OpenSearchSecurityStatus) {
+			status.Phase = opsterv1.SecurityPhaseError
+			status.Reason = err.Error()
+		})
+	}
+
+	// The resource was renamed, remove the old one from the cluster
+	if existing := r.status.ExistingName; existing != "" && existing != desired.name {
+		if err := r.osClient.DeleteSecurityResource(r.ctx, r.resourceType, existing); err != nil {
+			return ctrl.Result{}, err
+		}
+		r.recorder.Eventf(r.object, "Normal", r.kind+" deleted", "deleted renamed %s %s", r.kind, existing)
+	}
+
+	changed, err := services.EnsureSecurityResource(r.ctx, r.osClient, r.resourceType, desired.name, desired.resource, desired.force)
+	if err != nil {
+		r.recorder.Eventf(r.object, "Warning", r.kind+" failed", "failed to apply %s %s: %s", r.kind, desired.name, err)
+		if statusErr := r.updateStatus(func(status *opsterv1.OpenSearchSecurityStatus) {
+			status.Phase = opsterv1.SecurityPhaseError
+			status.Reason = err.Error()
+		}); statusErr != nil {
+			return ctrl.Result{}, statusErr
+		}
+		// Reserved resources can not be changed by retrying
+		if err == services.ErrSecurityReserved {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+	if changed {
+		r.recorder.Eventf(r.object, "Normal", r.kind+" applied", "applied %s %s", r.kind, desired.name)
+	}
+	return ctrl.Result{Requeue: true, RequeueAfter: securityResyncInterval}, r.updateStatus(func(status *opsterv1.OpenSearchSecurityStatus) {
+		status.Phase = opsterv1.SecurityPhaseApplied
+		status.Reason = ""
+		status.ExistingName = desired.name
+		status.PasswordChecksum = desired.passwordChecksum
+	})
+}
+
+// delete removes the resource from the cluster, if the cluster no longer exists there is nothing to do
+func (r *securityResourceReconciler) delete() error {
+	name := r.status.ExistingName
+	if name == "" {
+		return nil
+	}
+	return deleteFromReadyCluster(r.ctx, r.Client, r.recorder, r.object, r.clusterRef, func(osClient *services.OsClusterClient) error {
+		return osClient.DeleteSecurityResource(r.ctx, r.resourceType, name)
+	})
+}
+
+func (r *securityResourceReconciler) updateStatus(update func(*opsterv1.OpenSearchSecurityStatus)) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if err := r.Get(r.ctx, client.ObjectKeyFromObject(r.object), r.object); err != nil {
+			return err
+		}
+		update(r.status)
+		return r.Status().Update(r.ctx, r.object)
+	})
+}
+
+type UserReconciler struct {
+	securityResourceReconciler
+	instance *opsterv1.OpenSearchUser
+}
+
+func NewUserReconciler(
+	client client.Client,
+	ctx context.Context,
+	recorder record.EventRecorder,
+	instance *opsterv1.OpenSearchUser,
+	opts ...reconciler.ResourceReconcilerOption,
+) *UserReconciler {
+	return &UserReconciler{
+		securityResourceReconciler: newSecurityResourceReconciler(client, ctx, recorder, instance, &instance.Status,
+			instance.Spec.OpensearchRef, services.SecurityInternalUsers, "user", opts...),
+		instance: instance,
+	}
+}
+
+func (r *UserReconciler) Reconcile() (ctrl.Result, error) {
+	return r.reconcile(r.buildUser)
+}
+
+func (r *UserReconciler) Delete() error {
+	return r.delete()
+}
+
+func (r *UserReconciler) buildUser() (securityResource, error) {
+	passwordFrom := r.instance.Spec.PasswordFrom
+	secret := corev1.Secret{}
+	if err := r.Get(r.ctx, client.ObjectKey{Name: passwordFrom.Name, Namespace: r.instance.Namespace}, &secret); err != nil {
+		if errors.IsNotFound(err) {
+			return securityResource{}, fmt.Errorf("password secret %s not found", passwordFrom.Name)
+		}
+		return securityResource{}, err
+	}
+	password, ok := secret.Data[passwordFrom.Key]
+	if !ok || len(password) == 0 {
+		return securityResource{}, fmt.Errorf("key %s not found in password secret %s", passwordFrom.Key, passwordFrom.Name)
+	}
+	passwordChecksum, err := checksum(map[string][]byte{"password": password})
+	if err != nil {
+		return securityResource{}, err
+	}
+
+	name := r.instance.Spec.Name
+	if name == "" {
+		name = r.instance.Name
+	}
+	return securityResource{
+		name: name,
+		resource: requests.SecurityUser{
+			Password:                string(password),
+			BackendRoles:            r.instance.Spec.BackendRoles,
+			OpendistroSecurityRoles: r.instance.Spec.OpendistroSecurityRoles,
+			Attributes:              r.instance.Spec.Attributes,
+			Description:             r.instance.Spec.Description,
+		},
+		force:            passwordChecksum != r.instance.Status.PasswordChecksum || name != r.instance.Status.ExistingName,
+		passwordChecksum: passwordChecksum,
+	}, nil
+}
+
+type RoleReconciler struct {
+	securityResourceReconciler
+	instance *opsterv1.OpenSearchRole
+}
+
+func NewRoleReconciler(
+	client client.Client,
+	ctx context.Context,
+	recorder record.EventRecorder,
+	instance *opsterv1.OpenSearchRole,
+	opts ...reconciler.ResourceReconcilerOption,
+) *RoleReconciler {
+	return &RoleReconciler{
+		securityResourceReconciler: newSecurityResourceReconciler(client, ctx, recorder, instance, &instance.Status,
+			instance.Spec.OpensearchRef, services.SecurityRoles, "role", opts...),
+		instance: instance,
+	}
+}
+
+func (r *RoleReconciler) Reconcile() (ctrl.Result, error) {
+	return r.reconcile(func() (securityResource, error) {
+		name := r.instance.Spec.Name
+		if name == "" {
+			name = r.instance.Name
+		}
+		return securityResource{
+			name:     name,
+			resource: BuildSecurityRole(r.instance.Spec),
+		}, nil
+	})
+}
+
+func (r *RoleReconciler) Delete() error {
+	return r.delete()
+}
+
+type RoleMappingReconciler struct {
+	securityResourceReconciler
+	instance *opsterv1.OpenSearchRoleMapping
+}
+
+func NewRoleMappingReconciler(
+	client client.Client,
+	ctx context.Context,
+	recorder record.EventRecorder,
+	instance *opsterv1.OpenSearchRoleMapping,
+	opts ...reconciler.ResourceReconcilerOption,
+) *RoleMappingReconciler {
+	return &RoleMappingReconciler{
+		securityResourceReconciler: newSecurityResourceReconciler(client, ctx, recorder, instance, &instance.Status,
+			instance.Spec.OpensearchRef, services.SecurityRolesMapping, "role mapping", opts...),
+		instance: instance,
+	}
+}
+
+func (r *RoleMappingReconciler) Reconcile() (ctrl.Result, error) {
+	return r.reconcile(func() (securityResource, error) {
+		role := r.instance.Spec.Role
+		if role == "" {
+			role = r.instance.Name
+		}
+		return securityResource{
+			name: role,
+			resource: requests.SecurityRoleMapping{
+				Users:        r.instance.Spec.Users,
+				BackendRoles: r.instance.Spec.BackendRoles,
+				Hosts:        r.instance.Spec.Hosts,
+				Description:  r.instance.Spec.Description,
+			},
+		}, nil
+	})
+}
+
+func (r *RoleMappingReconciler) Delete() error {
+	return r.delete()
+}
+
+// BuildSecurityRole converts the role of the resource into the format of the security API
+func BuildSecurityRole(spec opsterv1.OpenSearchRoleSpec) requests.SecurityRole {
+	role := requests.SecurityRole{
+		ClusterPermissions: spec.ClusterPermissions,
+		Description:        spec.Description,
+	}
+	for _, permissions := range spec.IndexPermissions {
+		role.IndexPermissions = append(role.IndexPermissions, requests.SecurityIndexPermissions{
+			IndexPatterns:  permissions.IndexPatterns,
+			AllowedActions: permissions.AllowedActions,
+			Dls:            permissions.Dls,
+			Fls:            permissions.Fls,
+			MaskedFields:   permissions.MaskedFields,
+		})
+	}
+	for _, permissions := range spec.TenantPermissions {
+		role.TenantPermissions = append(role.TenantPermissions, requests.SecurityTenantPermissions{
+			TenantPatterns: permissions.TenantPatterns,
+			AllowedActions: permissions.AllowedActions,
+		})
+	}
+	return role
+}
@@ -0,0 +1,131 @@
+package reconcilers
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	opsterv1 "opensearch.opster.io/api/v1"
+	"opensearch.opster.io/opensearch-gateway/services"
+	"opensearch.opster.io/pkg/helpers"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("Security Resources Reconciler", func() {
+
+	const (
+		userName = "user-test"
+	)
+
+	Context("When reconciling a user for a missing cluster", func() {
+		It("should set the user to pending", func() {
+			Expect(CreateNamespace(k8sClient, userName)).Should(Succeed())
+
+			user := opsterv1.OpenSearchUser{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      userName,
+					Namespace: userName,
+				},
+				Spec: opsterv1.OpenSearchUserSpec{
+					OpensearchRef: corev1.LocalObjectReference{Name: "missing"},
+					PasswordFrom: corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "user-password"},
+						Key:                  "password",
+					},
+				},
+			}
+			Expect(k8sClient.Create(context.Background(), &user)).Should(Succeed())
+
+			underTest := NewUserReconciler(
+				k8sClient,
+				context.Background(),
+				&helpers.MockEventRecorder{},
+				&user,
+			)
+			result, err := underTest.Reconcile()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.Requeue).To(BeTrue())
+
+			updated := opsterv1.OpenSearchUser{}
+			Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(&user), &updated)).Should(Succeed())
+			Expect(updated.Status.Phase).To(Equal(opsterv1.SecurityPhasePending))
+		})
+	})
+
+	Context("When deleting a user from a cluster that can not be reached", func() {
+		It("should only remove it with the skip annotation", func() {
+			Expect(CreateNamespace(k8sClient, userName+"-delete")).Should(Succeed())
+			Expect(CreateUnreachableCluster(k8sClient, "unreachable", userName+"-delete")).Should(Succeed())
+
+			user := opsterv1.OpenSearchUser{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      userName,
+					Namespace: userName + "-delete",
+				},
+				Spec: opsterv1.OpenSearchUserSpec{
+					OpensearchRef: corev1.LocalObjectReference{Name: "unreachable"},
+				},
+				Status: opsterv1.OpenSearchSecurityStatus{
+					ExistingName: userName,
+				},
+			}
+			underTest := NewUserReconciler(
+				k8sClient,
+				context.Background(),
+				&helpers.MockEventRecorder{},
+				&user,
+			)
+			Expect(underTest.Delete()).To(HaveOccurred())
+
+			user.Annotations = map[string]string{SkipClusterDeletionAnnotation: "true"}
+			Expect(underTest.Delete()).To(Succeed())
+		})
+	})
+
+	Context("When comparing roles", func() {
+		role := BuildSecurityRole(opsterv1.OpenSearchRoleSpec{
+			ClusterPermissions: []string{"cluster_monitor"},
+			IndexPermissions: []opsterv1.IndexPermissions{
+				{
+					IndexPatterns:  []string{"logs-*"},
+					AllowedActions: []string{"read"},
+				},
+			},
+		})
+
+		It("should ignore empty fields and metadata", func() {
+			existing := map[string]interface{}{
+				"reserved":            false,
+				"hidden":              false,
+				"static":              false,
+				"cluster_permissions": []interface{}{"cluster_monitor"},
+				"index_permissions": []interface{}{
+					map[string]interface{}{
+						"index_patterns":  []interface{}{"logs-*"},
+						"allowed_actions": []interface{}{"read"},
+						"fls":             []interface{}{},
+						"masked_fields":   []interface{}{},
+					},
+				},
+				"tenant_permissions": []interface{}{},
+			}
+			Expect(services.SecurityResourcesMatch(existing, role)).To(BeTrue())
+		})
+
+		It("should detect changed permissions", func() {
+			existing := map[string]interface{}{
+				"cluster_permissions": []interface{}{"cluster_all"},
+				"index_permissions": []interface{}{
+					map[string]interface{}{
+						"index_patterns":  []interface{}{"logs-*"},
+						"allowed_actions": []interface{}{"read"},
+					},
+				},
+			}
+			Expect(services.SecurityResourcesMatch(existing, role)).To(BeFalse())
+		})
+	})
+})
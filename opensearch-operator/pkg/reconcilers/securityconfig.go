@@ -18,6 +18,7 @@ import (
 	"k8s.io/client-go/tools/record"
 	opsterv1 "opensearch.opster.io/api/v1"
 	"opensearch.opster.io/pkg/builders"
+	"opensearch.opster.io/pkg/helpers"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
@@ -64,7 +65,7 @@ func (r *SecurityconfigReconciler) Reconcile() (ctrl.Result, error) {
 	}
 
 	var configSecretName string
-	adminCertName := r.determineAdminSecret()
+	adminCertName := helpers.AdminCertificateSecretName(r.instance)
 	namespace := r.instance.Namespace
 	clusterName := r.instance.Name
 	//Checking if Security Config values are empty and creates a default-securityconfig secret
@@ -190,16 +191,6 @@ func checksum(data map[string][]byte) (string, error) {
 	return base64.StdEncoding.EncodeToString(hash.Sum(nil)), nil
 }
 
-func (r *SecurityconfigReconciler) determineAdminSecret() string {
-	if r.instance.Spec.Security.Config != nil && r.instance.Spec.Security.Config.AdminSecret.Name != "" {
-		return r.instance.Spec.Security.Config.AdminSecret.Name
-	} else if r.instance.Spec.Security.Tls.Transport.Generate {
-		return fmt.Sprintf("%s-admin-cert", r.instance.Name)
-	} else {
-		return ""
-	}
-}
-
 func (r *SecurityconfigReconciler) DeleteResources() (ctrl.Result, error) {
 	result := reconciler.CombinedResult{}
 	return result.Result, result.Err
@@ -0,0 +1,278 @@
+package reconcilers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/banzaicloud/operator-tools/pkg/reconciler"
+	"github.com/go-logr/logr"
+	"github.com/robfig/cron/v3"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/retry"
+	opsterv1 "opensearch.opster.io/api/v1"
+	"opensearch.opster.io/opensearch-gateway/requests"
+	"opensearch.opster.io/opensearch-gateway/responses"
+	"opensearch.opster.io/opensearch-gateway/services"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	snapshotPollInterval    = 30 * time.Second
+	snapshotMaxRequeueDelay = time.Hour
+	snapshotNameTimeFormat  = "20060102-150405"
+)
+
+type SnapshotPolicyReconciler struct {
+	client.Client
+	reconciler.ResourceReconciler
+	ctx      context.Context
+	osClient *services.OsClusterClient
+	recorder record.EventRecorder
+	instance *opsterv1.OpenSearchSnapshotPolicy
+	logger   logr.Logger
+}
+
+func NewSnapshotPolicyReconciler(
+	client client.Client,
+	ctx context.Context,
+	recorder record.EventRecorder,
+	instance *opsterv1.OpenSearchSnapshotPolicy,
+	opts ...reconciler.ResourceReconcilerOption,
+) *SnapshotPolicyReconciler {
+	return &SnapshotPolicyReconciler{
+		Client: client,
+		ResourceReconciler: reconciler.NewReconcilerWith(client,
+			append(opts, reconciler.WithLog(log.FromContext(ctx).WithValues("reconciler", "snapshotpolicy")))...),
+		ctx:      ctx,
+		recorder: recorder,
+		instance: instance,
+		logger:   log.FromContext(ctx).WithValues("reconciler", "snapshotpolicy"),
+	}
+}
+
+func (r *SnapshotPolicyReconciler) Reconcile() (ctrl.Result, error) {
+	cluster, err := fetchReadyCluster(r.ctx, r.Client, r.instance.Namespace, r.instance.Spec.OpensearchRef)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if cluster == nil {
+		return r.waitForCluster("waiting for opensearch cluster to be initialized")
+	}
+
+	schedule, err := cron.ParseStandard(r.instance.Spec.Schedule)
+	if err != nil {
+		r.recorder.Event(r.instance, "Warning", "invalid schedule", err.Error())
+		// No requeue, a change to the schedule will trigger a new reconcile
+		return ctrl.Result{}, r.updateStatus(func(status *opsterv1.OpenSearchSnapshotPolicyStatus) {
+			status.Phase = opsterv1.SnapshotPolicyPhaseError
+			status.Reason = fmt.Sprintf("invalid schedule: %s", err)
+		})
+	}
+
+	r.osClient, err = newOsClientForCluster(r.ctx, r.Client, cluster)
+	if err != nil {
+		r.logger.Error(err, "failed to create os client")
+		return r.waitForCluster("failed to connect to opensearch cluster")
+	}
+
+	repository := r.instance.Spec.Repository
+	changed, err := services.EnsureSnapshotRepository(r.ctx, r.osClient, repository.Name, requests.SnapshotRepository{
+		Type:     repository.Type,
+		Settings: repository.Settings,
+	})
+	if err != nil {
+		r.recorder.Eventf(r.instance, "Warning", "repository failed", "failed to register snapshot repository %s: %s", repository.Name, err)
+		statusErr := r.updateStatus(func(status *opsterv1.OpenSearchSnapshotPolicyStatus) {
+			status.Phase = opsterv1.SnapshotPolicyPhaseError
+			status.Reason = "failed to register snapshot repository"
+			status.RepositoryRegistered = false
+		})
+		if statusErr != nil {
+			return ctrl.Result{}, statusErr
+		}
+		return ctrl.Result{}, err
+	}
+	if changed {
+		r.recorder.Eventf(r.instance, "Normal", "repository registered", "registered snapshot repository %s", repository.Name)
+	}
+
+	status := r.instance.Status.DeepCopy()
+	status.Phase = opsterv1.SnapshotPolicyPhaseRunning
+	status.Reason = ""
+	status.RepositoryRegistered = true
+
+	if err := r.trackLastSnapshot(status); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	now := time.Now()
+	next := r.nextSnapshotTime(schedule, status)
+	if !r.instance.Spec.Suspend && !now.Before(next) && status.LastSnapshotState != services.SnapshotStateInProgress {
+		if err := r.takeSnapshot(status, now); err != nil {
+			return ctrl.Result{}, err
+		}
+		next = schedule.Next(now)
+	}
+	if r.instance.Spec.Suspend {
+		status.NextSnapshotTime = nil
+	} else {
+		status.NextSnapshotTime = &metav1.Time{Time: next}
+	}
+
+	pendingDeletes, err := r.applyRetention(status, now)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if err := r.updateStatus(func(s *opsterv1.OpenSearchSnapshotPolicyStatus) {
+		*s = *status
+	}); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if pendingDeletes || status.LastSnapshotState == services.SnapshotStateInProgress {
+		return ctrl.Result{Requeue: true, RequeueAfter: snapshotPollInterval}, nil
+	}
+	requeueAfter := time.Until(next)
+	if r.instance.Spec.Suspend || requeueAfter > snapshotMaxRequeueDelay {
+		requeueAfter = snapshotMaxRequeueDelay
+	}
+	return ctrl.Result{Requeue: true, RequeueAfter: requeueAfter}, nil
+}
+
+func (r *SnapshotPolicyReconciler) waitForCluster(reason string) (ctrl.Result, error) {
+	err := r.updateStatus(func(status *opsterv1.OpenSearchSnapshotPolicyStatus) {
+		status.Phase = opsterv1.SnapshotPolicyPhasePending
+		status.Reason = reason
+	})
+	return ctrl.Result{Requeue: true, RequeueAfter: snapshotPollInterval}, err
+}
+
+// trackLastSnapshot updates the state of the last snapshot taken by the policy while it is running
+func (r *SnapshotPolicyReconciler) trackLastSnapshot(status *opsterv1.OpenSearchSnapshotPolicyStatus) error {
+	if status.LastSnapshotName == "" || status.LastSnapshotState != services.SnapshotStateInProgress {
+		return nil
+	}
+	snapshot, found, err := services.GetSnapshot(r.ctx, r.osClient, r.instance.Spec.Repository.Name, status.LastSnapshotName)
+	if err != nil {
+		return err
+	}
+	if !found {
+		r.recorder.Eventf(r.instance, "Warning", "snapshot missing", "snapshot %s is missing from the repository", status.LastSnapshotName)
+		status.LastSnapshotState = services.SnapshotStateFailed
+		return nil
+	}
+	if snapshot.State == status.LastSnapshotState {
+		return nil
+	}
+	status.LastSnapshotState = snapshot.State
+	switch snapshot.State {
+	case services.SnapshotStateSuccess:
+		r.recorder.Eventf(r.instance, "Normal", "snapshot completed", "snapshot %s completed", snapshot.Snapshot)
+	case services.SnapshotStateInProgress:
+	default:
+		r.recorder.Eventf(r.instance, "Warning", "snapshot failed", "snapshot %s finished with state %s", snapshot.Snapshot, snapshot.State)
+	}
+	return nil
+}
+
+// nextSnapshotTime calculates when the next snapshot is due based on the last one that was taken
+func (r *SnapshotPolicyReconciler) nextSnapshotTime(schedule cron.Schedule, status *opsterv1.OpenSearchSnapshotPolicyStatus) time.Time {
+	last := r.instance.CreationTimestamp.Time
+	if status.LastSnapshotTime != nil {
+		last = status.LastSnapshotTime.Time
+	}
+	return schedule.Next(last)
+}
+
+func (r *SnapshotPolicyReconciler) takeSnapshot(status *opsterv1.OpenSearchSnapshotPolicyStatus, now time.Time) error {
+	name := fmt.Sprintf("%s-%s", r.snapshotPrefix(), now.UTC().Format(snapshotNameTimeFormat))
+	settings := requests.CreateSnapshot{
+		Indices:            strings.Join(r.instance.Spec.Indices, ","),
+		IgnoreUnavailable:  true,
+		IncludeGlobalState: r.instance.Spec.IncludeGlobalState,
+	}
+	if err := r.osClient.CreateSnapshot(r.ctx, r.instance.Spec.Repository.Name, name, settings); err != nil {
+		r.recorder.Eventf(r.instance, "Warning", "snapshot failed", "failed to start snapshot %s: %s", name, err)
+		return err
+	}
+	r.recorder.Eventf(r.instance, "Normal", "snapshot started", "started snapshot %s", name)
+	status.LastSnapshotName = name
+	status.LastSnapshotState = services.SnapshotStateInProgress
+	status.LastSnapshotTime = &metav1.Time{Time: now}
+	return nil
+}
+
+// applyRetention deletes the oldest expired snapshot of the policy.
+// Only one snapshot is deleted per pass, the first return value signals that more are waiting for deletion.
+func (r *SnapshotPolicyReconciler) applyRetention(status *opsterv1.OpenSearchSnapshotPolicyStatus, now time.Time) (bool, error) {
+	snapshots, err := services.GetSnapshotsWithPrefix(r.ctx, r.osClient, r.instance.Spec.Repository.Name, r.snapshotPrefix()+"-")
+	if err != nil {
+		return false, err
+	}
+	status.Snapshots = int32(len(snapshots))
+
+	// Never delete snapshots while one is running
+	if status.LastSnapshotState == services.SnapshotStateInProgress {
+		return false, nil
+	}
+
+	expired := ExpiredSnapshots(snapshots, r.instance.Spec.Retention, now)
+	if len(expired) == 0 {
+		return false, nil
+	}
+	if err := r.osClient.DeleteSnapshot(r.ctx, r.instance.Spec.Repository.Name, expired[0]); err != nil {
+		r.recorder.Eventf(r.instance, "Warning", "retention failed", "failed to delete snapshot %s: %s", expired[0], err)
+		return false, err
+	}
+	r.recorder.Eventf(r.instance, "Normal", "snapshot deleted", "deleted snapshot %s because of retention", expired[0])
+	status.Snapshots--
+	return len(expired) > 1, nil
+}
+
+func (r *SnapshotPolicyReconciler) snapshotPrefix() string {
+	if r.instance.Spec.SnapshotPrefix != "" {
+		return strings.ToLower(r.instance.Spec.SnapshotPrefix)
+	}
+	return r.instance.Name
+}
+
+func (r *SnapshotPolicyReconciler) updateStatus(update func(*opsterv1.OpenSearchSnapshotPolicyStatus)) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if err := r.Get(r.ctx, client.ObjectKeyFromObject(r.instance), r.instance); err != nil {
+			return err
+		}
+		update(&r.instance.Status)
+		return r.Status().Update(r.ctx, r.instance)
+	})
+}
+
+// ExpiredSnapshots returns the names of all snapshots that should be deleted according to the retention, oldest first.
+// The snapshots must be sorted oldest first. Running snapshots and the newest successful snapshot are never returned.
+func ExpiredSnapshots(snapshots []responses.SnapshotResponse, retention opsterv1.SnapshotRetention, now time.Time) []string {
+	newestSuccess := -1
+	for i, snapshot := range snapshots {
+		if snapshot.State == services.SnapshotStateSuccess {
+			newestSuccess = i
+		}
+	}
+
+	var expired []string
+	for i, snapshot := range snapshots {
+		if snapshot.State == services.SnapshotStateInProgress || i == newestSuccess {
+			continue
+		}
+		tooMany := retention.MaxCount != nil && len(snapshots)-i > int(*retention.MaxCount)
+		tooOld := retention.MaxAge != nil &&
+			now.Sub(time.UnixMilli(snapshot.StartTimeInMillis)) > retention.MaxAge.Duration
+		if tooMany || tooOld {
+			expired = append(expired, snapshot.Snapshot)
+		}
+	}
+	return expired
+}
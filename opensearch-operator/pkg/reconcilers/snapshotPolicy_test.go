@@ -0,0 +1,95 @@
+package reconcilers
+
+import (
+	"context"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	opsterv1 "opensearch.opster.io/api/v1"
+	"opensearch.opster.io/opensearch-gateway/responses"
+	"opensearch.opster.io/opensearch-gateway/services"
+	"opensearch.opster.io/pkg/helpers"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("SnapshotPolicy Reconciler", func() {
+
+	const (
+		policyName = "snapshotpolicy-test"
+	)
+
+	Context("When reconciling a policy for a missing cluster", func() {
+		It("should set the policy to pending", func() {
+			Expect(CreateNamespace(k8sClient, policyName)).Should(Succeed())
+
+			policy := opsterv1.OpenSearchSnapshotPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      policyName,
+					Namespace: policyName,
+				},
+				Spec: opsterv1.OpenSearchSnapshotPolicySpec{
+					OpensearchRef: corev1.LocalObjectReference{Name: "missing"},
+					Repository: opsterv1.SnapshotRepository{
+						Name: "backups",
+						Type: "fs",
+					},
+					Schedule: "0 2 * * *",
+				},
+			}
+			Expect(k8sClient.Create(context.Background(), &policy)).Should(Succeed())
+
+			underTest := NewSnapshotPolicyReconciler(
+				k8sClient,
+				context.Background(),
+				&helpers.MockEventRecorder{},
+				&policy,
+			)
+			result, err := underTest.Reconcile()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.Requeue).To(BeTrue())
+
+			updated := opsterv1.OpenSearchSnapshotPolicy{}
+			Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(&policy), &updated)).Should(Succeed())
+			Expect(updated.Status.Phase).To(Equal(opsterv1.SnapshotPolicyPhasePending))
+		})
+	})
+
+	Context("When calculating expired snapshots", func() {
+		now := time.Now()
+		snapshot := func(name string, age time.Duration, state string) responses.SnapshotResponse {
+			return responses.SnapshotResponse{
+				Snapshot:          name,
+				State:             state,
+				StartTimeInMillis: now.Add(-age).UnixMilli(),
+			}
+		}
+		snapshots := []responses.SnapshotResponse{
+			snapshot("s1", 72*time.Hour, services.SnapshotStateSuccess),
+			snapshot("s2", 48*time.Hour, services.SnapshotStateFailed),
+			snapshot("s3", 24*time.Hour, services.SnapshotStateSuccess),
+			snapshot("s4", time.Minute, services.SnapshotStateInProgress),
+		}
+
+		It("should delete the oldest snapshots above the max count", func() {
+			maxCount := int32(2)
+			expired := ExpiredSnapshots(snapshots, opsterv1.SnapshotRetention{MaxCount: &maxCount}, now)
+			Expect(expired).To(Equal([]string{"s1", "s2"}))
+		})
+
+		It("should delete snapshots older than the max age", func() {
+			maxAge := metav1.Duration{Duration: 36 * time.Hour}
+			expired := ExpiredSnapshots(snapshots, opsterv1.SnapshotRetention{MaxAge: &maxAge}, now)
+			Expect(expired).To(Equal([]string{"s1", "s2"}))
+		})
+
+		It("should keep the newest successful snapshot", func() {
+			maxAge := metav1.Duration{Duration: time.Hour}
+			expired := ExpiredSnapshots(snapshots, opsterv1.SnapshotRetention{MaxAge: &maxAge}, now)
+			Expect(expired).To(Equal([]string{"s1", "s2"}))
+		})
+	})
+})
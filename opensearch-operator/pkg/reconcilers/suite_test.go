@@ -132,3 +132,32 @@ func CreateNamespace(k8sClient client.Client, name string) error {
 	ns := corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}}
 	return k8sClient.Create(context.Background(), &ns)
 }
+
+// CreateUnreachableCluster creates an initialized cluster without any nodes, requests to it fail
+func CreateUnreachableCluster(k8sClient client.Client, name string, namespace string) error {
+	cluster := opsterv1.OpenSearchCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: opsterv1.ClusterSpec{
+			General: opsterv1.GeneralConfig{
+				ServiceName: name,
+				HttpPort:    9200,
+			},
+			NodePools: []opsterv1.NodePool{
+				{
+					Component: "nodes",
+					Replicas:  1,
+					Roles:     []opsterv1.NodeRole{"master", "data"},
+				},
+			},
+		},
+	}
+	if err := k8sClient.Create(context.Background(), &cluster); err != nil {
+		return err
+	}
+	cluster.Status.Initialized = true
+	cluster.Status.ComponentsStatus = []opsterv1.ComponentStatus{}
+	return k8sClient.Status().Update(context.Background(), &cluster)
+}
@@ -1,15 +1,20 @@
 package reconcilers
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/banzaicloud/operator-tools/pkg/reconciler"
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/retry"
 	opsterv1 "opensearch.opster.io/api/v1"
 	"opensearch.opster.io/pkg/builders"
 	"opensearch.opster.io/pkg/helpers"
@@ -23,15 +28,20 @@ type TLSReconciler struct {
 	reconciler.ResourceReconciler
 	client.Client
 	ctx               context.Context
+	recorder          record.EventRecorder
 	reconcilerContext *ReconcilerContext
 	instance          *opsterv1.OpenSearchCluster
 	logger            logr.Logger
 	pki               tls.PKI
+	certificates      []opsterv1.CertificateStatus
+	// CA certificates trusted by the nodes, contains both the old and the new CA during a rotation
+	trustBundle []byte
 }
 
 func NewTLSReconciler(
 	client client.Client,
 	ctx context.Context,
+	recorder record.EventRecorder,
 	reconcilerContext *ReconcilerContext,
 	instance *opsterv1.OpenSearchCluster,
 	opts ...reconciler.ResourceReconcilerOption,
@@ -41,6 +51,7 @@ func NewTLSReconciler(
 		ResourceReconciler: reconciler.NewReconcilerWith(client,
 			append(opts, reconciler.WithLog(log.FromContext(ctx).WithValues("reconciler", "tls")))...),
 		ctx:               ctx,
+		recorder:          recorder,
 		reconcilerContext: reconcilerContext,
 		instance:          instance,
 		logger:            log.FromContext(ctx),
@@ -50,6 +61,11 @@ func NewTLSReconciler(
 
 const (
 	CaCertKey = "ca.crt"
+	// Set on secrets with generated certificates when the certificates were re-issued
+	CertificateRenewalAnnotation = "opster.io/certificate-renewal"
+
+	defaultCertificateValidity    = 365 * 24 * time.Hour
+	defaultCertificateRenewBefore = 30 * 24 * time.Hour
 )
 
 func (r *TLSReconciler) Reconcile() (ctrl.Result, error) {
@@ -60,24 +76,38 @@ func (r *TLSReconciler) Reconcile() (ctrl.Result, error) {
 	}
 
 	tlsConfig := r.instance.Spec.Security.Tls
+	if tlsConfig.Transport != nil && tlsConfig.Transport.Generate {
+		checkRenewalWindow(r.recorder, r.instance, "transport", tlsConfig.Transport.CertificateConfig)
+	}
+	if tlsConfig.Http != nil && tlsConfig.Http.Generate {
+		checkRenewalWindow(r.recorder, r.instance, "http", tlsConfig.Http.CertificateConfig)
+	}
+
+	if err := r.reconcileCaRotation(); err != nil {
+		return ctrl.Result{}, err
+	}
 
 	if tlsConfig.Transport != nil {
 		if err := r.handleTransport(); err != nil {
-			return ctrl.Result{}, err
+			return certificateResult(err, r.logger)
 		}
 	}
 	if tlsConfig.Http != nil {
 		if err := r.handleHttp(); err != nil {
-			return ctrl.Result{}, err
+			return certificateResult(err, r.logger)
 		}
 	}
 
-	return ctrl.Result{}, nil
+	return ctrl.Result{}, r.updateCertificateStatus()
 }
 
 func (r *TLSReconciler) handleTransport() error {
 	config := r.instance.Spec.Security.Tls.Transport
-	if config.Generate {
+	if config.CertificateConfig.CertManager != nil {
+		if err := r.handleTransportCertManager(); err != nil {
+			return err
+		}
+	} else if config.Generate {
 		if config.PerNode {
 			if err := r.handleTransportGeneratePerNode(); err != nil {
 				return err
@@ -92,8 +122,14 @@ func (r *TLSReconciler) handleTransport() error {
 			return err
 		}
 	}
-	err := r.handleAdminCertificate()
-	return err
+	// Clusters linked by cross-cluster replication or search trust each other's nodes
+	trustedCas, remoteNodesDn, err := r.handleRemoteClusterTrust()
+	if err != nil {
+		return err
+	}
+	r.reconcilerContext.AddConfig("plugins.security.nodes_dn", fmt.Sprintf("[\"%s\"]", strings.Join(append(transportNodesDn(r.instance), remoteNodesDn...), "\",\"")))
+	r.reconcilerContext.AddConfig("plugins.security.ssl.transport.pemtrustedcas_filepath", trustedCas)
+	return r.handleAdminCertificate()
 }
 
 func (r *TLSReconciler) handleAdminCertificate() error {
@@ -102,37 +138,43 @@ func (r *TLSReconciler) handleAdminCertificate() error {
 	clusterName := r.instance.Name
 	adminSecretName := clusterName + "-admin-cert"
 
-	if tlsConfig.Generate {
+	if tlsConfig.CertificateConfig.CertManager != nil {
+		if _, err := r.issueCertificate(clusterName+"-admin", adminSecretName, "admin", "admin", nil, tlsConfig.CertificateConfig); err != nil {
+			return err
+		}
+		r.reconcilerContext.AddConfig("plugins.security.authcz.admin_dn", fmt.Sprintf("[\"%s\"]", distinguishedName(tlsConfig.CertificateConfig, "admin", clusterName)))
+	} else if tlsConfig.Generate {
 		// Generate admin client certificate
 		var ca tls.Cert
 		var err error
 		if r.instance.Spec.Security.Tls.Transport.CertificateConfig.CaSecret.Name != "" {
 			ca, err = r.providedCaCert(r.instance.Spec.Security.Tls.Transport.CertificateConfig.CaSecret.Name, namespace)
 		} else {
-			ca, err = helpers.ReadOrGenerateCaCert(r.pki, r.Client, r.ctx, r.instance)
+			ca, err = r.generatedCaCert()
 		}
 		if err != nil {
 			return err
 		}
 
 		adminSecret := corev1.Secret{}
-		if err := r.Get(r.ctx, client.ObjectKey{Name: adminSecretName, Namespace: namespace}, &adminSecret); err != nil {
-			adminCert, err := ca.CreateAndSignCertificate("admin", clusterName, nil)
+		exists := r.Get(r.ctx, client.ObjectKey{Name: adminSecretName, Namespace: namespace}, &adminSecret) == nil
+		if !exists || certificateNeedsRenewal(adminSecret.Data[corev1.TLSCertKey], ca, tlsConfig.CertificateConfig) {
+			adminCert, err := createAndSignCertificate(ca, tlsConfig.CertificateConfig, "admin", clusterName, nil)
 			if err != nil {
 				r.logger.Error(err, "Failed to create admin certificate", "interface", "transport")
 				return err
 			}
-			adminSecret = corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: adminSecretName, Namespace: namespace}, Type: corev1.SecretTypeTLS, Data: adminCert.SecretData(ca)}
-			if err := ctrl.SetControllerReference(r.instance, &adminSecret, r.Client.Scheme()); err != nil {
-				return err
-			}
-			if err := r.Create(r.ctx, &adminSecret); err != nil {
+			if err := r.storeCertificateSecret(&adminSecret, adminSecretName, exists, adminCert, ca); err != nil {
 				r.logger.Error(err, "Failed to store admin certificate in secret", "interface", "transport")
 				return err
 			}
+		} else if err := r.updateTrustBundle(&adminSecret, ca); err != nil {
+			return err
 		}
+		// The admin certificate is only used by the operator, renewing it does not require a restart
+		r.trackCertificate("admin", &adminSecret, adminSecret.Data[corev1.TLSCertKey], false)
 		// Add admin_dn to config
-		r.reconcilerContext.AddConfig("plugins.security.authcz.admin_dn", fmt.Sprintf("[\"CN=admin,OU=%s\"]", clusterName))
+		r.reconcilerContext.AddConfig("plugins.security.authcz.admin_dn", fmt.Sprintf("[\"%s\"]", distinguishedName(tlsConfig.CertificateConfig, "admin", clusterName)))
 	} else {
 		// Add provided admin_dn to config
 		adminDn := strings.Join(tlsConfig.AdminDn, "\",\"")
@@ -153,15 +195,17 @@ func (r *TLSReconciler) handleTransportGenerateGlobal() error {
 	if r.instance.Spec.Security.Tls.Transport.CertificateConfig.CaSecret.Name != "" {
 		ca, err = r.providedCaCert(r.instance.Spec.Security.Tls.Transport.CertificateConfig.CaSecret.Name, namespace)
 	} else {
-		ca, err = helpers.ReadOrGenerateCaCert(r.pki, r.Client, r.ctx, r.instance)
+		ca, err = r.generatedCaCert()
 	}
 	if err != nil {
 		return err
 	}
 
 	// Generate node cert, sign it and put it into secret
+	certificateConfig := r.instance.Spec.Security.Tls.Transport.CertificateConfig
 	nodeSecret := corev1.Secret{}
-	if err := r.Get(r.ctx, client.ObjectKey{Name: nodeSecretName, Namespace: namespace}, &nodeSecret); err != nil {
+	exists := r.Get(r.ctx, client.ObjectKey{Name: nodeSecretName, Namespace: namespace}, &nodeSecret) == nil
+	if !exists || certificateNeedsRenewal(nodeSecret.Data[corev1.TLSCertKey], ca, certificateConfig) {
 		// Generate node cert and put it into secret
 		dnsNames := []string{
 			clusterName,
@@ -169,30 +213,27 @@ func (r *TLSReconciler) handleTransportGenerateGlobal() error {
 			fmt.Sprintf("%s.%s.svc", clusterName, namespace),
 			fmt.Sprintf("%s.%s.svc.cluster.local", clusterName, namespace),
 		}
-		nodeCert, err := ca.CreateAndSignCertificate(clusterName, clusterName, dnsNames)
+		nodeCert, err := createAndSignCertificate(ca, certificateConfig, clusterName, clusterName, dnsNames)
 		if err != nil {
 			r.logger.Error(err, "Failed to create node certificate", "interface", "transport")
 			return err
 		}
-		nodeSecret = corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: nodeSecretName, Namespace: namespace}, Type: corev1.SecretTypeTLS, Data: nodeCert.SecretData(ca)}
-		if err := ctrl.SetControllerReference(r.instance, &nodeSecret, r.Client.Scheme()); err != nil {
-			return err
-		}
-		if err := r.Create(r.ctx, &nodeSecret); err != nil {
+		if err := r.storeCertificateSecret(&nodeSecret, nodeSecretName, exists, nodeCert, ca); err != nil {
 			r.logger.Error(err, "Failed to store node certificate in secret", "interface", "transport")
 			return err
 		}
+	} else if err := r.updateTrustBundle(&nodeSecret, ca); err != nil {
+		return err
 	}
+	r.trackCertificate("transport", &nodeSecret, nodeSecret.Data[corev1.TLSCertKey], true)
 	// Tell cluster controller to mount secrets
 	volume := corev1.Volume{Name: "transport-cert", VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{SecretName: nodeSecretName}}}
 	r.reconcilerContext.Volumes = append(r.reconcilerContext.Volumes, volume)
 	mount := corev1.VolumeMount{Name: "transport-cert", MountPath: "/usr/share/opensearch/config/tls-transport"}
 	r.reconcilerContext.VolumeMounts = append(r.reconcilerContext.VolumeMounts, mount)
 	// Extend opensearch.yml
-	r.reconcilerContext.AddConfig("plugins.security.nodes_dn", fmt.Sprintf("[\"CN=%s,OU=%s\"]", clusterName, clusterName))
 	r.reconcilerContext.AddConfig("plugins.security.ssl.transport.pemcert_filepath", fmt.Sprintf("tls-transport/%s", corev1.TLSCertKey))
 	r.reconcilerContext.AddConfig("plugins.security.ssl.transport.pemkey_filepath", fmt.Sprintf("tls-transport/%s", corev1.TLSPrivateKeyKey))
-	r.reconcilerContext.AddConfig("plugins.security.ssl.transport.pemtrustedcas_filepath", fmt.Sprintf("tls-transport/%s", CaCertKey))
 	r.reconcilerContext.AddConfig("plugins.security.ssl.transport.enforce_hostname_verification", "false")
 	return nil
 }
@@ -209,7 +250,7 @@ func (r *TLSReconciler) handleTransportGeneratePerNode() error {
 	if r.instance.Spec.Security.Tls.Transport.CertificateConfig.CaSecret.Name != "" {
 		ca, err = r.providedCaCert(r.instance.Spec.Security.Tls.Transport.CertificateConfig.CaSecret.Name, namespace)
 	} else {
-		ca, err = helpers.ReadOrGenerateCaCert(r.pki, r.Client, r.ctx, r.instance)
+		ca, err = r.generatedCaCert()
 	}
 	if err != nil {
 		return err
@@ -222,7 +263,7 @@ func (r *TLSReconciler) handleTransportGeneratePerNode() error {
 		nodeSecret.ObjectMeta = metav1.ObjectMeta{Name: nodeSecretName, Namespace: namespace}
 		exists = false
 	}
-	nodeSecret.Data[CaCertKey] = ca.CertData()
+	nodeSecret.Data[CaCertKey] = r.caBundle(ca)
 
 	// Generate bootstrap pod cert
 	bootstrapPodName := builders.BootstrapPodName(r.instance)
@@ -230,19 +271,7 @@ func (r *TLSReconciler) handleTransportGeneratePerNode() error {
 	_, bootstrapKeyExists := nodeSecret.Data[fmt.Sprintf("%s.key", bootstrapPodName)]
 
 	if !r.instance.Status.Initialized && !(bootstrapCertExists && bootstrapKeyExists) {
-		dnsNames := []string{
-			bootstrapPodName,
-			clusterName,
-			builders.DiscoveryServiceName(r.instance),
-			fmt.Sprintf("%s.%s", bootstrapPodName, clusterName),
-			fmt.Sprintf("%s.%s", clusterName, namespace),
-			fmt.Sprintf("%s.%s.%s", bootstrapPodName, clusterName, namespace),
-			fmt.Sprintf("%s.%s.svc", clusterName, namespace),
-			fmt.Sprintf("%s.%s.%s.svc", bootstrapPodName, clusterName, namespace),
-			fmt.Sprintf("%s.%s.svc.cluster.local", clusterName, namespace),
-			fmt.Sprintf("%s.%s.%s.svc.cluster.local", bootstrapPodName, clusterName, namespace),
-		}
-		nodeCert, err := ca.CreateAndSignCertificate(bootstrapPodName, clusterName, dnsNames)
+		nodeCert, err := createAndSignCertificate(ca, r.instance.Spec.Security.Tls.Transport.CertificateConfig, bootstrapPodName, clusterName, podDnsNames(r.instance, bootstrapPodName))
 		if err != nil {
 			r.logger.Error(err, "Failed to create node certificate", "interface", "transport", "node", bootstrapPodName)
 			return err
@@ -252,37 +281,35 @@ func (r *TLSReconciler) handleTransportGeneratePerNode() error {
 	}
 
 	// Generate node cert and put it into secret
+	certificateConfig := r.instance.Spec.Security.Tls.Transport.CertificateConfig
+	renewed := false
+	var earliestCert []byte
 	for _, nodePool := range r.instance.Spec.NodePools {
-		for i := 0; i < int(nodePool.Replicas); i++ {
-			podName := fmt.Sprintf("%s-%s-%d", clusterName, nodePool.Component, i)
+		for _, podName := range builders.NodePoolPodNames(r.instance, &nodePool) {
 			certName := fmt.Sprintf("%s.crt", podName)
 			keyName := fmt.Sprintf("%s.key", podName)
 			_, certExists := nodeSecret.Data[certName]
 			_, keyExists := nodeSecret.Data[keyName]
 			if certExists && keyExists {
-				continue
-			}
-			dnsNames := []string{
-				podName,
-				clusterName,
-				builders.DiscoveryServiceName(r.instance),
-				fmt.Sprintf("%s.%s", podName, clusterName),
-				fmt.Sprintf("%s.%s", clusterName, namespace),
-				fmt.Sprintf("%s.%s.%s", podName, clusterName, namespace),
-				fmt.Sprintf("%s.%s.svc", clusterName, namespace),
-				fmt.Sprintf("%s.%s.%s.svc", podName, clusterName, namespace),
-				fmt.Sprintf("%s.%s.svc.cluster.local", clusterName, namespace),
-				fmt.Sprintf("%s.%s.%s.svc.cluster.local", podName, clusterName, namespace),
+				if !certificateNeedsRenewal(nodeSecret.Data[certName], ca, certificateConfig) {
+					earliestCert = earlierCertificate(earliestCert, nodeSecret.Data[certName])
+					continue
+				}
+				renewed = true
 			}
-			nodeCert, err := ca.CreateAndSignCertificate(podName, clusterName, dnsNames)
+			nodeCert, err := createAndSignCertificate(ca, certificateConfig, podName, clusterName, podDnsNames(r.instance, podName))
 			if err != nil {
 				r.logger.Error(err, "Failed to create node certificate", "interface", "transport", "node", podName)
 				return err
 			}
 			nodeSecret.Data[certName] = nodeCert.CertData()
 			nodeSecret.Data[keyName] = nodeCert.KeyData()
+			earliestCert = earlierCertificate(earliestCert, nodeCert.CertData())
 		}
 	}
+	if renewed {
+		setRenewalAnnotation(&nodeSecret)
+	}
 	if exists {
 		if err := r.Update(r.ctx, &nodeSecret); err != nil {
 			r.logger.Error(err, "Failed to store node certificate in secret", "interface", "transport")
@@ -297,6 +324,8 @@ func (r *TLSReconciler) handleTransportGeneratePerNode() error {
 			return err
 		}
 	}
+	r.trackCertificate("transport", &nodeSecret, earliestCert, true)
+
 	// Tell cluster controller to mount secrets
 	volume := corev1.Volume{Name: "transport-cert", VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{SecretName: nodeSecretName}}}
 	r.reconcilerContext.Volumes = append(r.reconcilerContext.Volumes, volume)
@@ -304,10 +333,8 @@ func (r *TLSReconciler) handleTransportGeneratePerNode() error {
 	r.reconcilerContext.VolumeMounts = append(r.reconcilerContext.VolumeMounts, mount)
 
 	// Extend opensearch.yml
-	r.reconcilerContext.AddConfig("plugins.security.nodes_dn", fmt.Sprintf("[\"CN=%s-*,OU=%s\"]", clusterName, clusterName))
 	r.reconcilerContext.AddConfig("plugins.security.ssl.transport.pemcert_filepath", "tls-transport/${HOSTNAME}.crt")
 	r.reconcilerContext.AddConfig("plugins.security.ssl.transport.pemkey_filepath", "tls-transport/${HOSTNAME}.key")
-	r.reconcilerContext.AddConfig("plugins.security.ssl.transport.pemtrustedcas_filepath", fmt.Sprintf("tls-transport/%s", CaCertKey))
 	r.reconcilerContext.AddConfig("plugins.security.ssl.transport.enforce_hostname_verification", "true")
 	return nil
 }
@@ -331,21 +358,12 @@ func (r *TLSReconciler) handleTransportExistingCerts() error {
 			r.logger.Error(err, "Not all secrets for transport provided")
 			return err
 		}
-		if tlsConfig.CertificateConfig.CaSecret.Name == "" {
-			mountFolder("transport", "certs", tlsConfig.CertificateConfig.Secret.Name, r.reconcilerContext)
-		} else {
-			mount("transport", "ca", CaCertKey, tlsConfig.CertificateConfig.CaSecret.Name, r.reconcilerContext)
-			mount("transport", "key", corev1.TLSPrivateKeyKey, tlsConfig.CertificateConfig.Secret.Name, r.reconcilerContext)
-			mount("transport", "cert", corev1.TLSCertKey, tlsConfig.CertificateConfig.Secret.Name, r.reconcilerContext)
-		}
+		mountCertificates("transport", tlsConfig.CertificateConfig.Secret.Name, tlsConfig.CertificateConfig.CaSecret.Name, r.reconcilerContext)
 		// Extend opensearch.yml
 		r.reconcilerContext.AddConfig("plugins.security.ssl.transport.pemcert_filepath", fmt.Sprintf("tls-transport/%s", corev1.TLSCertKey))
 		r.reconcilerContext.AddConfig("plugins.security.ssl.transport.pemkey_filepath", fmt.Sprintf("tls-transport/%s", corev1.TLSPrivateKeyKey))
 		r.reconcilerContext.AddConfig("plugins.security.ssl.transport.enforce_hostname_verification", "false")
 	}
-	r.reconcilerContext.AddConfig("plugins.security.ssl.transport.pemtrustedcas_filepath", fmt.Sprintf("tls-transport/%s", CaCertKey))
-	dnList := strings.Join(tlsConfig.NodesDn, "\",\"")
-	r.reconcilerContext.AddConfig("plugins.security.nodes_dn", fmt.Sprintf("[\"%s\"]", dnList))
 	return nil
 }
 
@@ -355,7 +373,15 @@ func (r *TLSReconciler) handleHttp() error {
 	clusterName := r.instance.Name
 	nodeSecretName := clusterName + "-http-cert"
 
-	if tlsConfig.Generate {
+	if tlsConfig.CertificateConfig.CertManager != nil {
+		r.logger.Info("Issuing certificates with cert-manager", "interface", "http")
+		issued, err := r.issueCertificate(clusterName+"-http", nodeSecretName, "http", clusterName, r.httpDnsNames(), tlsConfig.CertificateConfig)
+		if err != nil {
+			return err
+		}
+		r.addCertManagerRevision("http", issued.renewals)
+		mountCertificates("http", nodeSecretName, tlsConfig.CertificateConfig.CaSecret.Name, r.reconcilerContext)
+	} else if tlsConfig.Generate {
 		r.logger.Info("Generating certificates", "interface", "http")
 
 		var ca tls.Cert
@@ -363,7 +389,7 @@ func (r *TLSReconciler) handleHttp() error {
 		if r.instance.Spec.Security.Tls.Http.CertificateConfig.CaSecret.Name != "" {
 			ca, err = r.providedCaCert(r.instance.Spec.Security.Tls.Http.CertificateConfig.CaSecret.Name, namespace)
 		} else {
-			ca, err = helpers.ReadOrGenerateCaCert(r.pki, r.Client, r.ctx, r.instance)
+			ca, err = r.generatedCaCert()
 		}
 		if err != nil {
 			return err
@@ -371,30 +397,23 @@ func (r *TLSReconciler) handleHttp() error {
 
 		// Generate node cert, sign it and put it into secret
 		nodeSecret := corev1.Secret{}
-		if err := r.Get(r.ctx, client.ObjectKey{Name: nodeSecretName, Namespace: namespace}, &nodeSecret); err != nil {
+		exists := r.Get(r.ctx, client.ObjectKey{Name: nodeSecretName, Namespace: namespace}, &nodeSecret) == nil
+		if !exists || certificateNeedsRenewal(nodeSecret.Data[corev1.TLSCertKey], ca, tlsConfig.CertificateConfig) ||
+			!certificateCoversDnsNames(nodeSecret.Data[corev1.TLSCertKey], r.httpDnsNames()) {
 			// Generate node cert and put it into secret
-			dnsNames := []string{
-				clusterName,
-				r.instance.Spec.General.ServiceName,
-				builders.DiscoveryServiceName(r.instance),
-				fmt.Sprintf("%s.%s", clusterName, namespace),
-				fmt.Sprintf("%s.%s.svc", clusterName, namespace),
-				fmt.Sprintf("%s.%s.svc.cluster.local", clusterName, namespace),
-			}
-			nodeCert, err := ca.CreateAndSignCertificate(clusterName, clusterName, dnsNames)
+			nodeCert, err := createAndSignCertificate(ca, tlsConfig.CertificateConfig, clusterName, clusterName, r.httpDnsNames())
 			if err != nil {
 				r.logger.Error(err, "Failed to create node certificate", "interface", "http")
 				return err
 			}
-			nodeSecret = corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: nodeSecretName, Namespace: namespace}, Type: corev1.SecretTypeTLS, Data: nodeCert.SecretData(ca)}
-			if err := ctrl.SetControllerReference(r.instance, &nodeSecret, r.Client.Scheme()); err != nil {
-				return err
-			}
-			if err := r.Create(r.ctx, &nodeSecret); err != nil {
+			if err := r.storeCertificateSecret(&nodeSecret, nodeSecretName, exists, nodeCert, ca); err != nil {
 				r.logger.Error(err, "Failed to store node certificate in secret", "interface", "http")
 				return err
 			}
+		} else if err := r.updateTrustBundle(&nodeSecret, ca); err != nil {
+			return err
 		}
+		r.trackCertificate("http", &nodeSecret, nodeSecret.Data[corev1.TLSCertKey], true)
 		// Tell cluster controller to mount secrets
 		volume := corev1.Volume{Name: "http-cert", VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{SecretName: nodeSecretName}}}
 		r.reconcilerContext.Volumes = append(r.reconcilerContext.Volumes, volume)
@@ -406,13 +425,7 @@ func (r *TLSReconciler) handleHttp() error {
 			r.logger.Error(err, "Not all secrets for http provided")
 			return err
 		}
-		if tlsConfig.CertificateConfig.CaSecret.Name == "" {
-			mountFolder("http", "certs", tlsConfig.CertificateConfig.Secret.Name, r.reconcilerContext)
-		} else {
-			mount("http", "ca", CaCertKey, tlsConfig.CertificateConfig.CaSecret.Name, r.reconcilerContext)
-			mount("http", "key", corev1.TLSPrivateKeyKey, tlsConfig.CertificateConfig.Secret.Name, r.reconcilerContext)
-			mount("http", "cert", corev1.TLSCertKey, tlsConfig.CertificateConfig.Secret.Name, r.reconcilerContext)
-		}
+		mountCertificates("http", tlsConfig.CertificateConfig.Secret.Name, tlsConfig.CertificateConfig.CaSecret.Name, r.reconcilerContext)
 	}
 	// Extend opensearch.yml
 	r.reconcilerContext.AddConfig("plugins.security.ssl.http.enabled", "true")
@@ -422,6 +435,18 @@ func (r *TLSReconciler) handleHttp() error {
 	return nil
 }
 
+// generatedCaCert reads or generates the CA managed by the operator and tracks its expiry.
+// The CA itself is not renewed, it is valid much longer than the certificates signed by it.
+func (r *TLSReconciler) generatedCaCert() (tls.Cert, error) {
+	ca, err := helpers.ReadOrGenerateCaCert(r.pki, r.Client, r.ctx, r.instance)
+	if err != nil {
+		return ca, err
+	}
+	caSecret := corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: r.caSecretName(), Namespace: r.instance.Namespace}}
+	r.trackCertificate("ca", &caSecret, ca.CertData(), false)
+	return ca, nil
+}
+
 func (r *TLSReconciler) providedCaCert(secretName string, namespace string) (tls.Cert, error) {
 	var ca tls.Cert
 	caSecret := corev1.Secret{}
@@ -432,6 +457,270 @@ func (r *TLSReconciler) providedCaCert(secretName string, namespace string) (tls
 	return ca, nil
 }
 
+// storeCertificateSecret creates the secret for a generated certificate or updates it if the certificate was re-issued
+func (r *TLSReconciler) storeCertificateSecret(secret *corev1.Secret, name string, exists bool, cert tls.Cert, ca tls.Cert) error {
+	data := cert.SecretData(ca)
+	data[CaCertKey] = r.caBundle(ca)
+	if exists {
+		r.logger.Info("Renewing certificate", "secret", name)
+		secret.Data = data
+		setRenewalAnnotation(secret)
+		return r.Update(r.ctx, secret)
+	}
+	*secret = corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: r.instance.Namespace}, Type: corev1.SecretTypeTLS, Data: data}
+	if err := ctrl.SetControllerReference(r.instance, secret, r.Client.Scheme()); err != nil {
+		return err
+	}
+	return r.Create(r.ctx, secret)
+}
+
+// updateTrustBundle updates the CA certificates of a secret that was not re-issued, e.g. during a CA rotation
+func (r *TLSReconciler) updateTrustBundle(secret *corev1.Secret, ca tls.Cert) error {
+	bundle := r.caBundle(ca)
+	if bytes.Equal(secret.Data[CaCertKey], bundle) {
+		return nil
+	}
+	secret.Data[CaCertKey] = bundle
+	return r.Update(r.ctx, secret)
+}
+
+// certificateNeedsRenewal checks if the certificate expires within the renewal window.
+// Certificates that can not be parsed are never renewed.
+// Certificates not signed by the CA, e.g. after it was rotated, are always renewed.
+func certificateNeedsRenewal(certPEM []byte, ca tls.Cert, config opsterv1.TlsCertificateConfig) bool {
+	notAfter, err := tls.CertificateNotAfter(certPEM)
+	if err != nil {
+		return false
+	}
+	if signed, err := tls.CertificateSignedBy(certPEM, ca.CertData()); err == nil && !signed {
+		return true
+	}
+	return CertificateNeedsRenewal(notAfter, certificateRenewBefore(config), time.Now())
+}
+
+// trackCertificate records the expiry of a generated certificate in the status.
+// If restart is true, re-issuing the certificate triggers a rolling restart of the cluster.
+func (r *TLSReconciler) trackCertificate(name string, secret *corev1.Secret, certPEM []byte, restart bool) {
+	renewal, renewed := secret.Annotations[CertificateRenewalAnnotation]
+	if restart && renewed {
+		r.reconcilerContext.AddCertificateRevision(name, renewal)
+	}
+	notAfter, err := tls.CertificateNotAfter(certPEM)
+	if err != nil {
+		return
+	}
+	for _, tracked := range r.certificates {
+		if tracked.Name == name {
+			return
+		}
+	}
+	status := opsterv1.CertificateStatus{
+		Name:     name,
+		Secret:   secret.Name,
+		NotAfter: &metav1.Time{Time: notAfter},
+	}
+	if renewalTime, err := time.Parse(time.RFC3339, renewal); renewed && err == nil {
+		status.LastRenewalTime = &metav1.Time{Time: renewalTime}
+	}
+	r.certificates = append(r.certificates, status)
+}
+
+func (r *TLSReconciler) updateCertificateStatus() error {
+	if certificateStatusesEqual(r.instance.Status.Certificates, r.certificates) {
+		return nil
+	}
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if err := r.Get(r.ctx, client.ObjectKeyFromObject(r.instance), r.instance); err != nil {
+			return err
+		}
+		r.instance.Status.Certificates = r.certificates
+		return r.Status().Update(r.ctx, r.instance)
+	})
+}
+
+// CertificateNeedsRenewal checks if a certificate expiring at notAfter is inside the renewal window
+func CertificateNeedsRenewal(notAfter time.Time, renewBefore time.Duration, now time.Time) bool {
+	return !now.Add(renewBefore).Before(notAfter)
+}
+
+func certificateValidity(config opsterv1.TlsCertificateConfig) time.Duration {
+	if config.Duration != nil {
+		return config.Duration.Duration
+	}
+	return defaultCertificateValidity
+}
+
+// certificateRenewBefore returns the renewal window of generated certificates. A window that is not shorter than the
+// validity would renew every certificate right after it was issued, it is reduced to a third of the validity then.
+func certificateRenewBefore(config opsterv1.TlsCertificateConfig) time.Duration {
+	if !renewalWindowValid(config) {
+		return certificateValidity(config) / 3
+	}
+	if config.RenewBefore != nil {
+		return config.RenewBefore.Duration
+	}
+	return defaultCertificateRenewBefore
+}
+
+// renewalWindowValid checks that the configured renewal window is shorter than the validity of generated certificates
+func renewalWindowValid(config opsterv1.TlsCertificateConfig) bool {
+	renewBefore := defaultCertificateRenewBefore
+	if config.RenewBefore != nil {
+		renewBefore = config.RenewBefore.Duration
+	}
+	return renewBefore < certificateValidity(config)
+}
+
+// checkRenewalWindow warns about certificate configs whose renewal window is reduced by certificateRenewBefore
+func checkRenewalWindow(recorder record.EventRecorder, instance *opsterv1.OpenSearchCluster, name string, config opsterv1.TlsCertificateConfig) {
+	if config.CertManager != nil || renewalWindowValid(config) {
+		return
+	}
+	recorder.Eventf(instance, "Warning", "invalid certificate config", "renewBefore of the %s certificates is not shorter than their duration %s, renewing them %s before they expire instead", name, certificateValidity(config), certificateRenewBefore(config))
+}
+
+func setRenewalAnnotation(secret *corev1.Secret) {
+	if secret.Annotations == nil {
+		secret.Annotations = map[string]string{}
+	}
+	secret.Annotations[CertificateRenewalAnnotation] = time.Now().UTC().Format(time.RFC3339)
+}
+
+// earlierCertificate returns the certificate that expires first, certificates that can not be parsed are ignored
+func earlierCertificate(current []byte, candidate []byte) []byte {
+	candidateNotAfter, err := tls.CertificateNotAfter(candidate)
+	if err != nil {
+		return current
+	}
+	currentNotAfter, err := tls.CertificateNotAfter(current)
+	if err != nil || candidateNotAfter.Before(currentNotAfter) {
+		return candidate
+	}
+	return current
+}
+
+func certificateStatusesEqual(left []opsterv1.CertificateStatus, right []opsterv1.CertificateStatus) bool {
+	if len(left) != len(right) {
+		return false
+	}
+	sort.Slice(left, func(i, j int) bool { return left[i].Name < left[j].Name })
+	sort.Slice(right, func(i, j int) bool { return right[i].Name < right[j].Name })
+	for i := range left {
+		if left[i].Name != right[i].Name || left[i].Secret != right[i].Secret ||
+			!timesEqual(left[i].NotAfter, right[i].NotAfter) ||
+			!timesEqual(left[i].LastRenewalTime, right[i].LastRenewalTime) {
+			return false
+		}
+	}
+	return true
+}
+
+func timesEqual(left *metav1.Time, right *metav1.Time) bool {
+	if left == nil || right == nil {
+		return left == right
+	}
+	return left.Equal(right)
+}
+
+// certificateCoversDnsNames checks if the certificate contains all DNS names, e.g. after the service name of the cluster was changed.
+// Certificates that can not be parsed are left alone, they are re-issued by the renewal check if necessary.
+func certificateCoversDnsNames(certPEM []byte, dnsNames []string) bool {
+	names, err := tls.CertificateDNSNames(certPEM)
+	if err != nil {
+		return true
+	}
+	for _, dnsName := range dnsNames {
+		if !helpers.ContainsString(names, dnsName) {
+			return false
+		}
+	}
+	return true
+}
+
+// createAndSignCertificate generates a certificate signed by the CA using the key, subject and validity of the configuration
+func createAndSignCertificate(ca tls.Cert, config opsterv1.TlsCertificateConfig, commonName string, orgUnit string, dnsNames []string) (tls.Cert, error) {
+	options, err := helpers.CertificateOptions(config)
+	if err != nil {
+		return nil, err
+	}
+	options.CommonName = commonName
+	options.OrgUnit = orgUnit
+	options.DNSNames = dnsNames
+	options.Validity = certificateValidity(config)
+	return ca.CreateAndSignCertificate(options)
+}
+
+// transportNodesDn returns the DNs of the node certificates of the cluster
+func transportNodesDn(cr *opsterv1.OpenSearchCluster) []string {
+	if cr.Spec.Security == nil || cr.Spec.Security.Tls == nil || cr.Spec.Security.Tls.Transport == nil {
+		return nil
+	}
+	config := cr.Spec.Security.Tls.Transport
+	if !config.Generate && config.CertificateConfig.CertManager == nil {
+		return config.NodesDn
+	}
+	if config.PerNode {
+		return []string{distinguishedName(config.CertificateConfig, cr.Name+"-*", cr.Name)}
+	}
+	return []string{distinguishedName(config.CertificateConfig, cr.Name, cr.Name)}
+}
+
+// distinguishedName returns the DN of certificates generated or issued for the configuration
+func distinguishedName(config opsterv1.TlsCertificateConfig, commonName string, orgUnit string) string {
+	return tls.DistinguishedName(commonName, orgUnit, helpers.CertificateSubject(config))
+}
+
+func (r *TLSReconciler) httpDnsNames() []string {
+	clusterName := r.instance.Name
+	namespace := r.instance.Namespace
+	serviceName := r.instance.Spec.General.ServiceName
+	dnsNames := []string{
+		clusterName,
+		serviceName,
+		builders.DiscoveryServiceName(r.instance),
+		fmt.Sprintf("%s.%s", clusterName, namespace),
+		fmt.Sprintf("%s.%s.svc", clusterName, namespace),
+		fmt.Sprintf("%s.%s.svc.cluster.local", clusterName, namespace),
+	}
+	// The operator and dashboards verify the certificate for the name of the cluster service
+	if serviceName != clusterName {
+		dnsNames = append(dnsNames,
+			fmt.Sprintf("%s.%s", serviceName, namespace),
+			fmt.Sprintf("%s.%s.svc", serviceName, namespace),
+			fmt.Sprintf("%s.%s.svc.cluster.local", serviceName, namespace),
+		)
+	}
+	return dnsNames
+}
+
+func podDnsNames(cr *opsterv1.OpenSearchCluster, podName string) []string {
+	clusterName := cr.Name
+	namespace := cr.Namespace
+	return []string{
+		podName,
+		clusterName,
+		builders.DiscoveryServiceName(cr),
+		fmt.Sprintf("%s.%s", podName, clusterName),
+		fmt.Sprintf("%s.%s", clusterName, namespace),
+		fmt.Sprintf("%s.%s.%s", podName, clusterName, namespace),
+		fmt.Sprintf("%s.%s.svc", clusterName, namespace),
+		fmt.Sprintf("%s.%s.%s.svc", podName, clusterName, namespace),
+		fmt.Sprintf("%s.%s.svc.cluster.local", clusterName, namespace),
+		fmt.Sprintf("%s.%s.%s.svc.cluster.local", podName, clusterName, namespace),
+	}
+}
+
+// mountCertificates mounts a secret with a certificate, if caSecretName is set the CA certificate is taken from that secret
+func mountCertificates(interfaceName string, secretName string, caSecretName string, reconcilerContext *ReconcilerContext) {
+	if caSecretName == "" {
+		mountFolder(interfaceName, "certs", secretName, reconcilerContext)
+		return
+	}
+	mount(interfaceName, "ca", CaCertKey, caSecretName, reconcilerContext)
+	mount(interfaceName, "key", corev1.TLSPrivateKeyKey, secretName, reconcilerContext)
+	mount(interfaceName, "cert", corev1.TLSCertKey, secretName, reconcilerContext)
+}
+
 func mount(interfaceName string, name string, filename string, secretName string, reconcilerContext *ReconcilerContext) {
 	volume := corev1.Volume{Name: interfaceName + "-" + name, VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{SecretName: secretName}}}
 	reconcilerContext.Volumes = append(reconcilerContext.Volumes, volume)
@@ -2,13 +2,19 @@ package reconcilers
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/x509"
+	"encoding/pem"
 	"fmt"
+	"strings"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	opsterv1 "opensearch.opster.io/api/v1"
+	"opensearch.opster.io/pkg/builders"
 	"opensearch.opster.io/pkg/helpers"
+	"opensearch.opster.io/pkg/tls"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -20,6 +26,7 @@ func newTLSReconciler(spec *opsterv1.OpenSearchCluster) (*ReconcilerContext, *TL
 	underTest := NewTLSReconciler(
 		k8sClient,
 		context.Background(),
+		&helpers.MockEventRecorder{},
 		&reconcilerContext,
 		spec,
 	)
@@ -304,4 +311,169 @@ var _ = Describe("TLS Controller", func() {
 		})
 	})
 
+	Context("When checking generated certificates for renewal", func() {
+		It("should renew certificates inside the renewal window", func() {
+			now := time.Now()
+			Expect(CertificateNeedsRenewal(now.Add(time.Hour), 2*time.Hour, now)).To(BeTrue())
+			Expect(CertificateNeedsRenewal(now.Add(-time.Hour), 2*time.Hour, now)).To(BeTrue())
+			Expect(CertificateNeedsRenewal(now.Add(3*time.Hour), 2*time.Hour, now)).To(BeFalse())
+		})
+
+		It("should not renew a new certificate if the renewal window is not shorter than the validity", func() {
+			config := opsterv1.TlsCertificateConfig{Duration: &metav1.Duration{Duration: 720 * time.Hour}}
+			Expect(renewalWindowValid(config)).To(BeFalse())
+			Expect(certificateRenewBefore(config)).To(Equal(240 * time.Hour))
+
+			ca, err := tls.NewPKI().GenerateCA(tls.CertificateOptions{CommonName: "tls-renewal"})
+			Expect(err).ToNot(HaveOccurred())
+			cert, err := ca.CreateAndSignCertificate(tls.CertificateOptions{CommonName: "tls-renewal", OrgUnit: "tls-renewal", Validity: certificateValidity(config)})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(certificateNeedsRenewal(cert.CertData(), ca, config)).To(BeFalse())
+		})
+
+		It("should track the expiry and renewal of certificates", func() {
+			spec := opsterv1.OpenSearchCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "tls-renewal", Namespace: "tls-renewal", UID: "dummyuid"},
+			}
+			reconcilerContext, underTest := newTLSReconciler(&spec)
+			ca, err := tls.NewPKI().GenerateCA(tls.CertificateOptions{CommonName: "tls-renewal"})
+			Expect(err).ToNot(HaveOccurred())
+			cert, err := ca.CreateAndSignCertificate(tls.CertificateOptions{CommonName: "tls-renewal", OrgUnit: "tls-renewal", Validity: time.Hour})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(certificateNeedsRenewal(cert.CertData(), ca, opsterv1.TlsCertificateConfig{})).To(BeTrue())
+
+			secret := corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "tls-renewal-http-cert"}}
+			underTest.trackCertificate("http", &secret, cert.CertData(), true)
+			Expect(reconcilerContext.CertificateRevision).To(BeEmpty())
+			Expect(underTest.certificates).To(HaveLen(1))
+			Expect(underTest.certificates[0].NotAfter.Time).To(BeTemporally("~", time.Now().Add(time.Hour), time.Minute))
+			Expect(underTest.certificates[0].LastRenewalTime).To(BeNil())
+
+			setRenewalAnnotation(&secret)
+			underTest.certificates = nil
+			underTest.trackCertificate("http", &secret, cert.CertData(), true)
+			Expect(reconcilerContext.CertificateRevision).To(ContainSubstring("http="))
+			Expect(underTest.certificates[0].LastRenewalTime).ToNot(BeNil())
+		})
+	})
+
+	Context("When configuring the key and subject of generated certificates", func() {
+		It("should generate ECDSA certificates with the configured subject", func() {
+			config := opsterv1.TlsCertificateConfig{
+				PrivateKey:  &opsterv1.TlsPrivateKeyConfig{Algorithm: "ECDSA", Size: 384},
+				Subject:     &opsterv1.TlsSubjectConfig{Organizations: []string{"Opster"}, Countries: []string{"DE"}},
+				IPAddresses: []string{"10.0.0.1"},
+			}
+			options, err := helpers.CertificateOptions(config)
+			Expect(err).ToNot(HaveOccurred())
+			ca, err := tls.NewPKI().GenerateCA(options)
+			Expect(err).ToNot(HaveOccurred())
+			cert, err := createAndSignCertificate(ca, config, "tls-ecdsa", "tls-ecdsa", []string{"tls-ecdsa.svc"})
+			Expect(err).ToNot(HaveOccurred())
+
+			block, _ := pem.Decode(cert.CertData())
+			parsed, err := x509.ParseCertificate(block.Bytes)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(parsed.PublicKeyAlgorithm).To(Equal(x509.ECDSA))
+			Expect(parsed.PublicKey.(*ecdsa.PublicKey).Curve.Params().BitSize).To(Equal(384))
+			Expect(parsed.Subject.Organization).To(Equal([]string{"Opster"}))
+			Expect(parsed.DNSNames).To(ContainElement("tls-ecdsa.svc"))
+			Expect(parsed.IPAddresses).To(HaveLen(1))
+			Expect(parsed.IPAddresses[0].String()).To(Equal("10.0.0.1"))
+			Expect(distinguishedName(config, "tls-ecdsa", "tls-ecdsa")).To(Equal("CN=tls-ecdsa,OU=tls-ecdsa,O=Opster,C=DE"))
+			Expect(distinguishedName(opsterv1.TlsCertificateConfig{}, "admin", "tls-ecdsa")).To(Equal("CN=admin,OU=tls-ecdsa"))
+		})
+
+		It("should reject invalid key configurations", func() {
+			_, err := helpers.CertificateOptions(opsterv1.TlsCertificateConfig{IPAddresses: []string{"not-an-ip"}})
+			Expect(err).To(HaveOccurred())
+			_, err = tls.NewPKI().GenerateCA(tls.CertificateOptions{Key: tls.KeyOptions{Algorithm: tls.KeyAlgorithmRSA, Size: 1024}})
+			Expect(err).To(HaveOccurred())
+			_, err = tls.NewPKI().GenerateCA(tls.CertificateOptions{Key: tls.KeyOptions{Algorithm: tls.KeyAlgorithmECDSA, Size: 521}})
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("When rotating the generated CA", func() {
+		It("should trust both CAs and re-issue certificates signed by the old CA", func() {
+			clusterName := "tls-rotation"
+			spec := opsterv1.OpenSearchCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: clusterName, Namespace: clusterName, UID: "dummyuid"},
+				Spec: opsterv1.ClusterSpec{
+					General: opsterv1.GeneralConfig{},
+					Security: &opsterv1.Security{Tls: &opsterv1.TlsConfig{
+						Transport: &opsterv1.TlsConfigTransport{Generate: true},
+					}},
+				}}
+			Expect(CreateNamespace(k8sClient, clusterName)).Should(Succeed())
+			_, underTest := newTLSReconciler(&spec)
+			Expect(underTest.usesGeneratedCa()).To(BeTrue())
+
+			pki := tls.NewPKI()
+			oldCa, err := pki.GenerateCA(tls.CertificateOptions{CommonName: clusterName})
+			Expect(err).ToNot(HaveOccurred())
+			newCa, err := pki.GenerateCA(tls.CertificateOptions{CommonName: clusterName})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(underTest.createCaSecret(underTest.caSecretName(), oldCa.SecretDataCA())).Should(Succeed())
+			Expect(underTest.createCaSecret(underTest.nextCaSecretName(), newCa.SecretDataCA())).Should(Succeed())
+
+			Expect(underTest.buildTrustBundle()).Should(Succeed())
+			bundle := underTest.caBundle(oldCa)
+			Expect(string(bundle)).To(ContainSubstring(strings.TrimSpace(string(oldCa.CertData()))))
+			Expect(string(bundle)).To(ContainSubstring(strings.TrimSpace(string(newCa.CertData()))))
+
+			cert, err := createAndSignCertificate(oldCa, opsterv1.TlsCertificateConfig{}, clusterName, clusterName, nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(certificateNeedsRenewal(cert.CertData(), oldCa, opsterv1.TlsCertificateConfig{})).To(BeFalse())
+			Expect(certificateNeedsRenewal(cert.CertData(), newCa, opsterv1.TlsCertificateConfig{})).To(BeTrue())
+
+			Expect(underTest.swapCa()).Should(Succeed())
+			caSecret := corev1.Secret{}
+			Expect(k8sClient.Get(context.Background(), client.ObjectKey{Name: underTest.caSecretName(), Namespace: clusterName}, &caSecret)).Should(Succeed())
+			Expect(caSecret.Data[CaCertKey]).To(Equal(newCa.CertData()))
+			previousSecret := corev1.Secret{}
+			Expect(k8sClient.Get(context.Background(), client.ObjectKey{Name: underTest.previousCaSecretName(), Namespace: clusterName}, &previousSecret)).Should(Succeed())
+			Expect(previousSecret.Data[CaCertKey]).To(Equal(oldCa.CertData()))
+		})
+	})
+
+	Context("When using cert-manager as certificate source", func() {
+		It("should build certificates with the same subject as generated ones", func() {
+			spec := opsterv1.OpenSearchCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "tls-certmanager", Namespace: "tls-certmanager"},
+			}
+			config := opsterv1.TlsCertificateConfig{
+				CertManager: &opsterv1.CertManagerConfig{IssuerRef: opsterv1.CertManagerIssuerRef{Name: "issuer", Kind: "ClusterIssuer"}},
+				Duration:    &metav1.Duration{Duration: 48 * time.Hour},
+			}
+			certificate := builders.NewCertificate(&spec, "tls-certmanager-admin", "tls-certmanager-admin-cert", "admin", "admin", nil, config)
+			Expect(certificate.GetLabels()).To(HaveKeyWithValue(builders.CertificateInterfaceLabel, "admin"))
+			certSpec := certificate.Object["spec"].(map[string]interface{})
+			Expect(certSpec["secretName"]).To(Equal("tls-certmanager-admin-cert"))
+			Expect(certSpec["commonName"]).To(Equal("admin"))
+			Expect(certSpec["duration"]).To(Equal("48h0m0s"))
+			Expect(certSpec).ToNot(HaveKey("dnsNames"))
+			Expect(certSpec["subject"]).To(HaveKeyWithValue("organizationalUnits", ConsistOf("tls-certmanager")))
+			Expect(certSpec["issuerRef"]).To(HaveKeyWithValue("kind", "ClusterIssuer"))
+			Expect(certSpec["issuerRef"]).To(HaveKeyWithValue("group", "cert-manager.io"))
+		})
+
+		It("should fail if cert-manager is not installed", func() {
+			clusterName := "tls-certmanager"
+			spec := opsterv1.OpenSearchCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: clusterName, Namespace: clusterName, UID: "dummyuid"},
+				Spec: opsterv1.ClusterSpec{
+					General: opsterv1.GeneralConfig{},
+					Security: &opsterv1.Security{Tls: &opsterv1.TlsConfig{
+						Http: &opsterv1.TlsConfigHttp{CertificateConfig: opsterv1.TlsCertificateConfig{
+							CertManager: &opsterv1.CertManagerConfig{IssuerRef: opsterv1.CertManagerIssuerRef{Name: "issuer"}},
+						}},
+					}},
+				}}
+			Expect(CreateNamespace(k8sClient, clusterName)).Should(Succeed())
+			_, underTest := newTLSReconciler(&spec)
+			_, err := underTest.Reconcile()
+			Expect(err).To(Equal(errCertManagerNotInstalled))
+		})
+	})
 })
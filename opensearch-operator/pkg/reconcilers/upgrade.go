@@ -73,12 +73,7 @@ func (r *UpgradeReconciler) Reconcile() (ctrl.Result, error) {
 	}
 
 	// If there is work to do create an Opensearch Client
-	username, password, err := helpers.UsernameAndPassword(r.ctx, r.Client, r.instance)
-	if err != nil {
-		return ctrl.Result{}, err
-	}
-
-	clusterClient, err := services.NewOsClusterClient(fmt.Sprintf("https://%s.%s:9200", r.instance.Spec.General.ServiceName, r.instance.Namespace), username, password)
+	clusterClient, err := newOsClientForCluster(r.ctx, r.Client, r.instance)
 	if err != nil {
 		return ctrl.Result{}, err
 	}
@@ -192,8 +187,8 @@ func (r *UpgradeReconciler) findWorkingNodePool() (opsterv1.NodePool, opsterv1.C
 	// First sort node pools
 	var dataNodes, dataAndMasterNodes, otherNodes []opsterv1.NodePool
 	for _, nodePool := range r.instance.Spec.NodePools {
-		if helpers.ContainsString(nodePool.Roles, "data") {
-			if helpers.ContainsString(nodePool.Roles, "master") {
+		if helpers.HasRole(nodePool.Roles, "data") {
+			if helpers.HasManagerRole(nodePool.Roles) {
 				dataAndMasterNodes = append(dataAndMasterNodes, nodePool)
 			} else {
 				dataNodes = append(dataNodes, nodePool)
@@ -302,7 +297,7 @@ func (r *UpgradeReconciler) doDataNodeUpgrade(pool opsterv1.NodePool) error {
 		lg.Info("only 2 data nodes and drain is set, some shards may not drain")
 	}
 
-	ready, err := services.CheckClusterStatusForRestart(r.osClient, r.instance.Spec.General.DrainDataNodes)
+	ready, err := services.CheckClusterStatusForRestart(r.ctx, r.osClient, r.instance.Spec.General.DrainDataNodes)
 	if err != nil {
 		return err
 	}
@@ -335,7 +330,12 @@ func (r *UpgradeReconciler) doDataNodeUpgrade(pool opsterv1.NodePool) error {
 
 	workingPod := builders.WorkingPodForRollingRestart(sts)
 
-	ready, err = services.PreparePodForDelete(r.osClient, workingPod, r.instance.Spec.General.DrainDataNodes, dataCount)
+	allowed, err := podDisruptionAllowed(r.ctx, r.Client, r.instance, sts, workingPod)
+	if err != nil || !allowed {
+		return err
+	}
+
+	ready, err = services.PreparePodForDelete(r.ctx, r.osClient, workingPod, r.instance.Spec.General.DrainDataNodes, dataCount)
 	if err != nil {
 		return err
 	}
@@ -355,7 +355,7 @@ func (r *UpgradeReconciler) doDataNodeUpgrade(pool opsterv1.NodePool) error {
 
 	// If we are draining nodes remove the exclusion after the pod is deleted
 	if r.instance.Spec.General.DrainDataNodes {
-		_, err = services.RemoveExcludeNodeHost(r.osClient, workingPod)
+		_, err = services.RemoveExcludeNodeHost(r.ctx, r.osClient, workingPod)
 		return err
 	}
 
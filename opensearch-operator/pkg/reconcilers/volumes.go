@@ -0,0 +1,260 @@
+package reconcilers
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/utils/pointer"
+	opsterv1 "opensearch.opster.io/api/v1"
+	"opensearch.opster.io/pkg/builders"
+	"opensearch.opster.io/pkg/helpers"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	volumeExpansionComponent = "VolumeExpansion"
+	volumeResizing           = "Resizing"
+	// The volume was expanded and the file system is resized once the pod is restarted
+	volumeFileSystemResizePending = "FileSystemResizePending"
+	volumeResized                 = "Resized"
+
+	defaultStorageClassAnnotation = "storageclass.kubernetes.io/is-default-class"
+)
+
+// reconcileVolumes expands the PVCs of the node pool if its disk size was increased. The volume claim templates of
+// a statefulset cannot be changed, so the statefulset is deleted without deleting its pods and is recreated with the
+// new size. If the storage class or access modes changed the node pool is migrated to a new statefulset instead.
+// Returns true if the statefulset is replaced. Changes that cannot be applied keep the existing templates.
+func (r *ClusterReconciler) reconcileVolumes(nodePool opsterv1.NodePool, existing *appsv1.StatefulSet, desired *appsv1.StatefulSet) (bool, error) {
+	existingClaim, desiredClaim := dataVolumeClaim(existing), dataVolumeClaim(desired)
+	if existingClaim == nil || desiredClaim == nil {
+		return false, r.updateVolumeStatus(existing)
+	}
+	keepTemplates := func() {
+		desired.Spec.VolumeClaimTemplates = existing.Spec.VolumeClaimTemplates
+	}
+
+	if storageChanged(existingClaim, desiredClaim) {
+		keepTemplates()
+		return r.startStorageMigration(nodePool, existing)
+	}
+
+	existingSize := existingClaim.Spec.Resources.Requests[corev1.ResourceStorage]
+	desiredSize := desiredClaim.Spec.Resources.Requests[corev1.ResourceStorage]
+	switch desiredSize.Cmp(existingSize) {
+	case 0:
+		keepTemplates()
+		return false, r.updateVolumeStatus(existing)
+	case -1:
+		r.recorder.Eventf(r.instance, "Warning", "disk", "cannot shrink the volumes of node pool %s from %s to %s, shrinking volumes is not supported", nodePool.Component, existingSize.String(), desiredSize.String())
+		keepTemplates()
+		return false, r.updateVolumeStatus(existing)
+	}
+
+	expandable, err := r.allowsVolumeExpansion(existingClaim.Spec.StorageClassName)
+	if err != nil {
+		return false, err
+	}
+	if !expandable {
+		r.recorder.Eventf(r.instance, "Warning", "disk", "cannot expand the volumes of node pool %s, the storage class does not allow volume expansion", nodePool.Component)
+		keepTemplates()
+		return false, r.updateVolumeStatus(existing)
+	}
+
+	for ordinal := int32(0); ordinal < pointer.Int32Deref(existing.Spec.Replicas, 1); ordinal++ {
+		pvc := corev1.PersistentVolumeClaim{}
+		if err := r.Get(r.ctx, types.NamespacedName{Name: dataClaimName(existing, ordinal), Namespace: existing.Namespace}, &pvc); err != nil {
+			if k8serrors.IsNotFound(err) {
+				continue
+			}
+			return false, err
+		}
+		currentSize := pvc.Spec.Resources.Requests[corev1.ResourceStorage]
+		if currentSize.Cmp(desiredSize) >= 0 {
+			continue
+		}
+		patch := client.MergeFrom(pvc.DeepCopy())
+		if pvc.Spec.Resources.Requests == nil {
+			pvc.Spec.Resources.Requests = corev1.ResourceList{}
+		}
+		pvc.Spec.Resources.Requests[corev1.ResourceStorage] = desiredSize
+		if err := r.Patch(r.ctx, &pvc, patch); err != nil {
+			return false, err
+		}
+	}
+	if err := r.updateVolumeStatus(existing); err != nil {
+		return false, err
+	}
+
+	if err := r.Delete(r.ctx, existing, client.PropagationPolicy(metav1.DeletePropagationOrphan)); err != nil {
+		return false, err
+	}
+	r.recorder.Eventf(r.instance, "Normal", "disk", "expanding the volumes of node pool %s from %s to %s", nodePool.Component, existingSize.String(), desiredSize.String())
+	return true, nil
+}
+
+// startStorageMigration replaces the statefulset of the node pool with a new statefulset with the new storage. The
+// scaler drains and removes the existing statefulset once the new statefulset is ready.
+func (r *ClusterReconciler) startStorageMigration(nodePool opsterv1.NodePool, existing *appsv1.StatefulSet) (bool, error) {
+	for _, migration := range r.instance.Status.StorageMigrations {
+		if migration.Component == nodePool.Component && migration.Source != "" {
+			log.FromContext(r.ctx).Info("storage migration in progress, postponing storage changes", "nodepool", nodePool.Component)
+			return false, nil
+		}
+	}
+
+	target := builders.MigrationStsName(r.instance, &nodePool)
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if err := r.Get(r.ctx, client.ObjectKeyFromObject(r.instance), r.instance); err != nil {
+			return err
+		}
+		var migrations []opsterv1.StorageMigrationStatus
+		for _, migration := range r.instance.Status.StorageMigrations {
+			if migration.Component != nodePool.Component {
+				migrations = append(migrations, migration)
+			}
+		}
+		r.instance.Status.StorageMigrations = append(migrations, opsterv1.StorageMigrationStatus{
+			Component:   nodePool.Component,
+			StatefulSet: target,
+			Source:      existing.Name,
+		})
+		return r.Status().Update(r.ctx, r.instance)
+	})
+	if err != nil {
+		return false, err
+	}
+	r.recorder.Eventf(r.instance, "Normal", "disk", "migrating the storage of node pool %s from statefulset %s to %s", nodePool.Component, existing.Name, target)
+	return true, nil
+}
+
+// allowsVolumeExpansion checks if the storage class, or the default storage class if no class is set, allows expansion
+func (r *ClusterReconciler) allowsVolumeExpansion(storageClassName *string) (bool, error) {
+	if storageClassName != nil && *storageClassName != "" {
+		storageClass := storagev1.StorageClass{}
+		if err := r.Get(r.ctx, types.NamespacedName{Name: *storageClassName}, &storageClass); err != nil {
+			if k8serrors.IsNotFound(err) {
+				return false, nil
+			}
+			return false, err
+		}
+		return pointer.BoolDeref(storageClass.AllowVolumeExpansion, false), nil
+	}
+
+	storageClasses := storagev1.StorageClassList{}
+	if err := r.List(r.ctx, &storageClasses); err != nil {
+		return false, err
+	}
+	for _, storageClass := range storageClasses.Items {
+		if storageClass.Annotations[defaultStorageClassAnnotation] == "true" {
+			return pointer.BoolDeref(storageClass.AllowVolumeExpansion, false), nil
+		}
+	}
+	return false, nil
+}
+
+// updateVolumeStatus reports the resize progress of every PVC of the statefulset while any of them is being resized.
+// Once all PVCs are resized the status is removed.
+func (r *ClusterReconciler) updateVolumeStatus(sts *appsv1.StatefulSet) error {
+	var current []opsterv1.ComponentStatus
+	for _, status := range r.instance.Status.ComponentsStatus {
+		if status.Component == volumeExpansionComponent && strings.HasPrefix(status.Description, fmt.Sprintf("data-%s-", sts.Name)) {
+			current = append(current, status)
+		}
+	}
+
+	var desired []opsterv1.ComponentStatus
+	resizing := false
+	for ordinal := int32(0); ordinal < pointer.Int32Deref(sts.Spec.Replicas, 1); ordinal++ {
+		pvc := corev1.PersistentVolumeClaim{}
+		if err := r.Get(r.ctx, types.NamespacedName{Name: dataClaimName(sts, ordinal), Namespace: sts.Namespace}, &pvc); err != nil {
+			if k8serrors.IsNotFound(err) {
+				continue
+			}
+			return err
+		}
+		status := pvcResizeStatus(pvc)
+		resizing = resizing || status != volumeResized
+		desired = append(desired, opsterv1.ComponentStatus{
+			Component:   volumeExpansionComponent,
+			Status:      status,
+			Description: pvc.Name,
+		})
+	}
+	if !resizing {
+		desired = nil
+	}
+	if reflect.DeepEqual(current, desired) {
+		return nil
+	}
+	if len(current) > 0 && len(desired) == 0 {
+		r.recorder.Eventf(r.instance, "Normal", "disk", "resized the volumes of statefulset %s", sts.Name)
+	}
+
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if err := r.Get(r.ctx, client.ObjectKeyFromObject(r.instance), r.instance); err != nil {
+			return err
+		}
+		for _, status := range current {
+			r.instance.Status.ComponentsStatus = helpers.RemoveIt(status, r.instance.Status.ComponentsStatus)
+		}
+		r.instance.Status.ComponentsStatus = append(r.instance.Status.ComponentsStatus, desired...)
+		return r.Status().Update(r.ctx, r.instance)
+	})
+}
+
+func pvcResizeStatus(pvc corev1.PersistentVolumeClaim) string {
+	requested := pvc.Spec.Resources.Requests[corev1.ResourceStorage]
+	capacity := pvc.Status.Capacity[corev1.ResourceStorage]
+	if capacity.Cmp(requested) >= 0 {
+		return volumeResized
+	}
+	for _, condition := range pvc.Status.Conditions {
+		if condition.Type == corev1.PersistentVolumeClaimFileSystemResizePending && condition.Status == corev1.ConditionTrue {
+			return volumeFileSystemResizePending
+		}
+	}
+	return volumeResizing
+}
+
+func storageChanged(existing *corev1.PersistentVolumeClaim, desired *corev1.PersistentVolumeClaim) bool {
+	if pointer.StringDeref(existing.Spec.StorageClassName, "") != pointer.StringDeref(desired.Spec.StorageClassName, "") {
+		return true
+	}
+	if len(existing.Spec.AccessModes) != len(desired.Spec.AccessModes) {
+		return true
+	}
+	for _, mode := range desired.Spec.AccessModes {
+		found := false
+		for _, existingMode := range existing.Spec.AccessModes {
+			found = found || existingMode == mode
+		}
+		if !found {
+			return true
+		}
+	}
+	return false
+}
+
+func dataVolumeClaim(sts *appsv1.StatefulSet) *corev1.PersistentVolumeClaim {
+	for i, claim := range sts.Spec.VolumeClaimTemplates {
+		if claim.Name == "data" {
+			return &sts.Spec.VolumeClaimTemplates[i]
+		}
+	}
+	return nil
+}
+
+// dataClaimName returns the name of the data PVC of the pod with the ordinal
+func dataClaimName(sts *appsv1.StatefulSet, ordinal int32) string {
+	return fmt.Sprintf("data-%s-%d", sts.Name, ordinal)
+}
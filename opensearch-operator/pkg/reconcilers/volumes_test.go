@@ -0,0 +1,194 @@
+package reconcilers
+
+import (
+	"context"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	opsterv1 "opensearch.opster.io/api/v1"
+	"opensearch.opster.io/pkg/builders"
+	"opensearch.opster.io/pkg/helpers"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("Volume expansion", func() {
+
+	const (
+		clusterName = "volume-expansion"
+	)
+
+	Context("When the disk size of a node pool changes", func() {
+		It("should expand the volumes and recreate the statefulset or reject shrinking", func() {
+			Expect(CreateNamespace(k8sClient, clusterName)).Should(Succeed())
+			Expect(k8sClient.Create(context.Background(), &storagev1.StorageClass{
+				ObjectMeta:           metav1.ObjectMeta{Name: "expandable"},
+				Provisioner:          "example.com/provisioner",
+				AllowVolumeExpansion: pointer.Bool(true),
+			})).Should(Succeed())
+
+			nodePool := opsterv1.NodePool{
+				Component: "data",
+				Replicas:  1,
+				DiskSize:  "10Gi",
+				Roles:     []opsterv1.NodeRole{"data"},
+				Persistence: &opsterv1.PersistenceConfig{PersistenceSource: opsterv1.PersistenceSource{
+					PVC: &opsterv1.PVCSource{
+						StorageClassName: "expandable",
+						AccessModes:      []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+					},
+				}},
+			}
+			spec := opsterv1.OpenSearchCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: clusterName, Namespace: clusterName},
+				Spec: opsterv1.ClusterSpec{
+					General:   opsterv1.GeneralConfig{ServiceName: clusterName},
+					NodePools: []opsterv1.NodePool{nodePool},
+				},
+			}
+			Expect(k8sClient.Create(context.Background(), &spec)).Should(Succeed())
+
+			existing := builders.NewSTSForNodePool("admin", &spec, nodePool, "", nil, nil)
+			Expect(k8sClient.Create(context.Background(), existing)).Should(Succeed())
+			pvc := corev1.PersistentVolumeClaim{
+				ObjectMeta: metav1.ObjectMeta{Name: "data-" + existing.Name + "-0", Namespace: clusterName},
+				Spec:       existing.Spec.VolumeClaimTemplates[0].Spec,
+			}
+			Expect(k8sClient.Create(context.Background(), &pvc)).Should(Succeed())
+
+			reconcilerContext := NewReconcilerContext(spec.Spec.NodePools)
+			underTest := NewClusterReconciler(
+				k8sClient,
+				context.Background(),
+				&helpers.MockEventRecorder{},
+				&reconcilerContext,
+				&spec,
+			)
+
+			By("rejecting a smaller disk size")
+			nodePool.DiskSize = "5Gi"
+			desired := builders.NewSTSForNodePool("admin", &spec, nodePool, "", nil, nil)
+			recreate, err := underTest.reconcileVolumes(nodePool, existing, desired)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(recreate).To(BeFalse())
+			Expect(desired.Spec.VolumeClaimTemplates).To(Equal(existing.Spec.VolumeClaimTemplates))
+
+			By("expanding the volumes for a larger disk size")
+			nodePool.DiskSize = "20Gi"
+			desired = builders.NewSTSForNodePool("admin", &spec, nodePool, "", nil, nil)
+			recreate, err = underTest.reconcileVolumes(nodePool, existing, desired)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(recreate).To(BeTrue())
+
+			Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(&pvc), &pvc)).Should(Succeed())
+			Expect(pvc.Spec.Resources.Requests[corev1.ResourceStorage]).To(Equal(resource.MustParse("20Gi")))
+
+			sts := appsv1.StatefulSet{}
+			err = k8sClient.Get(context.Background(), client.ObjectKeyFromObject(existing), &sts)
+			Expect(err != nil || sts.DeletionTimestamp != nil).To(BeTrue())
+
+			updated := opsterv1.OpenSearchCluster{}
+			Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(&spec), &updated)).Should(Succeed())
+			Expect(updated.Status.ComponentsStatus).To(ContainElement(opsterv1.ComponentStatus{
+				Component:   "VolumeExpansion",
+				Status:      "Resizing",
+				Description: pvc.Name,
+			}))
+		})
+	})
+
+	Context("When the storage class of a node pool changes", func() {
+		It("should migrate the node pool to a new statefulset", func() {
+			migrationName := "storage-migration"
+			Expect(CreateNamespace(k8sClient, migrationName)).Should(Succeed())
+
+			nodePool := opsterv1.NodePool{
+				Component: "data",
+				Replicas:  1,
+				DiskSize:  "10Gi",
+				Roles:     []opsterv1.NodeRole{"data"},
+				Persistence: &opsterv1.PersistenceConfig{PersistenceSource: opsterv1.PersistenceSource{
+					PVC: &opsterv1.PVCSource{
+						StorageClassName: "standard",
+						AccessModes:      []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+					},
+				}},
+			}
+			spec := opsterv1.OpenSearchCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: migrationName, Namespace: migrationName},
+				Spec: opsterv1.ClusterSpec{
+					General:   opsterv1.GeneralConfig{ServiceName: migrationName},
+					NodePools: []opsterv1.NodePool{nodePool},
+				},
+			}
+			Expect(k8sClient.Create(context.Background(), &spec)).Should(Succeed())
+			existing := builders.NewSTSForNodePool("admin", &spec, nodePool, "", nil, nil)
+
+			reconcilerContext := NewReconcilerContext(spec.Spec.NodePools)
+			underTest := NewClusterReconciler(
+				k8sClient,
+				context.Background(),
+				&helpers.MockEventRecorder{},
+				&reconcilerContext,
+				&spec,
+			)
+
+			nodePool.Persistence.PVC.StorageClassName = "fast"
+			desired := builders.NewSTSForNodePool("admin", &spec, nodePool, "", nil, nil)
+			replace, err := underTest.reconcileVolumes(nodePool, existing, desired)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(replace).To(BeTrue())
+			Expect(desired.Spec.VolumeClaimTemplates).To(Equal(existing.Spec.VolumeClaimTemplates))
+
+			Expect(spec.Status.StorageMigrations).To(Equal([]opsterv1.StorageMigrationStatus{{
+				Component:   "data",
+				StatefulSet: migrationName + "-data-b",
+				Source:      migrationName + "-data",
+			}}))
+			Expect(builders.StsName(&spec, &nodePool)).To(Equal(migrationName + "-data-b"))
+			Expect(builders.MigrationStsName(&spec, &nodePool)).To(Equal(migrationName + "-data"))
+			Expect(builders.NewSTSForNodePool("admin", &spec, nodePool, "", nil, nil).Name).To(Equal(migrationName + "-data-b"))
+			// Node certificates are issued for the pods of both statefulsets
+			Expect(builders.NodePoolPodNames(&spec, &nodePool)).To(Equal([]string{migrationName + "-data-b-0", migrationName + "-data-0"}))
+
+			By("removing the volumes of the old statefulset once it is gone")
+			Expect(isDataClaimOf(migrationName+"-data-data-b-0", migrationName+"-data")).To(BeFalse())
+			newClaim := func(name string) *corev1.PersistentVolumeClaim {
+				return &corev1.PersistentVolumeClaim{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      name,
+						Namespace: migrationName,
+						Labels:    map[string]string{builders.ClusterLabel: migrationName, builders.NodePoolLabel: "data"},
+					},
+					Spec: corev1.PersistentVolumeClaimSpec{
+						AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+						Resources: corev1.ResourceRequirements{
+							Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("10Gi")},
+						},
+					},
+				}
+			}
+			oldClaim := newClaim("data-" + migrationName + "-data-0")
+			targetClaim := newClaim("data-" + migrationName + "-data-b-0")
+			Expect(k8sClient.Create(context.Background(), oldClaim)).Should(Succeed())
+			Expect(k8sClient.Create(context.Background(), targetClaim)).Should(Succeed())
+
+			scaler := NewScalerReconciler(k8sClient, context.Background(), &helpers.MockEventRecorder{}, &reconcilerContext, &spec)
+			result, err := scaler.finishStorageMigration(spec.Status.StorageMigrations[0])
+			Expect(err).ToNot(HaveOccurred())
+			// The source is only cleared once the volumes are deleted
+			Expect(result).ToNot(BeNil())
+			err = k8sClient.Get(context.Background(), client.ObjectKeyFromObject(oldClaim), oldClaim)
+			Expect(k8serrors.IsNotFound(err) || oldClaim.DeletionTimestamp != nil).To(BeTrue())
+			Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(targetClaim), targetClaim)).Should(Succeed())
+			Expect(targetClaim.DeletionTimestamp).To(BeNil())
+		})
+	})
+})
@@ -0,0 +1,63 @@
+package reconcilers
+
+import (
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"opensearch.opster.io/pkg/builders"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// reconcilePodZones copies the zone of the Kubernetes node of every scheduled pod into the zone annotation of the
+// pod, the pods wait for it before starting OpenSearch. The operator does not watch the pods of the statefulsets, so
+// the cluster is requeued while pods are not scheduled yet.
+func (r *ClusterReconciler) reconcilePodZones() (*ctrl.Result, error) {
+	if r.instance.Spec.ZoneAwareness == nil {
+		return nil, nil
+	}
+	lg := log.FromContext(r.ctx)
+	pods := corev1.PodList{}
+	if err := r.List(r.ctx, &pods, client.InNamespace(r.instance.Namespace), client.MatchingLabels{builders.ClusterLabel: r.instance.Name}); err != nil {
+		return nil, err
+	}
+	topologyKey := builders.ZoneTopologyKey(r.instance)
+	waiting := false
+	for _, pod := range pods.Items {
+		if pod.Annotations[builders.ZoneAnnotation] != "" {
+			continue
+		}
+		if pod.Spec.NodeName == "" {
+			waiting = true
+			continue
+		}
+		node := corev1.Node{}
+		if err := r.Get(r.ctx, types.NamespacedName{Name: pod.Spec.NodeName}, &node); err != nil {
+			if k8serrors.IsNotFound(err) {
+				continue
+			}
+			return nil, err
+		}
+		zone := node.Labels[topologyKey]
+		if zone == "" {
+			lg.Info("node has no zone label", "node", node.Name, "label", topologyKey)
+			r.recorder.Eventf(r.instance, "Warning", "zone awareness", "node %s of pod %s has no label %s", node.Name, pod.Name, topologyKey)
+			continue
+		}
+		patch := client.MergeFrom(pod.DeepCopy())
+		if pod.Annotations == nil {
+			pod.Annotations = map[string]string{}
+		}
+		pod.Annotations[builders.ZoneAnnotation] = zone
+		if err := r.Patch(r.ctx, &pod, patch); err != nil {
+			return nil, err
+		}
+	}
+	if waiting {
+		return &ctrl.Result{Requeue: true, RequeueAfter: 5 * time.Second}, nil
+	}
+	return nil, nil
+}
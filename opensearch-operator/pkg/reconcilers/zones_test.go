@@ -0,0 +1,100 @@
+package reconcilers
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	opsterv1 "opensearch.opster.io/api/v1"
+	"opensearch.opster.io/pkg/builders"
+	"opensearch.opster.io/pkg/helpers"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("Zone awareness", func() {
+
+	const (
+		clusterName = "zone-awareness"
+	)
+
+	Context("When zone awareness is enabled", func() {
+		It("should spread the pods and annotate them with the zone of their node", func() {
+			Expect(CreateNamespace(k8sClient, clusterName)).Should(Succeed())
+			spec := opsterv1.OpenSearchCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: clusterName, Namespace: clusterName},
+				Spec: opsterv1.ClusterSpec{
+					General:       opsterv1.GeneralConfig{ServiceName: clusterName},
+					NodePools:     []opsterv1.NodePool{{Component: "data", Replicas: 3, Roles: []opsterv1.NodeRole{"data"}}},
+					ZoneAwareness: &opsterv1.ZoneAwareness{ForcedZones: []string{"eu-1a", "eu-1b"}},
+				},
+			}
+
+			sts := builders.NewSTSForNodePool("admin", &spec, spec.Spec.NodePools[0], "", nil, nil)
+			Expect(sts.Spec.Template.Spec.TopologySpreadConstraints).To(HaveLen(1))
+			Expect(sts.Spec.Template.Spec.TopologySpreadConstraints[0].TopologyKey).To(Equal("topology.kubernetes.io/zone"))
+			Expect(sts.Spec.Template.Spec.TopologySpreadConstraints[0].WhenUnsatisfiable).To(Equal(corev1.DoNotSchedule))
+			Expect(sts.Spec.Template.Spec.Containers[0].Command).To(BeEmpty())
+			Expect(sts.Spec.Template.Spec.Containers[0].Env).To(ContainElement(corev1.EnvVar{
+				Name: "node.attr.zone",
+				ValueFrom: &corev1.EnvVarSource{
+					FieldRef: &corev1.ObjectFieldSelector{APIVersion: "v1", FieldPath: "metadata.annotations['opster.io/zone']"},
+				},
+			}))
+
+			settings := desiredClusterSettings(&spec)
+			Expect(settings).To(HaveKeyWithValue("cluster.routing.allocation.awareness.attributes", "zone"))
+			Expect(settings).To(HaveKeyWithValue("cluster.routing.allocation.awareness.force.zone.values", "eu-1a,eu-1b"))
+
+			node := corev1.Node{ObjectMeta: metav1.ObjectMeta{
+				Name:   "zone-awareness-node",
+				Labels: map[string]string{"topology.kubernetes.io/zone": "eu-1a"},
+			}}
+			Expect(k8sClient.Create(context.Background(), &node)).Should(Succeed())
+			pod := corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      clusterName + "-data-0",
+					Namespace: clusterName,
+					Labels:    map[string]string{builders.ClusterLabel: clusterName},
+				},
+				Spec: corev1.PodSpec{
+					NodeName:   node.Name,
+					Containers: []corev1.Container{{Name: "opensearch", Image: "opensearch"}},
+				},
+			}
+			Expect(k8sClient.Create(context.Background(), &pod)).Should(Succeed())
+
+			reconcilerContext := NewReconcilerContext(spec.Spec.NodePools)
+			underTest := NewClusterReconciler(
+				k8sClient,
+				context.Background(),
+				&helpers.MockEventRecorder{},
+				&reconcilerContext,
+				&spec,
+			)
+			result, err := underTest.reconcilePodZones()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result).To(BeNil())
+			Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(&pod), &pod)).Should(Succeed())
+			Expect(pod.Annotations).To(HaveKeyWithValue(builders.ZoneAnnotation, "eu-1a"))
+
+			By("requeueing while a pod is not scheduled")
+			pending := corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      clusterName + "-data-1",
+					Namespace: clusterName,
+					Labels:    map[string]string{builders.ClusterLabel: clusterName},
+				},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "opensearch", Image: "opensearch"}},
+				},
+			}
+			Expect(k8sClient.Create(context.Background(), &pending)).Should(Succeed())
+			result, err = underTest.reconcilePodZones()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result).ToNot(BeNil())
+		})
+	})
+})
@@ -2,6 +2,9 @@ package tls
 
 import (
 	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
 	"crypto/rand"
 	"crypto/rsa"
 	"crypto/tls"
@@ -9,7 +12,10 @@ import (
 	"crypto/x509/pkix"
 	"encoding/asn1"
 	"encoding/pem"
+	"errors"
+	"fmt"
 	"math/big"
+	"net"
 	"time"
 )
 
@@ -17,7 +23,7 @@ import (
 //  and https://github.com/rancher-sandbox/opni-opensearch-operator/blob/main/pkg/pki/pki.go
 
 type PKI interface {
-	GenerateCA(name string) (ca Cert, err error)
+	GenerateCA(options CertificateOptions) (ca Cert, err error)
 	CAFromSecret(data map[string][]byte) Cert
 }
 
@@ -26,7 +32,43 @@ type Cert interface {
 	SecretData(ca Cert) map[string][]byte
 	KeyData() []byte
 	CertData() []byte
-	CreateAndSignCertificate(commonName string, orgUnit string, dnsnames []string) (cert Cert, err error)
+	CreateAndSignCertificate(options CertificateOptions) (cert Cert, err error)
+}
+
+const (
+	KeyAlgorithmRSA   = "RSA"
+	KeyAlgorithmECDSA = "ECDSA"
+
+	defaultRSAKeySize   = 4096
+	defaultECDSAKeySize = 256
+	defaultCAValidity   = 10 * 365 * 24 * time.Hour
+)
+
+// CertificateOptions describes a certificate to generate
+type CertificateOptions struct {
+	CommonName  string
+	OrgUnit     string
+	DNSNames    []string
+	IPAddresses []net.IP
+	// Defaults to ten years for CAs
+	Validity time.Duration
+	Subject  Subject
+	Key      KeyOptions
+}
+
+// Subject contains the optional fields of the certificate subject
+type Subject struct {
+	Organizations []string
+	Countries     []string
+	Localities    []string
+}
+
+// KeyOptions configures the private key of a certificate, defaults to RSA with 4096 bits
+type KeyOptions struct {
+	// RSA or ECDSA
+	Algorithm string
+	// Bits for RSA keys, curve size (256 or 384) for ECDSA keys
+	Size int
 }
 
 // Dummy struct so that PKI interface can be implemented for easier mocking in tests
@@ -43,29 +85,31 @@ type PEMCert struct {
 	keyBytes  []byte
 }
 
-func (pki *PkiImpl) GenerateCA(name string) (ca Cert, err error) {
+func (pki *PkiImpl) GenerateCA(options CertificateOptions) (ca Cert, err error) {
 	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
 	if err != nil {
 		return
 	}
+	validity := options.Validity
+	if validity == 0 {
+		validity = defaultCAValidity
+	}
 	caCertTemplate := &x509.Certificate{
-		SerialNumber: serial,
-		Subject: pkix.Name{
-			CommonName: name,
-		},
+		SerialNumber:          serial,
+		Subject:               subjectName(options),
 		NotBefore:             time.Now(),
-		NotAfter:              time.Now().AddDate(10, 0, 0),
+		NotAfter:              time.Now().Add(validity),
 		IsCA:                  true,
 		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
 		BasicConstraintsValid: true,
 	}
 
-	caPrivateKey, err := rsa.GenerateKey(rand.Reader, 4096)
+	caPrivateKey, err := generateKey(options.Key)
 	if err != nil {
 		return
 	}
 
-	caBytes, err := x509.CreateCertificate(rand.Reader, caCertTemplate, caCertTemplate, &caPrivateKey.PublicKey, caPrivateKey)
+	caBytes, err := x509.CreateCertificate(rand.Reader, caCertTemplate, caCertTemplate, caPrivateKey.Public(), caPrivateKey)
 	if err != nil {
 		return
 	}
@@ -78,11 +122,16 @@ func (pki *PkiImpl) GenerateCA(name string) (ca Cert, err error) {
 		return
 	}
 
+	// RSA keys are kept in PKCS1 format to stay compatible with existing CA secrets
+	caKeyBlock := &pem.Block{Type: "PRIVATE KEY"}
+	if rsaKey, ok := caPrivateKey.(*rsa.PrivateKey); ok {
+		caKeyBlock.Type = "RSA PRIVATE KEY"
+		caKeyBlock.Bytes = x509.MarshalPKCS1PrivateKey(rsaKey)
+	} else if caKeyBlock.Bytes, err = x509.MarshalPKCS8PrivateKey(caPrivateKey); err != nil {
+		return
+	}
 	caKeyPEM := new(bytes.Buffer)
-	err = pem.Encode(caKeyPEM, &pem.Block{
-		Type:  "RSA PRIVATE KEY",
-		Bytes: x509.MarshalPKCS1PrivateKey(caPrivateKey),
-	})
+	err = pem.Encode(caKeyPEM, caKeyBlock)
 	if err != nil {
 		return
 	}
@@ -117,7 +166,7 @@ func (cert *PEMCert) CertData() []byte {
 	return cert.certBytes
 }
 
-func (ca *PEMCert) CreateAndSignCertificate(commonName string, orgUnit string, dnsnames []string) (cert Cert, err error) {
+func (ca *PEMCert) CreateAndSignCertificate(options CertificateOptions) (cert Cert, err error) {
 	tlscacert, err := ca.cert()
 	if err != nil {
 		return
@@ -127,7 +176,7 @@ func (ca *PEMCert) CreateAndSignCertificate(commonName string, orgUnit string, d
 		return
 	}
 
-	keypair, err := rsa.GenerateKey(rand.Reader, 4096)
+	keypair, err := generateKey(options.Key)
 	if err != nil {
 		return
 	}
@@ -139,24 +188,21 @@ func (ca *PEMCert) CreateAndSignCertificate(commonName string, orgUnit string, d
 
 	x509cert := &x509.Certificate{
 		SerialNumber: serial,
-		Subject: pkix.Name{
-			CommonName:         commonName,
-			OrganizationalUnit: []string{orgUnit},
-		},
-		NotBefore:   time.Now(),
-		NotAfter:    time.Now().AddDate(1, 0, 0),
-		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth, x509.ExtKeyUsageServerAuth},
-		KeyUsage:    x509.KeyUsageDigitalSignature,
-	}
-	if len(dnsnames) > 0 {
-		san, err := calculateExtension(commonName, dnsnames)
+		Subject:      subjectName(options),
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(options.Validity),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth, x509.ExtKeyUsageServerAuth},
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+	}
+	if len(options.DNSNames) > 0 || len(options.IPAddresses) > 0 {
+		san, err := calculateExtension(options.DNSNames, options.IPAddresses)
 		if err != nil {
 			return cert, err
 		}
 		x509cert.ExtraExtensions = []pkix.Extension{san}
 	}
 
-	signed, err := x509.CreateCertificate(rand.Reader, x509cert, cacert, &keypair.PublicKey, tlscacert.PrivateKey)
+	signed, err := x509.CreateCertificate(rand.Reader, x509cert, cacert, keypair.Public(), tlscacert.PrivateKey)
 	if err != nil {
 		return
 	}
@@ -193,13 +239,106 @@ func (pki *PkiImpl) CAFromSecret(data map[string][]byte) Cert {
 	return &PEMCert{certBytes: data["ca.crt"], keyBytes: data["ca.key"]}
 }
 
-func calculateExtension(commonName string, dnsNames []string) (pkix.Extension, error) {
+// CertificateNotAfter returns the expiry time of the first certificate in the PEM data
+func CertificateNotAfter(certPEM []byte) (time.Time, error) {
+	cert, err := parseCertificate(certPEM)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return cert.NotAfter, nil
+}
+
+// CertificateDNSNames returns the DNS names of the first certificate in the PEM data
+func CertificateDNSNames(certPEM []byte) ([]string, error) {
+	cert, err := parseCertificate(certPEM)
+	if err != nil {
+		return nil, err
+	}
+	return cert.DNSNames, nil
+}
+
+// CertificateSignedBy checks if the first certificate in certPEM was signed by the first certificate in caPEM
+func CertificateSignedBy(certPEM []byte, caPEM []byte) (bool, error) {
+	cert, err := parseCertificate(certPEM)
+	if err != nil {
+		return false, err
+	}
+	ca, err := parseCertificate(caPEM)
+	if err != nil {
+		return false, err
+	}
+	return cert.CheckSignatureFrom(ca) == nil, nil
+}
+
+func parseCertificate(certPEM []byte) (*x509.Certificate, error) {
+	block, _ := pem.Decode(certPEM)
+	if block == nil || block.Type != "CERTIFICATE" {
+		return nil, errors.New("no certificate found in PEM data")
+	}
+	return x509.ParseCertificate(block.Bytes)
+}
+
+// DistinguishedName returns the DN of a certificate with the given subject in the format used by the security plugin,
+// e.g. for nodes_dn and admin_dn. The common name may contain wildcards.
+func DistinguishedName(commonName string, orgUnit string, subject Subject) string {
+	return subjectName(CertificateOptions{CommonName: commonName, OrgUnit: orgUnit, Subject: subject}).String()
+}
+
+func subjectName(options CertificateOptions) pkix.Name {
+	name := pkix.Name{
+		CommonName:   options.CommonName,
+		Organization: options.Subject.Organizations,
+		Country:      options.Subject.Countries,
+		Locality:     options.Subject.Localities,
+	}
+	if options.OrgUnit != "" {
+		name.OrganizationalUnit = []string{options.OrgUnit}
+	}
+	return name
+}
+
+func generateKey(options KeyOptions) (crypto.Signer, error) {
+	switch options.Algorithm {
+	case "", KeyAlgorithmRSA:
+		size := options.Size
+		if size == 0 {
+			size = defaultRSAKeySize
+		}
+		if size < 2048 {
+			return nil, fmt.Errorf("rsa key size %d is too small, must be at least 2048", size)
+		}
+		return rsa.GenerateKey(rand.Reader, size)
+	case KeyAlgorithmECDSA:
+		size := options.Size
+		if size == 0 {
+			size = defaultECDSAKeySize
+		}
+		switch size {
+		case 256:
+			return ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		case 384:
+			return ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+		default:
+			return nil, fmt.Errorf("unsupported ecdsa key size %d, must be 256 or 384", size)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported key algorithm %s", options.Algorithm)
+	}
+}
+
+func calculateExtension(dnsNames []string, ipAddresses []net.IP) (pkix.Extension, error) {
 	rawValues := []asn1.RawValue{
 		{FullBytes: []byte{0x88, 0x05, 0x2A, 0x03, 0x04, 0x05, 0x05}},
 	}
 	for _, name := range dnsNames {
 		rawValues = append(rawValues, asn1.RawValue{Tag: 2, Class: 2, Bytes: []byte(name)})
 	}
+	for _, ip := range ipAddresses {
+		if ipv4 := ip.To4(); ipv4 != nil {
+			ip = ipv4
+		}
+		rawValues = append(rawValues, asn1.RawValue{Tag: 7, Class: 2, Bytes: ip})
+	}
 	rawByte, err := asn1.Marshal(rawValues)
 	if err != nil {
 		return pkix.Extension{}, err